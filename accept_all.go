@@ -0,0 +1,17 @@
+package emailvalidator
+
+// DefaultAcceptAllDomains are the domains of mailbox providers known to
+// accept mail for any local part during the SMTP dialogue and bounce it
+// later, so that no recipient check can tell their mailboxes apart
+var DefaultAcceptAllDomains = []string{
+	"yahoo.com",
+	"ymail.com",
+	"rocketmail.com",
+	"aol.com",
+	"aim.com",
+}
+
+// checkAcceptAll flags addresses at domains known to accept any local part
+func (v *EmailValidator) checkAcceptAll(domain string, result *ValidationResult) {
+	result.AcceptAll = v.acceptAllDomains.Match(domain)
+}
@@ -0,0 +1,27 @@
+package emailvalidator
+
+import "testing"
+
+func TestAcceptAllDomains(t *testing.T) {
+	v := New(WithAcceptAllDomains())
+	r := v.Validate("jane@mail.yahoo.com")
+	if !r.AcceptAll || r.Reason() != ReasonCatchAll {
+		t.Errorf("yahoo: accept all %v, reasons %v", r.AcceptAll, r.Reasons())
+	}
+	if r := v.Validate("jane@example.com"); r.AcceptAll {
+		t.Error("example.com reported as accepting all")
+	}
+
+	cfg := DefaultConfig()
+	cfg.AcceptAllDomains = []string{"default", "catchall.example"}
+	opts, err := cfg.Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v = New(opts...)
+	for _, email := range []string{"jane@aol.com", "jane@catchall.example"} {
+		if !v.Validate(email).AcceptAll {
+			t.Errorf("%s not reported as accepting all", email)
+		}
+	}
+}
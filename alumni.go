@@ -0,0 +1,33 @@
+package emailvalidator
+
+import "strings"
+
+// alumniDomains lists lifetime forwarding domains that universities give
+// their graduates, beyond those recognized by isAlumniForwarder's naming
+// rule
+var alumniDomains = NewDomainSet(
+	"post.harvard.edu",
+	"cantab.net",
+	"oxon.org",
+)
+
+// alumniLabels are the first labels of alumni forwarding subdomains of
+// universities, as in alumni.duke.edu
+var alumniLabels = map[string]bool{
+	"alumni":  true,
+	"alum":    true,
+	"alumnae": true,
+	"alumnus": true,
+	"grads":   true,
+}
+
+// isAlumniForwarder reports whether the lower-cased domain is a university
+// alumni forwarding domain: a listed one, or an alumni subdomain of an
+// educational domain
+func isAlumniForwarder(lowerDomain string) bool {
+	if alumniDomains.Contains(lowerDomain) {
+		return true
+	}
+	label, parent, ok := strings.Cut(lowerDomain, ".")
+	return ok && alumniLabels[label] && strings.Contains(parent, ".") && organizationType(parent) == OrgEducation
+}
@@ -0,0 +1,25 @@
+package emailvalidator
+
+import "testing"
+
+func TestAlumniForwarder(t *testing.T) {
+	tests := map[string]bool{
+		"alum.mit.edu":        true,
+		"alumni.stanford.edu": true,
+		"alumni.ox.ac.uk":     true,
+		"post.harvard.edu":    true,
+		"cantab.net":          true,
+		"harvard.edu":         false,
+		"alumni.example.com":  false,
+		"alumni.edu":          false,
+	}
+	for domain, want := range tests {
+		if got := isAlumniForwarder(domain); got != want {
+			t.Errorf("isAlumniForwarder(%q) = %v, want %v", domain, got, want)
+		}
+	}
+
+	if r := New().Validate("jane@alum.mit.edu"); !r.AlumniForwarder || !r.IsValid {
+		t.Errorf("alum.mit.edu: forwarder %v, valid %v", r.AlumniForwarder, r.IsValid)
+	}
+}
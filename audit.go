@@ -0,0 +1,219 @@
+package emailvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AuditRecord is one validation in an audit trail. It identifies the
+// address by its salted Hash only, so trails can be kept without holding
+// addresses; see SetHashSalt for hashes comparable across processes.
+type AuditRecord struct {
+	AddressHash string    `json:"address_hash"`
+	Verdict     Verdict   `json:"verdict"`
+	Reasons     []Reason  `json:"reasons,omitempty"`
+	Started     time.Time `json:"started"`
+	Finished    time.Time `json:"finished"`
+}
+
+// Auditor receives a record of every validation made by a validator
+// configured WithAuditor. Implementations must be safe for concurrent use
+// and should return quickly; they report their own failures.
+type Auditor interface {
+	Audit(record AuditRecord)
+}
+
+var (
+	_ Auditor = (*FileAuditor)(nil)
+	_ Auditor = (*HTTPAuditor)(nil)
+)
+
+// newAuditRecord returns the record of validating email into result
+func newAuditRecord(email string, result ValidationResult, started, finished time.Time) AuditRecord {
+	return AuditRecord{
+		AddressHash: Hash(email),
+		Verdict:     result.Verdict,
+		Reasons:     result.Reasons(),
+		Started:     started,
+		Finished:    finished,
+	}
+}
+
+// FileAuditor appends records to a file as JSON lines. Each record is
+// written as it arrives, so a crash loses none that were audited.
+type FileAuditor struct {
+	mu   sync.Mutex
+	file *os.File
+	err  error
+}
+
+// NewFileAuditor opens path for appending, creating it readable by the
+// owner only when it does not exist
+func NewFileAuditor(path string) (*FileAuditor, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileAuditor{file: file}, nil
+}
+
+// Audit implements Auditor. After a failed write it drops records; Err
+// reports the failure.
+func (a *FileAuditor) Audit(record AuditRecord) {
+	line, _ := json.Marshal(record)
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err != nil {
+		return
+	}
+	if _, err := a.file.Write(line); err != nil {
+		a.err = err
+	}
+}
+
+// Err returns the first write error, if any
+func (a *FileAuditor) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// Close closes the file, returning the first write error if any
+func (a *FileAuditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.file.Close(); a.err == nil {
+		a.err = err
+	}
+	return a.err
+}
+
+// DefaultAuditBatchSize is the number of records HTTPAuditor sends per
+// request at most
+const DefaultAuditBatchSize = 100
+
+// auditFlushInterval is how long HTTPAuditor holds a partial batch
+const auditFlushInterval = time.Second
+
+// HTTPAuditor posts records to an endpoint in batches, as a JSON line per
+// record with Content-Type application/x-ndjson. Records are queued and
+// sent in the background so that validations do not wait for the
+// endpoint; a partial batch is sent after a second. When the queue is full
+// records are dropped and counted rather than blocking validation.
+type HTTPAuditor struct {
+	url     string
+	client  *http.Client
+	records chan AuditRecord
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewHTTPAuditor starts an auditor posting to url with client, or with
+// http.DefaultClient when client is nil. Call Close to send the queued
+// records and stop it.
+func NewHTTPAuditor(url string, client *http.Client) *HTTPAuditor {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	a := &HTTPAuditor{
+		url:     url,
+		client:  client,
+		records: make(chan AuditRecord, 10*DefaultAuditBatchSize),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Audit implements Auditor. It must not be called after Close.
+func (a *HTTPAuditor) Audit(record AuditRecord) {
+	select {
+	case a.records <- record:
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of records dropped because the queue was full
+func (a *HTTPAuditor) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Err returns the error of the last request, or nil when it succeeded.
+// The records of a failed request are not retried.
+func (a *HTTPAuditor) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// Close sends the queued records, stops the auditor and returns Err
+func (a *HTTPAuditor) Close() error {
+	close(a.records)
+	<-a.done
+	return a.Err()
+}
+
+func (a *HTTPAuditor) run() {
+	defer close(a.done)
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]AuditRecord, 0, DefaultAuditBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		err := a.post(batch)
+		a.mu.Lock()
+		a.err = err
+		a.mu.Unlock()
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case record, ok := <-a.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) == DefaultAuditBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// post sends one batch
+func (a *HTTPAuditor) post(batch []AuditRecord) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, record := range batch {
+		enc.Encode(record)
+	}
+	resp, err := a.client.Post(a.url, "application/x-ndjson", &body)
+	if err != nil {
+		return fmt.Errorf("audit: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit: endpoint responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFileAuditor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditor, err := NewFileAuditor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := New(WithAuditor(auditor))
+	v.Validate("jane@example.com")
+	v.Validate("webmaster@example.com")
+	v.Validate("jane@@example.com")
+	if err := auditor.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "jane") || strings.Contains(string(data), "webmaster") {
+		t.Errorf("audit log holds addresses:\n%s", data)
+	}
+	var records []AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var record AuditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 3 || records[0].AddressHash != Hash("Jane@example.com") || records[0].Verdict != VerdictValid {
+		t.Fatalf("records %+v", records)
+	}
+	if !reflect.DeepEqual(records[1].Reasons, []Reason{ReasonRoleAccount}) || records[2].Verdict != VerdictInvalid {
+		t.Errorf("records %+v", records)
+	}
+	if records[0].Finished.Before(records[0].Started) {
+		t.Errorf("finished %v before started %v", records[0].Finished, records[0].Started)
+	}
+}
+
+func TestHTTPAuditor(t *testing.T) {
+	var mu sync.Mutex
+	var hashes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("content type %q", r.Header.Get("Content-Type"))
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var record AuditRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			hashes = append(hashes, record.AddressHash)
+			mu.Unlock()
+		}
+	}))
+	defer server.Close()
+
+	auditor := NewHTTPAuditor(server.URL, server.Client())
+	v := New(WithAuditor(auditor))
+	for i := 0; i < DefaultAuditBatchSize+5; i++ {
+		v.Validate("jane@example.com")
+	}
+	if err := auditor.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != DefaultAuditBatchSize+5 || hashes[0] != Hash("jane@example.com") || auditor.Dropped() != 0 {
+		t.Errorf("received %d records, dropped %d", len(hashes), auditor.Dropped())
+	}
+
+	failing := NewHTTPAuditor(server.URL+"/\x00", nil)
+	failing.Audit(AuditRecord{})
+	if err := failing.Close(); err == nil {
+		t.Error("failed request not reported")
+	}
+}
@@ -0,0 +1,86 @@
+package emailvalidator
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+// Performance budget, measured with
+//
+//	go test -run '^$' -bench . -benchmem
+//
+// on a single modern x86-64 core. A change that exceeds a target should
+// explain why in its description.
+//
+//	BenchmarkIsValidSyntax        < 1000 ns/op   0 allocs/op
+//	BenchmarkValidateSyntax       < 1500 ns/op   1 allocs/op
+//	BenchmarkValidateOffline      < 2500 ns/op   1 allocs/op
+//	BenchmarkValidateInvalid      < 3000 ns/op   5 allocs/op
+//	BenchmarkValidateDNS          < 3500 ns/op   8 allocs/op
+//	BenchmarkValidateDNSMemoized  < 2500 ns/op   1 allocs/op
+//
+// The single allocation of a valid address is the result itself.
+
+const benchEmail = "jane.doe+news@mail.example.com"
+
+// staticResolver answers every lookup with one record without touching
+// the network
+type staticResolver struct{}
+
+func (staticResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return []*net.MX{{Host: "mx." + name, Pref: 10}}, nil
+}
+
+func (staticResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.IPv4(192, 0, 2, 1)}}, nil
+}
+
+func BenchmarkIsValidSyntax(b *testing.B) {
+	v := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.IsValidSyntax(benchEmail)
+	}
+}
+
+func BenchmarkValidateSyntax(b *testing.B) {
+	v := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var result ValidationResult
+		v.checkSyntax(benchEmail, &result)
+	}
+}
+
+func BenchmarkValidateOffline(b *testing.B) {
+	v := New(WithDisposableCheck(SeverityWarning))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.Validate(benchEmail)
+	}
+}
+
+func BenchmarkValidateInvalid(b *testing.B) {
+	v := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.Validate("jane..doe@mail.example.com")
+	}
+}
+
+func BenchmarkValidateDNS(b *testing.B) {
+	v := New(WithDNSCheck(NewDNSChecker().WithResolver(staticResolver{})))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.Validate(benchEmail)
+	}
+}
+
+func BenchmarkValidateDNSMemoized(b *testing.B) {
+	v := New(WithDNSCheck(NewDNSChecker().WithResolver(staticResolver{}))).Batch(nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.Validate(benchEmail)
+	}
+}
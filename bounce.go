@@ -0,0 +1,94 @@
+package emailvalidator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BounceCategory is the classification of a bounce diagnostic
+type BounceCategory struct {
+	// Reason is the cause of the bounce in the taxonomy of
+	// ValidationResult.Reasons, or ReasonOther when it cannot be told
+	Reason Reason `json:"reason"`
+	// Hard is set for permanent failures of the address itself, which
+	// warrant suppressing it. Policy rejections and full mailboxes are
+	// soft, since the address may well accept a later message.
+	Hard bool `json:"hard"`
+	// Code is the enhanced status code, such as 5.1.1, or else the SMTP
+	// reply code, such as 550, found in the diagnostic
+	Code string `json:"code,omitempty"`
+}
+
+var (
+	enhancedStatusCode = regexp.MustCompile(`\b[245]\.\d{1,3}\.\d{1,3}\b`)
+	smtpReplyCode      = regexp.MustCompile(`\b[245]\d\d\b`)
+)
+
+// bouncePhrases match the wording of common diagnostics, in the order they
+// are tried: phrases about the mailbox outweigh the status code, which
+// servers often set loosely
+var bouncePhrases = []struct {
+	reason  Reason
+	pattern *regexp.Regexp
+}{
+	{ReasonGreylisted, regexp.MustCompile(`(?i)greylist|graylist`)},
+	{ReasonMailboxNotFound, regexp.MustCompile(`(?i)user unknown|unknown user|no such (user|mailbox|recipient)|(mailbox|account|address|recipient|user)\b[^.;:]{0,40}\b(does not|doesn't) exist|invalid (recipient|mailbox)|recipient not found|mailbox (not found|unavailable)|account (is )?disabled`)},
+	{ReasonNullMX, regexp.MustCompile(`(?i)null mx`)},
+	{ReasonNoMX, regexp.MustCompile(`(?i)domain (not found|does not exist)|host (or domain name )?not found|no mx|nxdomain|unrouteable|unroutable|name or service not known`)},
+	{ReasonMailboxFull, regexp.MustCompile(`(?i)mailbox (is )?full|over ?quota|quota exceeded|insufficient storage`)},
+}
+
+// statusReasons maps the subject and detail of enhanced status codes
+// (RFC 3463) to reasons
+var statusReasons = map[string]Reason{
+	"1.1":  ReasonMailboxNotFound,
+	"1.6":  ReasonMailboxNotFound,
+	"2.1":  ReasonMailboxNotFound,
+	"1.2":  ReasonNoMX,
+	"4.4":  ReasonNoMX,
+	"1.10": ReasonNullMX,
+	"2.2":  ReasonMailboxFull,
+	"4.3":  ReasonDNSUnavailable,
+}
+
+// policyPhrases match diagnostics of messages refused by policy
+var policyPhrases = regexp.MustCompile(`(?i)spam|blocked|block ?list|black ?list|policy|reputation|not authorized|authentication`)
+
+// ClassifyBounce maps a raw SMTP or DSN diagnostic, such as "550 5.1.1
+// <jane@example.com>: Recipient address rejected: User unknown", to a
+// reason of the validator's taxonomy, so that feedback from sending can be
+// merged with the results of validating beforehand
+func ClassifyBounce(diagnostic string) BounceCategory {
+	c := BounceCategory{Reason: ReasonOther, Code: enhancedStatusCode.FindString(diagnostic)}
+	if c.Code == "" {
+		c.Code = smtpReplyCode.FindString(diagnostic)
+	}
+
+	matched := false
+	for _, phrase := range bouncePhrases {
+		if phrase.pattern.MatchString(diagnostic) {
+			c.Reason, matched = phrase.reason, true
+			break
+		}
+	}
+	if !matched && strings.Count(c.Code, ".") == 2 {
+		_, detail, _ := strings.Cut(c.Code, ".")
+		if reason, ok := statusReasons[detail]; ok {
+			c.Reason, matched = reason, true
+		} else if strings.HasPrefix(detail, "7.") {
+			c.Reason, matched = ReasonRejected, true
+		}
+	}
+	if !matched && policyPhrases.MatchString(diagnostic) {
+		c.Reason = ReasonRejected
+	}
+
+	switch c.Reason {
+	case ReasonMailboxNotFound, ReasonNoMX, ReasonNullMX:
+		// A temporary failure code means the server may yet accept it
+		c.Hard = !strings.HasPrefix(c.Code, "4")
+	case ReasonOther:
+		c.Hard = strings.HasPrefix(c.Code, "5")
+	}
+	return c
+}
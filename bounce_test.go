@@ -0,0 +1,32 @@
+package emailvalidator
+
+import "testing"
+
+func TestClassifyBounce(t *testing.T) {
+	tests := []struct {
+		diagnostic string
+		want       BounceCategory
+	}{
+		{"550 5.1.1 <jane@example.com>: Recipient address rejected: User unknown in virtual mailbox table", BounceCategory{ReasonMailboxNotFound, true, "5.1.1"}},
+		{"smtp; 550-5.1.1 The email account that you tried to reach does not exist.", BounceCategory{ReasonMailboxNotFound, true, "5.1.1"}},
+		{"550 No such user here", BounceCategory{ReasonMailboxNotFound, true, "550"}},
+		{"450 4.1.1 <jane@example.com>: Recipient address rejected: unverified address", BounceCategory{ReasonMailboxNotFound, false, "4.1.1"}},
+		{"Host or domain name not found. Name service error for name=gone.example type=MX", BounceCategory{ReasonNoMX, true, ""}},
+		{"5.4.4 Unable to route", BounceCategory{ReasonNoMX, true, "5.4.4"}},
+		{"556 5.1.10 Recipient address has null MX", BounceCategory{ReasonNullMX, true, "5.1.10"}},
+		{"452 4.2.2 The email account that you tried to reach is over quota", BounceCategory{ReasonMailboxFull, false, "4.2.2"}},
+		{"552 5.2.2 Mailbox full", BounceCategory{ReasonMailboxFull, false, "5.2.2"}},
+		{"451 4.7.1 Greylisted, please try again in 300 seconds", BounceCategory{ReasonGreylisted, false, "4.7.1"}},
+		{"550 5.7.1 Message rejected due to sender reputation", BounceCategory{ReasonRejected, false, "5.7.1"}},
+		{"554 Your IP is listed on a blocklist", BounceCategory{ReasonRejected, false, "554"}},
+		{"451 4.4.3 Temporary DNS failure", BounceCategory{ReasonDNSUnavailable, false, "4.4.3"}},
+		{"554 transaction failed", BounceCategory{ReasonOther, true, "554"}},
+		{"421 try again later", BounceCategory{ReasonOther, false, "421"}},
+		{"", BounceCategory{ReasonOther, false, ""}},
+	}
+	for _, tt := range tests {
+		if got := ClassifyBounce(tt.diagnostic); got != tt.want {
+			t.Errorf("ClassifyBounce(%q) = %+v, want %+v", tt.diagnostic, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package emailvalidator
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBreachURL is the base URL of the Have I Been Pwned API
+const DefaultBreachURL = "https://haveibeenpwned.com/api/v3/"
+
+// ErrBreachRateLimited is returned by BreachChecker when the API rejected a
+// request for exceeding the rate limit of the key
+var ErrBreachRateLimited = errors.New("breach lookup rate limited")
+
+// breachPrefixLength is the number of hex digits of the SHA-1 hash sent to
+// the API; the rest of the hash never leaves the process
+const breachPrefixLength = 6
+
+// BreachChecker reports the known data breaches of an address using the
+// Have I Been Pwned range API, which takes the first six hex digits of the
+// SHA-1 hash of the address and returns every breached hash sharing them.
+// Responses are cached per prefix and requests are spaced to stay within
+// the rate limit of the API key. A checker is safe for concurrent use.
+type BreachChecker struct {
+	apiKey   string
+	client   *http.Client
+	baseURL  string
+	timeout  time.Duration
+	interval time.Duration
+	ttl      time.Duration
+	maxSize  int
+
+	limitMu sync.Mutex
+	next    time.Time
+
+	// cache holds the breached suffixes of each prefix
+	cache *ttlCache[string, map[string][]string]
+}
+
+// BreachOption configures a BreachChecker
+type BreachOption func(*BreachChecker)
+
+// WithBreachClient sends requests with client instead of http.DefaultClient
+func WithBreachClient(client *http.Client) BreachOption {
+	return func(b *BreachChecker) {
+		b.client = client
+	}
+}
+
+// WithBreachURL queries baseURL instead of DefaultBreachURL
+func WithBreachURL(baseURL string) BreachOption {
+	return func(b *BreachChecker) {
+		b.baseURL = baseURL
+	}
+}
+
+// WithBreachTimeout sets the timeout of each request, including the time
+// spent waiting for the rate limit. The default is 5 seconds.
+func WithBreachTimeout(timeout time.Duration) BreachOption {
+	return func(b *BreachChecker) {
+		b.timeout = timeout
+	}
+}
+
+// WithBreachRateLimit allows at most perMinute requests per minute, the
+// limit of the API key's plan. The default is 10; zero removes the limit.
+func WithBreachRateLimit(perMinute int) BreachOption {
+	return func(b *BreachChecker) {
+		b.interval = 0
+		if perMinute > 0 {
+			b.interval = time.Minute / time.Duration(perMinute)
+		}
+	}
+}
+
+// WithBreachCache keeps up to size prefix responses for ttl each. The
+// default is 10000 responses for 24 hours; a size of zero disables caching.
+func WithBreachCache(size int, ttl time.Duration) BreachOption {
+	return func(b *BreachChecker) {
+		b.maxSize, b.ttl = size, ttl
+	}
+}
+
+// NewBreachChecker creates a BreachChecker authenticating with apiKey
+func NewBreachChecker(apiKey string, opts ...BreachOption) *BreachChecker {
+	b := &BreachChecker{
+		apiKey:   apiKey,
+		client:   http.DefaultClient,
+		baseURL:  DefaultBreachURL,
+		timeout:  5 * time.Second,
+		interval: time.Minute / 10,
+		ttl:      24 * time.Hour,
+		maxSize:  10000,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.cache = newTTLCache[string, map[string][]string](b.maxSize, b.ttl)
+	return b
+}
+
+// Breaches returns the names of the breaches email appears in, or none
+func (b *BreachChecker) Breaches(ctx context.Context, email string) ([]string, error) {
+	sum := sha1.Sum([]byte(Canonical(email)))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachPrefixLength], hash[breachPrefixLength:]
+
+	if suffixes, ok := b.cache.get(prefix); ok {
+		return suffixes[suffix], nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+	suffixes, err := b.fetch(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	b.cache.add(prefix, suffixes)
+	return suffixes[suffix], nil
+}
+
+// CacheStats implements CacheMetrics for the cache of prefixes looked up
+func (b *BreachChecker) CacheStats() CacheStats {
+	return b.cache.stats()
+}
+
+// fetch requests the breached hashes sharing prefix, keyed by suffix
+func (b *BreachChecker) fetch(ctx context.Context, prefix string) (map[string][]string, error) {
+	if err := b.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(b.baseURL, "/") + "/breachedaccount/range/" + prefix
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("hibp-api-key", b.apiKey)
+	req.Header.Set("User-Agent", "emailvalidator")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("breach lookup: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// The range endpoint answers 404 when no breached hash shares
+		// the prefix
+		return map[string][]string{}, nil
+	case http.StatusTooManyRequests:
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			b.delay(time.Duration(seconds) * time.Second)
+		}
+		return nil, ErrBreachRateLimited
+	default:
+		return nil, fmt.Errorf("breach lookup: unexpected status %s", resp.Status)
+	}
+
+	var matches []struct {
+		HashSuffix string   `json:"hashSuffix"`
+		Websites   []string `json:"websites"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&matches); err != nil {
+		return nil, fmt.Errorf("breach lookup: %v", err)
+	}
+	suffixes := make(map[string][]string, len(matches))
+	for _, m := range matches {
+		suffixes[strings.ToUpper(m.HashSuffix)] = m.Websites
+	}
+	return suffixes, nil
+}
+
+// wait blocks until the rate limit allows another request
+func (b *BreachChecker) wait(ctx context.Context) error {
+	if b.interval <= 0 {
+		return nil
+	}
+	b.limitMu.Lock()
+	now := time.Now()
+	at := b.next
+	if at.Before(now) {
+		at = now
+	}
+	b.next = at.Add(b.interval)
+	b.limitMu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return fmt.Errorf("breach lookup: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// delay holds back further requests for d after the API asked to retry later
+func (b *BreachChecker) delay(d time.Duration) {
+	b.limitMu.Lock()
+	defer b.limitMu.Unlock()
+	if at := time.Now().Add(d); at.After(b.next) {
+		b.next = at
+	}
+}
+
+// checkBreaches records the breaches of the address, leaving Breached nil
+// when the lookup fails
+func (v *EmailValidator) checkBreaches(ctx context.Context, email string, result *ValidationResult) {
+	breaches, err := v.breachChecker.Breaches(ctx, email)
+	if err != nil {
+		return
+	}
+	breached := len(breaches) > 0
+	result.Breached = &breached
+	result.Breaches = breaches
+}
@@ -0,0 +1,109 @@
+package emailvalidator
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreachCheck(t *testing.T) {
+	sum := sha1.Sum([]byte("jane@example.com"))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("hibp-api-key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/breachedaccount/range/"+hash[:6] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `[{"hashSuffix":%q,"websites":["Adobe","LinkedIn"]},{"hashSuffix":"00","websites":["Other"]}]`, hash[6:])
+	}))
+	defer srv.Close()
+	checker := NewBreachChecker("key", WithBreachURL(srv.URL), WithBreachRateLimit(0))
+	v := New(WithBreachCheck(checker))
+
+	r := v.Validate("Jane@Example.com")
+	if r.Breached == nil || !*r.Breached || strings.Join(r.Breaches, ",") != "Adobe,LinkedIn" {
+		t.Fatalf("breached address: %v %v", r.Breached, r.Breaches)
+	}
+	r = v.Validate("nobody@example.com")
+	if r.Breached == nil || *r.Breached || r.Breaches != nil {
+		t.Fatalf("clean address: %v %v", r.Breached, r.Breaches)
+	}
+
+	// Responses are cached per prefix
+	before := requests.Load()
+	v.Validate("jane@example.com")
+	if requests.Load() != before {
+		t.Fatal("cached prefix requested again")
+	}
+
+	// Failed lookups leave the outcome unknown
+	v = v.With(WithBreachCheck(NewBreachChecker("wrong", WithBreachURL(srv.URL), WithBreachRateLimit(0))))
+	if r := v.Validate("jane@example.com"); !r.IsValid || r.Breached != nil {
+		t.Fatalf("failed lookup: %v %v", r.IsValid, r.Breached)
+	}
+}
+
+func TestBreachRateLimit(t *testing.T) {
+	var limited atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limited.Load() {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+	checker := NewBreachChecker("key", WithBreachURL(srv.URL), WithBreachRateLimit(600), WithBreachCache(0, 0))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := checker.Breaches(context.Background(), "jane@example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("3 requests at 600/min took %v", elapsed)
+	}
+
+	limited.Store(true)
+	if _, err := checker.Breaches(context.Background(), "jane@example.com"); !errors.Is(err, ErrBreachRateLimited) {
+		t.Fatalf("got %v", err)
+	}
+	// Retry-After holds back the next request beyond its timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := checker.Breaches(ctx, "jane@example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestBreachUnexpectedStatus(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		checker := NewBreachChecker("key", WithBreachURL(srv.URL), WithBreachRateLimit(0))
+		if _, err := checker.Breaches(context.Background(), "jane@example.com"); err == nil || !strings.Contains(err.Error(), "unexpected status") {
+			t.Errorf("%d: got %v", status, err)
+		}
+		if r := New(WithBreachCheck(checker)).Validate("jane@example.com"); r.Breached != nil {
+			t.Errorf("%d: reported breached %v instead of unknown", status, *r.Breached)
+		}
+		srv.Close()
+	}
+}
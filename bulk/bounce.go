@@ -0,0 +1,194 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// Bounce is one row of a bounce export
+type Bounce struct {
+	Email string
+	// Code is the enhanced status code, such as 5.1.1, or else the SMTP
+	// reply code, such as 550, found in the code or diagnostic column
+	Code       string
+	Diagnostic string
+	// Reason is the cause given by emailvalidator.ClassifyBounce
+	Reason emailvalidator.Reason
+	// Hard is set for permanent failures of the address, from the export's
+	// type column or else from the diagnostic. Bounces that cannot be told
+	// apart are soft, so that nothing is suppressed on a guess.
+	Hard bool
+}
+
+// BounceReport is the outcome of ImportBounces
+type BounceReport struct {
+	Hard int `json:"hard"`
+	Soft int `json:"soft"`
+	// Skipped counts rows without an address
+	Skipped int `json:"skipped"`
+	// Domains holds what the bounces tell about each domain's DNS: false
+	// when mail to it could not be routed, true when its servers answered
+	// for a mailbox. Seed runs with it through WithKnownDomains, or store
+	// it in a DomainCache.
+	Domains map[string]bool `json:"domains,omitempty"`
+}
+
+// bounceColumns lists the header names of each bounce export column, as
+// used by SendGrid, Mailgun, Mailchimp, Postmark and others, lower-cased
+// with underscores and dashes turned into spaces
+var bounceColumns = struct {
+	email, code, diagnostic, kind []string
+}{
+	email:      []string{"email", "email address", "address", "recipient", "to"},
+	code:       []string{"code", "status", "status code", "smtp code", "bounce code", "error code"},
+	diagnostic: []string{"reason", "error", "diagnostic", "diagnostic code", "details", "description", "message", "smtp response", "response"},
+	kind:       []string{"type", "bounce type", "bouncetype", "category"},
+}
+
+// ImportBounces reads a CSV bounce export with a header row, as exported
+// by most email service providers, adding the addresses that bounced hard
+// to list with their emailvalidator.ClassifyBounce reason. Columns are found by their usual
+// names; only the address column is required. list may be nil to only
+// collect the report.
+func ImportBounces(r io.Reader, list *SuppressionList) (BounceReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	report := BounceReport{Domains: make(map[string]bool)}
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		return report, fmt.Errorf("reading CSV header: %w", err)
+	}
+	email, code, diagnostic, kind := -1, -1, -1, -1
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+		switch {
+		case email < 0 && contains(bounceColumns.email, name):
+			email = i
+		case code < 0 && contains(bounceColumns.code, name):
+			code = i
+		case diagnostic < 0 && contains(bounceColumns.diagnostic, name):
+			diagnostic = i
+		case kind < 0 && contains(bounceColumns.kind, name):
+			kind = i
+		}
+	}
+	if email < 0 {
+		return report, errors.New("bounce export has no email column")
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		if err != nil {
+			return report, err
+		}
+		b := parseBounce(field(row, email), field(row, code), field(row, diagnostic), field(row, kind))
+		if !strings.Contains(b.Email, "@") {
+			report.Skipped++
+			continue
+		}
+		report.add(b)
+		if b.Hard && list != nil {
+			reason := string(b.Reason)
+			if b.Reason == emailvalidator.ReasonOther {
+				reason = "hard_bounce"
+			}
+			list.Add(b.Email, reason)
+		}
+	}
+}
+
+// add counts b and records its domain signal
+func (r *BounceReport) add(b Bounce) {
+	if b.Hard {
+		r.Hard++
+	} else {
+		r.Soft++
+	}
+	domain := domainOf(b.Email)
+	if domain == "" {
+		return
+	}
+	switch bounceDomainSignal(b) {
+	case domainAnswered:
+		r.Domains[domain] = true
+	case domainUnroutable:
+		// A server that answered for one mailbox shows the domain works
+		if _, ok := r.Domains[domain]; !ok {
+			r.Domains[domain] = false
+		}
+	}
+}
+
+// parseBounce builds a Bounce from the fields of one row
+func parseBounce(email, code, diagnostic, kind string) Bounce {
+	b := Bounce{Email: strings.TrimSpace(email), Diagnostic: strings.TrimSpace(diagnostic)}
+	category := emailvalidator.ClassifyBounce(strings.TrimSpace(code + " " + b.Diagnostic))
+	b.Code, b.Reason, b.Hard = category.Code, category.Reason, category.Hard
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "hard", "permanent", "hardbounce", "hard bounce":
+		b.Hard = true
+	case "soft", "transient", "softbounce", "soft bounce":
+		b.Hard = false
+	}
+	return b
+}
+
+type domainSignal int
+
+const (
+	domainUnknown domainSignal = iota
+	domainAnswered
+	domainUnroutable
+)
+
+// bounceDomainSignal tells what b says about its domain: mailbox-level
+// reasons are given by a server answering for the domain
+func bounceDomainSignal(b Bounce) domainSignal {
+	switch b.Reason {
+	case emailvalidator.ReasonNoMX, emailvalidator.ReasonNullMX:
+		if b.Hard {
+			return domainUnroutable
+		}
+	case emailvalidator.ReasonMailboxNotFound, emailvalidator.ReasonMailboxFull, emailvalidator.ReasonGreylisted:
+		return domainAnswered
+	}
+	return domainUnknown
+}
+
+func field(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// WithKnownDomains seeds every run with known DNS outcomes per domain, such
+// as BounceReport.Domains, so that those domains are not looked up
+func WithKnownDomains(domains map[string]bool) Option {
+	return func(j *Job) {
+		j.knownDomains = domains
+	}
+}
@@ -0,0 +1,82 @@
+package bulk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestImportBounces(t *testing.T) {
+	export := `created,email,reason,status
+1700000000,jane@example.com,"550 5.1.1 The email account that you tried to reach does not exist",5.1.1
+1700000001,full@example.com,"452 4.2.2 Mailbox full",4.2.2
+1700000002,john@gone.example,"Host or domain name not found. Name service error for name=gone.example type=MX",550
+1700000003,ann@gone.example,"unrouteable address",
+1700000004,,,
+`
+	list := NewSuppressionList()
+	report, err := ImportBounces(strings.NewReader(export), list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Hard != 3 || report.Soft != 1 || report.Skipped != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if reason, ok := list.Lookup("jane@example.com"); !ok || reason != "mailbox_not_found" {
+		t.Errorf("jane: %q, %v", reason, ok)
+	}
+	if reason, ok := list.Lookup("john@gone.example"); !ok || reason != "no_mx" {
+		t.Errorf("john: %q, %v", reason, ok)
+	}
+	if reason, ok := list.Lookup("ann@gone.example"); !ok || reason != "no_mx" {
+		t.Errorf("ann: %q, %v", reason, ok)
+	}
+	if _, ok := list.Lookup("full@example.com"); ok {
+		t.Error("suppressed a soft bounce")
+	}
+	if valid, ok := report.Domains["example.com"]; !ok || !valid {
+		t.Errorf("example.com: %v, %v", valid, ok)
+	}
+	if valid, ok := report.Domains["gone.example"]; !ok || valid {
+		t.Errorf("gone.example: %v, %v", valid, ok)
+	}
+}
+
+func TestImportBouncesType(t *testing.T) {
+	export := "Email,Type,Details\nann@example.com,HardBounce,rejected\nbob@example.com,SoftBounce,550 try later\n"
+	list := NewSuppressionList()
+	report, err := ImportBounces(strings.NewReader(export), list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Hard != 1 || report.Soft != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if reason, ok := list.Lookup("ann@example.com"); !ok || reason != "hard_bounce" {
+		t.Errorf("ann: %q, %v", reason, ok)
+	}
+
+	if _, err := ImportBounces(strings.NewReader("name,code\n"), list); err == nil {
+		t.Error("expected an error for an export without addresses")
+	}
+}
+
+func TestRunKnownDomains(t *testing.T) {
+	resolver := &countingResolver{lookups: make(map[string]int)}
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)))
+
+	var out Collector
+	job := New(v, WithKnownDomains(map[string]bool{"gone.example": false}))
+	if _, err := job.Run(context.Background(), FromSlice([]string{"jane@gone.example", "jane@example.com"}), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Items[0].Result.IsValid || !out.Items[1].Result.IsValid {
+		t.Errorf("results %v, %v", out.Items[0].Result, out.Items[1].Result)
+	}
+	if resolver.lookups["gone.example"] != 0 || resolver.lookups["example.com"] != 1 {
+		t.Errorf("lookups %v", resolver.lookups)
+	}
+}
@@ -0,0 +1,579 @@
+// Package bulk validates large lists of email addresses, streaming records
+// from a Source to a Sink in input order.
+//
+// A run is a pipeline of stages connected by bounded queues: a reader that
+// parses and deduplicates records, a pool of DNS prefetch workers that
+// resolve each domain ahead of time, a pool of validation workers for the
+// CPU-bound checks, and a writer that restores input order. Slow lookups
+// thus overlap with validation instead of stalling the validation workers.
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// Record is one input row of a bulk job
+type Record struct {
+	// Index is the zero-based position of the record in the input
+	Index int64
+	Email string
+	// Fields holds the original input row for tabular sources such as CSV
+	Fields []string
+	// Raw holds the original input object for JSON sources
+	Raw json.RawMessage
+
+	// canonical is set when deduplication is enabled
+	canonical string
+}
+
+// Item is a validated record
+type Item struct {
+	Record
+	Result emailvalidator.ValidationResult
+
+	// Duplicate is set when deduplication is enabled and an earlier record
+	// had the same canonical address; Result is then copied from the record
+	// at index DuplicateOf instead of being validated again.
+	Duplicate   bool
+	DuplicateOf int64
+
+	// Suppressed is set when the address is on the job's suppression list;
+	// Result is then zero and SuppressionReason holds the listed reason.
+	Suppressed        bool
+	SuppressionReason string
+}
+
+// Job runs bulk validations with a fixed configuration. A Job may be run
+// any number of times, including concurrently.
+type Job struct {
+	validator  emailvalidator.Validator
+	workers    int
+	dnsWorkers int
+
+	dedupe      bool
+	groupWindow int
+
+	checkpoint      CheckpointStore
+	checkpointEvery int64
+
+	qps        float64
+	dnsLookups int
+
+	domainCache  DomainCache
+	knownDomains map[string]bool
+	suppressed   *SuppressionList
+
+	sample     int
+	sampleSeed int64
+
+	progress         func(Progress)
+	progressInterval time.Duration
+	total            int64
+
+	drainTimeout time.Duration
+}
+
+// Option configures a Job
+type Option func(*Job)
+
+// WithWorkers sets how many addresses are validated concurrently. The
+// default is GOMAXPROCS.
+func WithWorkers(n int) Option {
+	return func(j *Job) {
+		j.workers = n
+	}
+}
+
+// WithDNSWorkers sets the number of DNS prefetch workers. The default is
+// four times the number of validation workers; a negative value disables
+// the prefetch stage. Prefetching requires a validator implementing both
+// emailvalidator.BatchValidator and emailvalidator.Prefetcher.
+func WithDNSWorkers(n int) Option {
+	return func(j *Job) {
+		j.dnsWorkers = n
+	}
+}
+
+// WithDedupe validates each canonical address once. Later records with the
+// same canonical form reuse the first result and are reported as duplicates.
+// Memory use then grows with the number of unique addresses.
+func WithDedupe() Option {
+	return func(j *Job) {
+		j.dedupe = true
+	}
+}
+
+// WithGroupByDomain buffers up to window records at a time and hands all
+// records of one domain to the same worker, so that domain-level work such
+// as DNS lookups happens once per domain and stays on one connection.
+// Results are still written in input order.
+func WithGroupByDomain(window int) Option {
+	return func(j *Job) {
+		j.groupWindow = window
+	}
+}
+
+// WithRateLimit caps the job at qps validations per second across all
+// workers. Duplicates skipped by WithDedupe do not count.
+func WithRateLimit(qps float64) Option {
+	return func(j *Job) {
+		j.qps = qps
+	}
+}
+
+// WithMaxDNSLookups caps the number of DNS lookups running at once across
+// all workers. It only applies to validators implementing
+// emailvalidator.BatchValidator.
+func WithMaxDNSLookups(n int) Option {
+	return func(j *Job) {
+		j.dnsLookups = n
+	}
+}
+
+// DefaultDrainTimeout is how long Run waits for in-flight validations after
+// its context is cancelled
+const DefaultDrainTimeout = 5 * time.Second
+
+// WithDrainTimeout bounds how long Run waits for in-flight validations once
+// its context is cancelled or a source or sink fails. Validators
+// implementing emailvalidator.ContextValidator stop their lookups right
+// away; others are abandoned after d and finish in the background.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(j *Job) {
+		j.drainTimeout = d
+	}
+}
+
+// New creates a Job that validates addresses with v
+func New(v emailvalidator.Validator, opts ...Option) *Job {
+	j := &Job{
+		validator:        v,
+		workers:          runtime.GOMAXPROCS(0),
+		progressInterval: time.Second,
+		drainTimeout:     DefaultDrainTimeout,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	if j.workers <= 0 {
+		j.workers = 1
+	}
+	if j.dnsWorkers == 0 {
+		j.dnsWorkers = 4 * j.workers
+	}
+	return j
+}
+
+// Run validates every record read from src and writes the results to sink
+// in input order. It returns the summary of the records processed, and stops
+// at the first source or sink error or when ctx is cancelled.
+//
+// On cancellation all stages stop, results completed before the
+// cancellation are still written in order and the sink is flushed, so the
+// output is a prefix of the full result. Run returns within the drain
+// timeout plus the time the sink takes to flush.
+func (j *Job) Run(ctx context.Context, src Source, sink Sink) (Summary, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var population int64
+	if j.sample > 0 {
+		sampled, n, err := sampleSource(ctx, src, j.sample, j.sampleSeed)
+		if err != nil {
+			return Summary{}, err
+		}
+		src, population = sampled, n
+	}
+
+	total := j.total
+	if j.sample > 0 {
+		// WithTotal counts the full input; the sample knows its own size
+		total = 0
+	}
+	tracker := newTracker(total, src)
+	memo := emailvalidator.NewDomainMemo().Limit(j.dnsLookups)
+	limit := newLimiter(j.qps)
+
+	if j.domainCache != nil {
+		domains, err := j.domainCache.Load()
+		if err != nil {
+			return tracker.summary(), fmt.Errorf("loading domain cache: %w", err)
+		}
+		memo.Restore(domains)
+	}
+	memo.Restore(j.knownDomains)
+
+	var offset int64
+	if j.checkpoint != nil {
+		resume, err := j.checkpoint.Load()
+		if err != nil {
+			return tracker.summary(), fmt.Errorf("loading checkpoint: %w", err)
+		}
+		if resume.Done {
+			return tracker.summary(), nil
+		}
+		offset = resume.Processed
+		tracker.resumeAt(offset)
+		memo.Restore(resume.Domains)
+	}
+
+	// Share domain-level checks between all records of this run
+	validator := j.validator
+	var prefetcher emailvalidator.Prefetcher
+	if batcher, ok := validator.(emailvalidator.BatchValidator); ok {
+		validator = batcher.Batch(memo)
+		if p, ok := validator.(emailvalidator.Prefetcher); ok && j.dnsWorkers > 0 {
+			prefetcher = p
+		}
+	}
+
+	// window bounds the number of records in flight, which keeps memory
+	// constant regardless of input size while results are re-ordered
+	inFlight := 2*j.workers + j.groupWindow
+	if prefetcher != nil {
+		inFlight += j.dnsWorkers
+	}
+	window := make(chan struct{}, inFlight)
+	results := make(chan Item)
+
+	// seen maps canonical addresses to the index of their first record
+	seen := make(map[string]int64)
+	batches := make(chan []work)
+
+	var readMu sync.Mutex
+	var readErr error
+	fail := func(err error) {
+		readMu.Lock()
+		readErr = err
+		readMu.Unlock()
+		cancel()
+	}
+	go func() {
+		defer close(batches)
+
+		var buffered []work
+		dispatch := func() bool {
+			for _, batch := range groupByDomain(buffered) {
+				select {
+				case batches <- batch:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			buffered = nil
+			return true
+		}
+
+		for skipped := int64(0); skipped < offset; skipped++ {
+			if _, err := src.Next(); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = fmt.Errorf("source ended after %d records, before checkpoint at %d", skipped, offset)
+				}
+				fail(err)
+				return
+			}
+		}
+
+		for index := offset; ; index++ {
+			record, err := src.Next()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					fail(err)
+					return
+				}
+				dispatch()
+				return
+			}
+			record.Index = index
+
+			next := work{Record: record, duplicateOf: -1}
+			if j.suppressed != nil {
+				next.suppressionReason, next.suppressed = j.suppressed.Lookup(record.Email)
+			}
+			if j.dedupe && !next.suppressed {
+				next.canonical = emailvalidator.Canonical(record.Email)
+				if first, ok := seen[next.canonical]; ok {
+					next.duplicateOf = first
+				} else {
+					seen[next.canonical] = index
+				}
+			}
+
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			if j.groupWindow <= 0 {
+				select {
+				case batches <- []work{next}:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			buffered = append(buffered, next)
+			if len(buffered) >= j.groupWindow && !dispatch() {
+				return
+			}
+		}
+	}()
+
+	var queue <-chan []work = batches
+	if prefetcher != nil {
+		queue = prefetch(ctx, prefetcher, batches, j.dnsWorkers)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(j.workers)
+	for i := 0; i < j.workers; i++ {
+		go func() {
+			defer wg.Done()
+			for batch := range queue {
+				for _, w := range batch {
+					item := Item{Record: w.Record, DuplicateOf: -1}
+					if w.suppressed {
+						item.Suppressed = true
+						item.SuppressionReason = w.suppressionReason
+					} else if w.duplicateOf >= 0 {
+						item.Duplicate = true
+						item.DuplicateOf = w.duplicateOf
+					} else {
+						if err := limit.wait(ctx); err != nil {
+							return
+						}
+						item.Result = validate(ctx, validator, w.Email)
+						if ctx.Err() != nil {
+							// The result may be incomplete; drop it
+							return
+						}
+					}
+					select {
+					case results <- item:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := make(map[int64]Item)
+	// unique holds the results of first occurrences for deduplication
+	unique := make(map[string]emailvalidator.ValidationResult)
+	next := offset
+	var writeErr error
+	lastReport := time.Now()
+
+	saveCheckpoint := func(done bool) error {
+		if f, ok := sink.(flusher); ok {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+		return j.checkpoint.Save(Checkpoint{
+			Processed: next,
+			Done:      done,
+			Domains:   memo.Snapshot(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	handle := func(item Item) {
+		if writeErr != nil {
+			return
+		}
+		pending[item.Index] = item
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-window
+
+			if j.dedupe && !ready.Suppressed {
+				if ready.Duplicate {
+					ready.Result = unique[ready.canonical]
+				} else {
+					unique[ready.canonical] = ready.Result
+				}
+			}
+
+			if err := sink.Write(ready); err != nil {
+				writeErr = err
+				cancel()
+				return
+			}
+			tracker.add(ready)
+
+			if j.checkpoint != nil && j.checkpointEvery > 0 && (next-offset)%j.checkpointEvery == 0 {
+				if err := saveCheckpoint(false); err != nil {
+					writeErr = fmt.Errorf("saving checkpoint: %w", err)
+					cancel()
+					return
+				}
+			}
+		}
+
+		if j.progress != nil && time.Since(lastReport) >= j.progressInterval {
+			j.progress(tracker.snapshot())
+			lastReport = time.Now()
+		}
+	}
+
+	// Once the run is cancelled, keep writing the results that completed
+	// before, but give up on stuck workers after the drain timeout
+	cancelled := ctx.Done()
+	var drain <-chan time.Time
+collect:
+	for {
+		select {
+		case item, ok := <-results:
+			if !ok {
+				break collect
+			}
+			handle(item)
+		case <-cancelled:
+			cancelled = nil
+			timer := time.NewTimer(j.drainTimeout)
+			defer timer.Stop()
+			drain = timer.C
+		case <-drain:
+			break collect
+		}
+	}
+
+	if j.progress != nil {
+		j.progress(tracker.snapshot())
+	}
+	final := tracker.summary()
+	final.DNSCache = memo.CacheStats()
+	if j.sample > 0 {
+		final.Estimate = newEstimate(final, population)
+	}
+
+	if f, ok := sink.(flusher); ok && writeErr == nil {
+		writeErr = f.Flush()
+	}
+	readMu.Lock()
+	defer readMu.Unlock()
+
+	if j.checkpoint != nil && writeErr == nil {
+		done := readErr == nil && ctx.Err() == nil
+		if err := saveCheckpoint(done); err != nil {
+			writeErr = fmt.Errorf("saving checkpoint: %w", err)
+		}
+	}
+	if j.domainCache != nil && writeErr == nil {
+		if err := j.domainCache.Store(memo.Snapshot()); err != nil {
+			writeErr = fmt.Errorf("storing domain cache: %w", err)
+		}
+	}
+
+	switch {
+	case readErr != nil:
+		return final, readErr
+	case writeErr != nil:
+		return final, writeErr
+	default:
+		return final, ctx.Err()
+	}
+}
+
+// validate validates email with v, cancelling its network checks with ctx
+// when v supports it
+func validate(ctx context.Context, v emailvalidator.Validator, email string) emailvalidator.ValidationResult {
+	if cv, ok := v.(emailvalidator.ContextValidator); ok {
+		return cv.ValidateContext(ctx, email)
+	}
+	return v.Validate(email)
+}
+
+// prefetch runs the DNS prefetch stage: workers resolve the domains of
+// each batch before passing it on to the returned queue. The prefetcher
+// skips addresses failing the syntax stage.
+func prefetch(ctx context.Context, prefetcher emailvalidator.Prefetcher, in <-chan []work, workers int) <-chan []work {
+	out := make(chan []work, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for batch := range in {
+				for _, w := range batch {
+					if w.duplicateOf < 0 && !w.suppressed {
+						prefetcher.Prefetch(ctx, w.Email)
+					}
+				}
+				select {
+				case out <- batch:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// work is a record scheduled for validation
+type work struct {
+	Record
+	// duplicateOf is the index of the first record with the same canonical
+	// address, or -1
+	duplicateOf int64
+
+	suppressed        bool
+	suppressionReason string
+}
+
+// groupByDomain splits records into one batch per domain, ordered by the
+// first appearance of each domain
+func groupByDomain(records []work) [][]work {
+	var order []string
+	groups := make(map[string][]work)
+	for _, w := range records {
+		domain := domainOf(w.Email)
+		if _, ok := groups[domain]; !ok {
+			order = append(order, domain)
+		}
+		groups[domain] = append(groups[domain], w)
+	}
+
+	batches := make([][]work, len(order))
+	for i, domain := range order {
+		batches[i] = groups[domain]
+	}
+	return batches
+}
+
+// domainOf returns the lower-cased domain of email, or "" without one
+func domainOf(email string) string {
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		return strings.ToLower(email[at+1:])
+	}
+	return ""
+}
@@ -0,0 +1,266 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/testutil"
+)
+
+func testEmails(n int) []string {
+	emails := make([]string, n)
+	for i := range emails {
+		if i%3 == 0 {
+			emails[i] = fmt.Sprintf("invalid-%d", i)
+		} else {
+			emails[i] = fmt.Sprintf("user%d@example.com", i)
+		}
+	}
+	return emails
+}
+
+func TestRunPreservesOrder(t *testing.T) {
+	emails := testEmails(200)
+	var out Collector
+
+	progress, err := New(emailvalidator.New(), WithWorkers(8)).Run(context.Background(), FromSlice(emails), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Items) != len(emails) || progress.Processed != int64(len(emails)) {
+		t.Fatalf("expected %d items, got %d (progress %d)", len(emails), len(out.Items), progress.Processed)
+	}
+	for i, item := range out.Items {
+		if item.Index != int64(i) || item.Email != emails[i] {
+			t.Fatalf("item %d out of order: %+v", i, item.Record)
+		}
+	}
+}
+
+func TestRunReportsProgress(t *testing.T) {
+	emails := testEmails(30)
+	var reports []Progress
+
+	job := New(emailvalidator.New(), WithProgress(func(p Progress) {
+		reports = append(reports, p)
+	}, time.Nanosecond))
+	if _, err := job.Run(context.Background(), FromSlice(emails), &Collector{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) == 0 {
+		t.Fatal("expected progress reports")
+	}
+	final := reports[len(reports)-1]
+	if final.Processed != 30 || final.Total != 30 || final.ETA != 0 {
+		t.Errorf("unexpected final progress: %+v", final)
+	}
+	if final.Verdicts[emailvalidator.VerdictInvalid] != 10 || final.Verdicts[emailvalidator.VerdictValid] != 20 {
+		t.Errorf("unexpected verdict tallies: %v", final.Verdicts)
+	}
+}
+
+func TestRunStopsOnSinkError(t *testing.T) {
+	failure := errors.New("disk full")
+	sink := SinkFunc(func(item Item) error {
+		if item.Index == 5 {
+			return failure
+		}
+		return nil
+	})
+
+	progress, err := New(emailvalidator.New()).Run(context.Background(), FromSlice(testEmails(100)), sink)
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected sink error, got %v", err)
+	}
+	if progress.Processed != 5 {
+		t.Errorf("expected 5 processed records, got %d", progress.Processed)
+	}
+}
+
+func TestRunDedupe(t *testing.T) {
+	emails := []string{"User@Example.com", "other@example.com", " user@example.com", "invalid", "USER@example.com", "INVALID"}
+
+	validated := 0
+	v := emailvalidator.ValidatorFunc(func(email string) emailvalidator.ValidationResult {
+		validated++
+		return emailvalidator.New().Validate(email)
+	})
+
+	var out Collector
+	progress, err := New(v, WithWorkers(1), WithDedupe()).Run(context.Background(), FromSlice(emails), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if validated != 3 {
+		t.Errorf("expected 3 validations, got %d", validated)
+	}
+	if !out.Items[2].Duplicate || out.Items[2].DuplicateOf != 0 || !out.Items[2].Result.IsValid {
+		t.Errorf("expected item 2 to reuse the result of item 0, got %+v", out.Items[2])
+	}
+	if out.Items[5].Result.IsValid || out.Items[5].DuplicateOf != 3 {
+		t.Errorf("expected item 5 to reuse the result of item 3, got %+v", out.Items[5])
+	}
+
+	if progress.Duplicates != 3 || len(progress.DuplicateGroups) != 2 {
+		t.Fatalf("unexpected duplicate report: %+v", progress)
+	}
+	group := progress.DuplicateGroups[0]
+	if group.Canonical != "user@example.com" || len(group.Indexes) != 3 || group.Indexes[0] != 0 {
+		t.Errorf("unexpected first group: %+v", group)
+	}
+}
+
+// countingResolver answers every lookup with one MX record and counts MX
+// queries per domain
+type countingResolver struct {
+	mu      sync.Mutex
+	lookups map[string]int
+}
+
+func (r *countingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups[name]++
+	return []*net.MX{{Host: "mx." + name, Pref: 10}}, nil
+}
+
+func (r *countingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.IPv4(192, 0, 2, 1)}}, nil
+}
+
+func TestRunLooksUpEachDomainOnce(t *testing.T) {
+	var emails []string
+	for i := 0; i < 60; i++ {
+		emails = append(emails, fmt.Sprintf("user%d@domain%d.com", i, i%4))
+	}
+
+	resolver := &countingResolver{lookups: make(map[string]int)}
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)))
+
+	var out Collector
+	job := New(v, WithWorkers(4), WithGroupByDomain(16))
+	if _, err := job.Run(context.Background(), FromSlice(emails), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resolver.lookups) != 4 {
+		t.Fatalf("expected lookups for 4 domains, got %v", resolver.lookups)
+	}
+	for domain, n := range resolver.lookups {
+		if n != 1 {
+			t.Errorf("%s looked up %d times", domain, n)
+		}
+	}
+	for i, item := range out.Items {
+		if item.Index != int64(i) || item.Result.DNS.Status != emailvalidator.StatusPassed {
+			t.Fatalf("unexpected item %d: %+v", i, item)
+		}
+	}
+}
+
+func TestRunRateLimit(t *testing.T) {
+	emails := testEmails(10)
+
+	start := time.Now()
+	job := New(emailvalidator.New(), WithWorkers(4), WithRateLimit(100))
+	if _, err := job.Run(context.Background(), FromSlice(emails), &Collector{}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("10 validations at 100/s took only %v", elapsed)
+	}
+}
+
+// slowResolver answers after a delay and records the peak number of
+// concurrent MX lookups
+type slowResolver struct {
+	mu           sync.Mutex
+	active, peak int
+}
+
+func (r *slowResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.mu.Lock()
+	r.active++
+	if r.active > r.peak {
+		r.peak = r.active
+	}
+	r.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	r.mu.Lock()
+	r.active--
+	r.mu.Unlock()
+	return []*net.MX{{Host: "mx." + name, Pref: 10}}, nil
+}
+
+func (r *slowResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.IPv4(192, 0, 2, 1)}}, nil
+}
+
+func TestRunMaxDNSLookups(t *testing.T) {
+	var emails []string
+	for i := 0; i < 40; i++ {
+		emails = append(emails, fmt.Sprintf("user@domain%d.com", i))
+	}
+
+	resolver := &slowResolver{}
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)))
+	job := New(v, WithWorkers(8), WithMaxDNSLookups(2))
+	if _, err := job.Run(context.Background(), FromSlice(emails), &Collector{}); err != nil {
+		t.Fatal(err)
+	}
+	if resolver.peak > 2 {
+		t.Fatalf("expected at most 2 concurrent lookups, saw %d", resolver.peak)
+	}
+}
+
+func TestRunPrefetchesDNS(t *testing.T) {
+	var emails []string
+	for i := 0; i < 16; i++ {
+		emails = append(emails, fmt.Sprintf("user@domain%d.com", i))
+	}
+
+	resolver := &slowResolver{}
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)))
+
+	// A single validation worker would look domains up one at a time
+	var out Collector
+	job := New(v, WithWorkers(1), WithDNSWorkers(8))
+	if _, err := job.Run(context.Background(), FromSlice(emails), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if resolver.peak < 2 {
+		t.Fatalf("expected overlapping lookups from the prefetch stage, peak was %d", resolver.peak)
+	}
+	for i, item := range out.Items {
+		if item.Index != int64(i) || item.Result.DNS.Status != emailvalidator.StatusPassed {
+			t.Fatalf("unexpected item %d: %+v", i, item)
+		}
+	}
+}
+
+func TestRunPrefetchSkipsInvalidAddresses(t *testing.T) {
+	resolver := testutil.NewResolver()
+	v := emailvalidator.New(
+		emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)),
+		emailvalidator.WithBlockedDomains([]string{"blocked.example"}),
+	)
+	emails := []string{"nope@", "x@-x-.com", "jane..doe@example.com", "jane@blocked.example"}
+	if _, err := New(v, WithDNSWorkers(4)).Run(context.Background(), FromSlice(emails), &Collector{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := resolver.Lookups(); n != 0 {
+		t.Fatalf("expected no lookups for addresses failing syntax, saw %d", n)
+	}
+}
@@ -0,0 +1,97 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// blockingResolver answers the first lookups and then blocks until the
+// lookup context is done
+type blockingResolver struct {
+	answered, limit int32
+}
+
+func (r *blockingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if atomic.AddInt32(&r.answered, 1) <= r.limit {
+		return []*net.MX{{Host: "mx." + name, Pref: 10}}, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (r *blockingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRunCancelStopsLookups(t *testing.T) {
+	var emails []string
+	for i := 0; i < 100; i++ {
+		emails = append(emails, fmt.Sprintf("user@domain%d.com", i))
+	}
+
+	resolver := &blockingResolver{limit: 10}
+	checker := emailvalidator.NewDNSChecker().WithResolver(resolver).WithTimeout(time.Minute)
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(checker))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	var out Collector
+	start := time.Now()
+	_, err := New(v, WithWorkers(4)).Run(ctx, FromSlice(emails), &out)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Run took %v to return after cancellation", elapsed)
+	}
+
+	// Only results completed before the cancellation are written, in order
+	for i, item := range out.Items {
+		if item.Index != int64(i) || item.Result.DNS.Status != emailvalidator.StatusPassed {
+			t.Fatalf("unexpected item %d: %+v", i, item.Result.DNS)
+		}
+	}
+	if len(out.Items) > 10 {
+		t.Fatalf("expected at most 10 completed items, got %d", len(out.Items))
+	}
+}
+
+func TestRunDrainTimeoutAbandonsStuckValidators(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	emails := testEmails(20)
+	completed := map[string]bool{emails[0]: true, emails[1]: true, emails[2]: true}
+	stuck := emailvalidator.ValidatorFunc(func(email string) emailvalidator.ValidationResult {
+		// Workers may pick records in any order, so block by record
+		if !completed[email] {
+			<-release
+		}
+		return emailvalidator.ValidationResult{IsValid: true, Verdict: emailvalidator.VerdictValid}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	var out Collector
+	start := time.Now()
+	_, err := New(stuck, WithWorkers(2), WithDrainTimeout(100*time.Millisecond)).Run(ctx, FromSlice(emails), &out)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Run took %v despite a 100ms drain timeout", elapsed)
+	}
+	if len(out.Items) != 3 {
+		t.Fatalf("expected the 3 completed items to be flushed, got %d", len(out.Items))
+	}
+}
@@ -0,0 +1,101 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Checkpoint records how far a bulk job got, so that an interrupted run can
+// resume where it stopped
+type Checkpoint struct {
+	// Processed is the number of input records written to the sink
+	Processed int64 `json:"processed"`
+	// Done is set once the whole input has been processed
+	Done bool `json:"done"`
+	// Domains holds the cached DNS outcome per domain
+	Domains   map[string]bool `json:"domains,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CheckpointStore persists checkpoints. Load returns a zero Checkpoint when
+// nothing has been saved yet.
+type CheckpointStore interface {
+	Load() (Checkpoint, error)
+	Save(Checkpoint) error
+}
+
+// FileCheckpoint stores checkpoints as JSON in a file, replacing it
+// atomically on every save
+type FileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint returns a CheckpointStore backed by the file at path
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load implements CheckpointStore
+func (f *FileCheckpoint) Load() (Checkpoint, error) {
+	var cp Checkpoint
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	err = json.Unmarshal(data, &cp)
+	return cp, err
+}
+
+// Save implements CheckpointStore
+func (f *FileCheckpoint) Save(cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(f.path, data)
+}
+
+// writeFileAtomic replaces the file at path with data, so readers never see
+// a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// WithCheckpoint saves progress to store after every `every` records and
+// when the run ends, and resumes from the stored checkpoint when the job
+// starts. The source must produce the same records in the same order on
+// every run and the sink should append to earlier output; records already
+// processed are read from the source and skipped, and records written
+// after the last saved checkpoint are written again. Deduplication state is
+// not persisted, so duplicates of records from an earlier run are not
+// detected, and the returned Summary only covers the current run.
+func WithCheckpoint(store CheckpointStore, every int64) Option {
+	return func(j *Job) {
+		j.checkpoint = store
+		j.checkpointEvery = every
+	}
+}
@@ -0,0 +1,72 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	var emails []string
+	for i := 0; i < 40; i++ {
+		emails = append(emails, fmt.Sprintf("user%d@domain%d.com", i, i%4))
+	}
+
+	resolver := &countingResolver{lookups: make(map[string]int)}
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)))
+	store := NewFileCheckpoint(filepath.Join(t.TempDir(), "job.checkpoint"))
+	job := New(v, WithWorkers(4), WithCheckpoint(store, 5))
+
+	// Interrupt the first run with a sink failure after 12 records
+	var first Collector
+	failing := SinkFunc(func(item Item) error {
+		if len(first.Items) == 12 {
+			return errors.New("disk full")
+		}
+		return first.Write(item)
+	})
+	if _, err := job.Run(context.Background(), FromSlice(emails), failing); err == nil {
+		t.Fatal("expected the first run to fail")
+	}
+
+	cp, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Done || cp.Processed != 10 || len(cp.Domains) != 4 {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+
+	var second Collector
+	summary, err := job.Run(context.Background(), FromSlice(emails), &second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Processed != int64(len(emails))-cp.Processed {
+		t.Fatalf("expected %d records in the resumed run, got %d", int64(len(emails))-cp.Processed, summary.Processed)
+	}
+	for i, item := range second.Items {
+		if item.Index != cp.Processed+int64(i) || item.Email != emails[item.Index] {
+			t.Fatalf("unexpected item %d: %+v", i, item.Record)
+		}
+	}
+	for domain, n := range resolver.lookups {
+		if n != 1 {
+			t.Errorf("%s looked up %d times", domain, n)
+		}
+	}
+
+	if cp, err = store.Load(); err != nil || !cp.Done || cp.Processed != int64(len(emails)) {
+		t.Fatalf("expected a finished checkpoint, got %+v (%v)", cp, err)
+	}
+
+	// A finished job does nothing when run again
+	var third Collector
+	if _, err := job.Run(context.Background(), FromSlice(emails), &third); err != nil || len(third.Items) != 0 {
+		t.Fatalf("expected no work after completion, got %d items (%v)", len(third.Items), err)
+	}
+}
@@ -0,0 +1,134 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// CSVSource streams records from CSV data whose first row is a header
+type CSVSource struct {
+	mu          sync.Mutex
+	reader      *csv.Reader
+	input       *boundedReader
+	emailColumn string
+	header      []string
+	column      int
+}
+
+// FromCSV returns a Source reading CSV rows from r and validating the column
+// named emailColumn. The original row is kept in Record.Fields.
+func FromCSV(r io.Reader, emailColumn string) *CSVSource {
+	// Allow for the CSV reader's read-ahead buffer on top of one record
+	input := &boundedReader{reader: r, limit: MaxRecordBytes + 64<<10}
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = false
+	return &CSVSource{reader: reader, input: input, emailColumn: emailColumn, column: -1}
+}
+
+// Header returns the header row, once the first record has been read
+func (s *CSVSource) Header() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header
+}
+
+// Next implements Source
+func (s *CSVSource) Next() (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.header == nil {
+		header, err := s.reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return Record{}, io.EOF
+			}
+			return Record{}, fmt.Errorf("reading CSV header: %w", err)
+		}
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), s.emailColumn) {
+				s.column = i
+				break
+			}
+		}
+		if s.column < 0 {
+			return Record{}, fmt.Errorf("CSV header has no %q column", s.emailColumn)
+		}
+		s.header = header
+	}
+
+	s.input.mark()
+	start := s.reader.InputOffset()
+	row, err := s.reader.Read()
+	if err != nil {
+		return Record{}, err
+	}
+	if s.reader.InputOffset()-start > MaxRecordBytes {
+		return Record{}, ErrRecordTooLarge
+	}
+	record := Record{Fields: row}
+	if s.column < len(row) {
+		record.Email = row[s.column]
+	}
+	return record, nil
+}
+
+// CSVColumns are the columns appended by CSVSink
+var CSVColumns = []string{"verdict", "score", "reason"}
+
+// CSVSink writes each input row followed by the verdict, score and reason
+// columns. Suppressed records have the verdict "suppressed", no score and
+// the suppression reason.
+type CSVSink struct {
+	writer      *csv.Writer
+	source      *CSVSource
+	wroteHeader bool
+}
+
+// ToCSV returns a Sink writing the rows read by source to w, augmented with
+// CSVColumns
+func ToCSV(w io.Writer, source *CSVSource) *CSVSink {
+	return &CSVSink{writer: csv.NewWriter(w), source: source}
+}
+
+// Write implements Sink
+func (s *CSVSink) Write(item Item) error {
+	if !s.wroteHeader {
+		header := append(append([]string(nil), s.source.Header()...), CSVColumns...)
+		if err := s.writer.Write(header); err != nil {
+			return err
+		}
+		s.wroteHeader = true
+	}
+
+	row := append([]string(nil), item.Fields...)
+	if item.Suppressed {
+		row = append(row, "suppressed", "", item.SuppressionReason)
+	} else {
+		row = append(row,
+			string(item.Result.Verdict),
+			strconv.FormatFloat(item.Result.Score, 'f', -1, 64),
+			Reason(item.Result),
+		)
+	}
+	return s.writer.Write(row)
+}
+
+// Flush writes any buffered data to the underlying writer
+func (s *CSVSink) Flush() error {
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+// Reason returns the most significant reason explaining a result, or an
+// empty string
+func Reason(result emailvalidator.ValidationResult) string {
+	return string(result.Reason())
+}
@@ -0,0 +1,39 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	input := "id,Email,name\n" +
+		"1,jane@example.com,Jane\n" +
+		"2,invalid-email,Bob\n" +
+		"3,\"admin@example.com\",\"Admin, Team\"\n"
+
+	src := FromCSV(strings.NewReader(input), "email")
+	var out bytes.Buffer
+	if _, err := New(emailvalidator.New(), WithWorkers(2)).Run(context.Background(), src, ToCSV(&out, src)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,Email,name,verdict,score,reason\n" +
+		"1,jane@example.com,Jane,valid,100,\n" +
+		"2,invalid-email,Bob,invalid,0,invalid_syntax\n" +
+		"3,admin@example.com,\"Admin, Team\",valid,80,role_account\n"
+	if out.String() != want {
+		t.Errorf("unexpected CSV output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestCSVMissingColumn(t *testing.T) {
+	src := FromCSV(strings.NewReader("id,name\n1,Jane\n"), "email")
+	_, err := New(emailvalidator.New()).Run(context.Background(), src, &Collector{})
+	if err == nil || !strings.Contains(err.Error(), "email") {
+		t.Errorf("expected a missing column error, got %v", err)
+	}
+}
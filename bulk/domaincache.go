@@ -0,0 +1,134 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// DomainCache persists the DNS outcome of each domain between runs, so that
+// repeated runs over the same list do not look every domain up again
+type DomainCache interface {
+	// Load returns the cached outcome per domain
+	Load() (map[string]bool, error)
+	// Store records the outcomes known at the end of a run
+	Store(domains map[string]bool) error
+}
+
+// WithDomainCache seeds each run with the outcomes in cache and stores the
+// outcomes known at the end of the run back into it
+func WithDomainCache(cache DomainCache) Option {
+	return func(j *Job) {
+		j.domainCache = cache
+	}
+}
+
+// DiskCache is a DomainCache kept in a local JSON file. Entries older than
+// its TTL are ignored and dropped on the next Store. Concurrent runs sharing
+// one file do not corrupt it, but the last run to finish wins.
+type DiskCache struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]diskEntry
+	evictions uint64
+}
+
+type diskEntry struct {
+	Valid     bool      `json:"valid"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// NewDiskCache returns a DiskCache stored at path whose entries stay valid
+// for ttl. A ttl of zero keeps entries forever.
+func NewDiskCache(path string, ttl time.Duration) *DiskCache {
+	return &DiskCache{path: path, ttl: ttl, now: time.Now}
+}
+
+// Load implements DomainCache. A missing file is an empty cache.
+func (c *DiskCache) Load() (map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.readLocked(); err != nil {
+		return nil, err
+	}
+	domains := make(map[string]bool, len(c.entries))
+	for domain, entry := range c.entries {
+		domains[domain] = entry.Valid
+	}
+	return domains, nil
+}
+
+// Store implements DomainCache. Outcomes that match the loaded entries keep
+// their original check time, so they still expire on schedule.
+func (c *DiskCache) Store(domains map[string]bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		if err := c.readLocked(); err != nil {
+			return err
+		}
+	}
+
+	now := c.now()
+	for domain, valid := range domains {
+		if entry, ok := c.entries[domain]; ok && entry.Valid == valid {
+			continue
+		}
+		c.entries[domain] = diskEntry{Valid: valid, CheckedAt: now}
+	}
+	c.expireLocked()
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.path, data)
+}
+
+// CacheStats implements emailvalidator.CacheMetrics. Lookups are served
+// by the run's DomainMemo, so only Entries and Evictions are reported.
+func (c *DiskCache) CacheStats() emailvalidator.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return emailvalidator.CacheStats{Evictions: c.evictions, Entries: len(c.entries)}
+}
+
+// readLocked loads the file into entries, dropping expired ones
+func (c *DiskCache) readLocked() error {
+	c.entries = make(map[string]diskEntry)
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return err
+	}
+	c.expireLocked()
+	return nil
+}
+
+func (c *DiskCache) expireLocked() {
+	if c.ttl <= 0 {
+		return
+	}
+	cutoff := c.now().Add(-c.ttl)
+	for domain, entry := range c.entries {
+		if entry.CheckedAt.Before(cutoff) {
+			delete(c.entries, domain)
+			c.evictions++
+		}
+	}
+}
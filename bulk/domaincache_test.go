@@ -0,0 +1,65 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestDomainCacheSpansRuns(t *testing.T) {
+	var emails []string
+	for i := 0; i < 20; i++ {
+		emails = append(emails, fmt.Sprintf("user%d@domain%d.com", i, i%4))
+	}
+
+	path := filepath.Join(t.TempDir(), "domains.json")
+	resolver := &countingResolver{lookups: make(map[string]int)}
+	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker().WithResolver(resolver)))
+
+	var summary Summary
+	for run := 0; run < 2; run++ {
+		job := New(v, WithDomainCache(NewDiskCache(path, time.Hour)))
+		var err error
+		if summary, err = job.Run(context.Background(), FromSlice(emails), &Collector{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if stats := summary.DNSCache; stats.Misses != 0 || stats.Entries != 4 {
+		t.Fatalf("expected the second run to be served from the cache, got %+v", stats)
+	}
+
+	if len(resolver.lookups) != 4 {
+		t.Fatalf("expected lookups for 4 domains, got %v", resolver.lookups)
+	}
+	for domain, n := range resolver.lookups {
+		if n != 1 {
+			t.Errorf("%s looked up %d times across runs", domain, n)
+		}
+	}
+}
+
+func TestDiskCacheExpiresEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	now := time.Now()
+
+	cache := NewDiskCache(path, time.Hour)
+	cache.now = func() time.Time { return now }
+	if err := cache.Store(map[string]bool{"example.com": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	later := NewDiskCache(path, time.Hour)
+	later.now = func() time.Time { return now.Add(2 * time.Hour) }
+	domains, err := later.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domains) != 0 {
+		t.Fatalf("expected expired entries to be dropped, got %v", domains)
+	}
+}
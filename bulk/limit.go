@@ -0,0 +1,53 @@
+package bulk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter spaces events evenly so that at most a fixed number happen per
+// second. A nil limiter never waits.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newLimiter returns a limiter for qps events per second, or nil when qps
+// is not positive
+func newLimiter(qps float64) *limiter {
+	if qps <= 0 {
+		return nil
+	}
+	return &limiter{interval: time.Duration(float64(time.Second) / qps)}
+}
+
+// wait blocks until the caller may proceed or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	slot := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
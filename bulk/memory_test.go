@@ -0,0 +1,134 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// syntheticCSV generates a CSV file of rows on the fly, so that inputs
+// larger than memory can be streamed. Every tenth row has a distinct
+// invalid domain, which stresses per-domain statistics.
+type syntheticCSV struct {
+	rows, next int
+	buf        []byte
+}
+
+func (s *syntheticCSV) Read(p []byte) (int, error) {
+	for len(s.buf) < len(p) && s.next <= s.rows {
+		switch {
+		case s.next == 0:
+			s.buf = append(s.buf, "id,email\n"...)
+		case s.next%10 == 0:
+			s.buf = fmt.Appendf(s.buf, "%d,user@-bad%d.com\n", s.next, s.next)
+		default:
+			s.buf = fmt.Appendf(s.buf, "%d,user%d@domain%d.com\n", s.next, s.next, s.next%1000)
+		}
+		s.next++
+	}
+	if len(s.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[:copy(s.buf, s.buf[n:])]
+	return n, nil
+}
+
+// TestRunConstantMemory streams large synthetic CSV files through a job
+// and checks that the peak heap does not grow with the number of rows, as
+// it would if rows or results were retained. It compares 50k and 400k
+// rows, or 50k and 10M when EMAILVALIDATOR_LARGE_TESTS is set.
+func TestRunConstantMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large input in short mode")
+	}
+	small, large := 50000, 400000
+	if os.Getenv("EMAILVALIDATOR_LARGE_TESTS") != "" {
+		large = 10000000
+	}
+	// Slack for GC timing; retaining 100 bytes per row of the large run
+	// would exceed it many times over
+	const slack = 8 << 20
+
+	smallPeak := peakHeap(t, small)
+	largePeak := peakHeap(t, large)
+	t.Logf("peak heap above baseline: %d KiB for %d rows, %d KiB for %d rows", smallPeak>>10, small, largePeak>>10, large)
+	if largePeak > smallPeak+slack {
+		t.Fatalf("heap grew with the input: %d KiB for %d rows, %d KiB for %d rows", smallPeak>>10, small, largePeak>>10, large)
+	}
+}
+
+// peakHeap runs a job over rows synthetic rows and returns the peak heap in
+// use above the heap before the run
+func peakHeap(t *testing.T, rows int) uint64 {
+	t.Helper()
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := stats.HeapInuse
+
+	var peak uint64
+	var mu sync.Mutex
+	sample := func() {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		mu.Lock()
+		if stats.HeapInuse > peak {
+			peak = stats.HeapInuse
+		}
+		mu.Unlock()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				sample()
+			}
+		}
+	}()
+
+	src := FromCSV(&syntheticCSV{rows: rows}, "email")
+	summary, err := New(emailvalidator.New()).Run(context.Background(), src, ToCSV(io.Discard, src))
+	close(done)
+	sample()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Processed != int64(rows) {
+		t.Fatalf("expected %d rows, got %d", rows, summary.Processed)
+	}
+	if peak < baseline {
+		return 0
+	}
+	return peak - baseline
+}
+
+func TestSourcesRejectOversizedRecords(t *testing.T) {
+	huge := strings.Repeat("x", MaxRecordBytes+1)
+
+	csvSource := FromCSV(strings.NewReader("email\n"+huge+"\n"), "email")
+	if _, err := csvSource.Next(); !errors.Is(err, ErrRecordTooLarge) {
+		t.Errorf("CSV: expected ErrRecordTooLarge, got %v", err)
+	}
+
+	ndjsonSource := FromNDJSON(strings.NewReader(strconv.Quote(huge)+"\n"), "email")
+	if _, err := ndjsonSource.Next(); !errors.Is(err, ErrRecordTooLarge) {
+		t.Errorf("NDJSON: expected ErrRecordTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// NDJSONSource streams records from newline-delimited JSON. Each line is
+// either a JSON string holding the address or an object with the address in
+// a named field.
+type NDJSONSource struct {
+	mu         sync.Mutex
+	reader     *bufio.Reader
+	emailField string
+	line       int
+}
+
+// FromNDJSON returns a Source reading newline-delimited JSON from r. For
+// object lines the address is read from emailField and the whole object is
+// kept in Record.Raw. Blank lines are skipped.
+func FromNDJSON(r io.Reader, emailField string) *NDJSONSource {
+	return &NDJSONSource{reader: bufio.NewReader(r), emailField: emailField}
+}
+
+// Next implements Source
+func (s *NDJSONSource) Next() (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		line, err := s.readLine()
+		if errors.Is(err, ErrRecordTooLarge) {
+			return Record{}, fmt.Errorf("NDJSON line %d: %w", s.line+1, err)
+		}
+		if len(line) == 0 && err != nil {
+			return Record{}, err
+		}
+		s.line++
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err != nil {
+				return Record{}, err
+			}
+			continue
+		}
+		return s.parse(line)
+	}
+}
+
+// readLine reads up to and including the next newline, failing for lines
+// longer than MaxRecordBytes
+func (s *NDJSONSource) readLine() ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := s.reader.ReadSlice('\n')
+		if len(line)+len(chunk) > MaxRecordBytes {
+			return nil, ErrRecordTooLarge
+		}
+		line = append(line, chunk...)
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return line, err
+		}
+	}
+}
+
+// parse decodes one non-empty line
+func (s *NDJSONSource) parse(line []byte) (Record, error) {
+	if line[0] == '"' {
+		var email string
+		if err := json.Unmarshal(line, &email); err != nil {
+			return Record{}, fmt.Errorf("NDJSON line %d: %w", s.line, err)
+		}
+		return Record{Email: email}, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return Record{}, fmt.Errorf("NDJSON line %d: %w", s.line, err)
+	}
+	record := Record{Raw: json.RawMessage(append([]byte(nil), line...))}
+	if value, ok := object[s.emailField]; ok {
+		if err := json.Unmarshal(value, &record.Email); err != nil {
+			return Record{}, fmt.Errorf("NDJSON line %d: field %q: %w", s.line, s.emailField, err)
+		}
+	}
+	return record, nil
+}
+
+// NDJSONSink writes one JSON object per item
+type NDJSONSink struct {
+	writer *bufio.Writer
+}
+
+// ndjsonItem is the encoding of one output line
+type ndjsonItem struct {
+	Index             int64                           `json:"index"`
+	Email             string                          `json:"email"`
+	Input             json.RawMessage                 `json:"input,omitempty"`
+	Duplicate         bool                            `json:"duplicate,omitempty"`
+	DuplicateOf       *int64                          `json:"duplicate_of,omitempty"`
+	Suppressed        bool                            `json:"suppressed,omitempty"`
+	SuppressionReason string                          `json:"suppression_reason,omitempty"`
+	Result            emailvalidator.ValidationResult `json:"result"`
+}
+
+// ToNDJSON returns a Sink writing newline-delimited JSON to w
+func ToNDJSON(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{writer: bufio.NewWriter(w)}
+}
+
+// Write implements Sink
+func (s *NDJSONSink) Write(item Item) error {
+	line := ndjsonItem{
+		Index:             item.Index,
+		Email:             item.Email,
+		Input:             item.Raw,
+		Duplicate:         item.Duplicate,
+		Suppressed:        item.Suppressed,
+		SuppressionReason: item.SuppressionReason,
+		Result:            item.Result,
+	}
+	if item.Duplicate {
+		line.DuplicateOf = &item.DuplicateOf
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	if _, err := s.writer.Write(data); err != nil {
+		return err
+	}
+	return s.writer.WriteByte('\n')
+}
+
+// Flush writes any buffered data to the underlying writer
+func (s *NDJSONSink) Flush() error {
+	return s.writer.Flush()
+}
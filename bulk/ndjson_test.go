@@ -0,0 +1,52 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestNDJSONRoundTrip(t *testing.T) {
+	input := `"jane@example.com"
+
+{"id": 7, "email": "invalid-email"}
+{"id": 8}
+`
+	var out bytes.Buffer
+	_, err := New(emailvalidator.New()).Run(context.Background(), FromNDJSON(strings.NewReader(input), "email"), ToNDJSON(&out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 output lines, got %d:\n%s", len(lines), out.String())
+	}
+
+	var second struct {
+		Index  int64
+		Email  string
+		Input  map[string]interface{}
+		Result emailvalidator.ValidationResult
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if second.Index != 1 || second.Email != "invalid-email" || second.Input["id"] != 7.0 || second.Result.IsValid {
+		t.Errorf("unexpected second line: %s", lines[1])
+	}
+	if want := `"schema_version":"` + emailvalidator.ResultSchemaVersion + `"`; !strings.Contains(lines[0], want) {
+		t.Errorf("expected the stable result schema, got %s", lines[0])
+	}
+}
+
+func TestNDJSONInvalidLine(t *testing.T) {
+	_, err := New(emailvalidator.New()).Run(context.Background(), FromNDJSON(strings.NewReader("\"a@b.com\"\n{oops\n"), "email"), &Collector{})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected a line 2 error, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package bulk
+
+import (
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// Progress is a snapshot of a running bulk job
+type Progress struct {
+	Processed int64 `json:"processed"`
+	// Total is the number of input records, or 0 when unknown
+	Total   int64         `json:"total,omitempty"`
+	Elapsed time.Duration `json:"elapsed_ns"`
+	// Rate is the number of records processed per second
+	Rate float64 `json:"rate"`
+	// ETA is the estimated remaining time, or 0 when Total is unknown
+	ETA      time.Duration                    `json:"eta_ns,omitempty"`
+	Verdicts map[emailvalidator.Verdict]int64 `json:"verdicts"`
+	// Duplicates counts records skipped by deduplication
+	Duplicates int64 `json:"duplicates,omitempty"`
+}
+
+// WithProgress registers fn to receive progress snapshots at most once per
+// interval while the job runs, and once more when it finishes. fn is called
+// from the job's goroutine and should return quickly; forward snapshots to
+// a channel to consume them elsewhere.
+func WithProgress(fn func(Progress), interval time.Duration) Option {
+	return func(j *Job) {
+		j.progress = fn
+		j.progressInterval = interval
+	}
+}
+
+// WithTotal sets the expected number of records used to compute the ETA.
+// Sources with a Len method, such as FromSlice, report it automatically.
+func WithTotal(n int64) Option {
+	return func(j *Job) {
+		j.total = n
+	}
+}
+
+// tracker accumulates progress for one run
+type tracker struct {
+	start     time.Time
+	total     int64
+	processed int64
+	verdicts  map[emailvalidator.Verdict]int64
+
+	suppressed int64
+	duplicates int64
+	groups     map[string]*DuplicateGroup
+
+	invalidDomains *topCounter
+	disposable     int64
+	roleAccounts   int64
+	suggestions    int64
+}
+
+func newTracker(total int64, src Source) *tracker {
+	if s, ok := src.(sizer); ok && total == 0 {
+		total = s.Len()
+	}
+	return &tracker{
+		start:          time.Now(),
+		total:          total,
+		verdicts:       make(map[emailvalidator.Verdict]int64),
+		invalidDomains: newTopCounter(invalidDomainsTracked),
+	}
+}
+
+// resumeAt accounts for offset records processed by an earlier run
+func (t *tracker) resumeAt(offset int64) {
+	if t.total > offset {
+		t.total -= offset
+	}
+}
+
+// add records one processed item
+func (t *tracker) add(item Item) {
+	t.processed++
+	if item.Suppressed {
+		t.suppressed++
+		return
+	}
+	t.verdicts[item.Result.Verdict]++
+	t.addSignals(item)
+
+	if item.Duplicate {
+		t.duplicates++
+		if t.groups == nil {
+			t.groups = make(map[string]*DuplicateGroup)
+		}
+		group, ok := t.groups[item.canonical]
+		if !ok {
+			group = &DuplicateGroup{Canonical: item.canonical, Indexes: []int64{item.DuplicateOf}}
+			t.groups[item.canonical] = group
+		}
+		group.Indexes = append(group.Indexes, item.Index)
+	}
+}
+
+// snapshot returns the current progress
+func (t *tracker) snapshot() Progress {
+	p := Progress{
+		Processed: t.processed,
+		Total:     t.total,
+		Elapsed:   time.Since(t.start),
+		Verdicts:  make(map[emailvalidator.Verdict]int64, len(t.verdicts)),
+
+		Duplicates: t.duplicates,
+	}
+	for verdict, n := range t.verdicts {
+		p.Verdicts[verdict] = n
+	}
+	if seconds := p.Elapsed.Seconds(); seconds > 0 {
+		p.Rate = float64(p.Processed) / seconds
+	}
+	if p.Total > p.Processed && p.Rate > 0 {
+		p.ETA = time.Duration(float64(p.Total-p.Processed) / p.Rate * float64(time.Second))
+	}
+	return p
+}
@@ -0,0 +1,163 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// sampleZ is the normal quantile for the 95% confidence level used by
+// estimates
+const sampleZ = 1.959964
+
+// WithSample validates a uniform random sample of n records instead of the
+// whole input, and reports estimates for the full list in Summary.Estimate.
+// The whole source is read to draw the sample, but only n records are kept
+// in memory and validated. Items are written in input order; their Index is
+// the position within the sample. The same seed draws the same sample.
+func WithSample(n int, seed int64) Option {
+	return func(j *Job) {
+		j.sample = n
+		j.sampleSeed = seed
+	}
+}
+
+// SampleSize returns the sample size needed to estimate any proportion
+// within margin (e.g. 0.01 for ±1 percentage point) at 95% confidence
+func SampleSize(margin float64) int {
+	return int(math.Ceil(sampleZ * sampleZ * 0.25 / (margin * margin)))
+}
+
+// Estimate extrapolates the results of a sampled run to the full list
+type Estimate struct {
+	// Population is the number of records in the input
+	Population int64 `json:"population"`
+	// Sample is the number of records validated
+	Sample int64 `json:"sample"`
+	// Confidence is the confidence level of the intervals
+	Confidence float64 `json:"confidence"`
+
+	Verdicts     map[emailvalidator.Verdict]Proportion `json:"verdicts"`
+	Disposable   Proportion                            `json:"disposable"`
+	RoleAccounts Proportion                            `json:"role_accounts"`
+}
+
+// Proportion is the estimated share of the population with some property
+type Proportion struct {
+	// Rate is the share observed in the sample
+	Rate float64 `json:"rate"`
+	// Low and High bound the share in the population
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+	// Count is Rate extrapolated to the population
+	Count int64 `json:"count"`
+}
+
+// newEstimate extrapolates summary, computed over sample records, to a
+// population of records
+func newEstimate(summary Summary, population int64) *Estimate {
+	e := &Estimate{
+		Population: population,
+		Sample:     summary.Processed,
+		Confidence: 0.95,
+		Verdicts:   make(map[emailvalidator.Verdict]Proportion, len(summary.Verdicts)),
+	}
+	for verdict, n := range summary.Verdicts {
+		e.Verdicts[verdict] = e.proportion(n)
+	}
+	e.Disposable = e.proportion(summary.Disposable)
+	e.RoleAccounts = e.proportion(summary.RoleAccounts)
+	return e
+}
+
+// proportion computes the Wilson score interval for hits out of the sample,
+// narrowed by the finite population correction
+func (e *Estimate) proportion(hits int64) Proportion {
+	if e.Sample == 0 {
+		return Proportion{}
+	}
+	n := float64(e.Sample)
+	p := float64(hits) / n
+	count := int64(math.Round(p * float64(e.Population)))
+	if e.Sample >= e.Population {
+		// The whole population was validated
+		return Proportion{Rate: p, Low: p, High: p, Count: count}
+	}
+	z2 := sampleZ * sampleZ
+
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	half := sampleZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	if e.Population > 1 {
+		half *= math.Sqrt(float64(e.Population-e.Sample) / float64(e.Population-1))
+	}
+
+	return Proportion{
+		Rate:  p,
+		Low:   math.Max(0, center-half),
+		High:  math.Min(1, center+half),
+		Count: count,
+	}
+}
+
+// sampleSource reads src to the end and keeps a uniform random sample of n
+// records (reservoir sampling), returned in input order
+func sampleSource(ctx context.Context, src Source, n int, seed int64) (*recordSource, int64, error) {
+	rng := rand.New(rand.NewSource(seed))
+	type drawn struct {
+		position int64
+		record   Record
+	}
+	reservoir := make([]drawn, 0, n)
+
+	var seen int64
+	for ; ; seen++ {
+		if seen%4096 == 0 && ctx.Err() != nil {
+			return nil, seen, ctx.Err()
+		}
+		record, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, seen, err
+		}
+
+		if len(reservoir) < n {
+			reservoir = append(reservoir, drawn{seen, record})
+		} else if j := rng.Int63n(seen + 1); j < int64(n) {
+			reservoir[j] = drawn{seen, record}
+		}
+	}
+
+	sort.Slice(reservoir, func(i, j int) bool { return reservoir[i].position < reservoir[j].position })
+	records := make([]Record, len(reservoir))
+	for i, d := range reservoir {
+		records[i] = d.record
+	}
+	return &recordSource{records: records}, seen, nil
+}
+
+// recordSource is a Source over records held in memory
+type recordSource struct {
+	records []Record
+	pos     int
+}
+
+// Next implements Source
+func (s *recordSource) Next() (Record, error) {
+	if s.pos >= len(s.records) {
+		return Record{}, io.EOF
+	}
+	s.pos++
+	return s.records[s.pos-1], nil
+}
+
+// Len returns the number of records
+func (s *recordSource) Len() int64 {
+	return int64(len(s.records))
+}
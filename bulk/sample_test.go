@@ -0,0 +1,66 @@
+package bulk
+
+import (
+	"context"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestRunSample(t *testing.T) {
+	// Every third address is invalid
+	emails := testEmails(10000)
+
+	var out Collector
+	summary, err := New(emailvalidator.New(), WithSample(2000, 1)).Run(context.Background(), FromSlice(emails), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Items) != 2000 || summary.Processed != 2000 {
+		t.Fatalf("expected 2000 sampled items, got %d", len(out.Items))
+	}
+	seen := make(map[string]bool)
+	for _, item := range out.Items {
+		if seen[item.Email] {
+			t.Fatalf("%s sampled twice", item.Email)
+		}
+		seen[item.Email] = true
+	}
+
+	estimate := summary.Estimate
+	if estimate == nil || estimate.Population != 10000 || estimate.Sample != 2000 {
+		t.Fatalf("unexpected estimate %+v", estimate)
+	}
+	invalid := estimate.Verdicts[emailvalidator.VerdictInvalid]
+	if invalid.Low > 1.0/3 || invalid.High < 1.0/3 {
+		t.Errorf("interval [%.3f, %.3f] misses the true invalid rate", invalid.Low, invalid.High)
+	}
+	if invalid.Count < 3000 || invalid.Count > 3700 {
+		t.Errorf("unexpected extrapolated count %d", invalid.Count)
+	}
+}
+
+func TestRunSampleLargerThanInput(t *testing.T) {
+	emails := testEmails(10)
+	var out Collector
+	summary, err := New(emailvalidator.New(), WithSample(100, 1)).Run(context.Background(), FromSlice(emails), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, item := range out.Items {
+		if item.Email != emails[i] {
+			t.Fatalf("expected the whole input in order, got %s at %d", item.Email, i)
+		}
+	}
+	if p := summary.Estimate.Verdicts[emailvalidator.VerdictInvalid]; p.Low != p.Rate || p.High != p.Rate {
+		t.Errorf("a full census should have no uncertainty, got [%v, %v]", p.Low, p.High)
+	}
+}
+
+func TestSampleSize(t *testing.T) {
+	if n := SampleSize(0.01); n != 9604 {
+		t.Fatalf("expected 9604 for ±1%%, got %d", n)
+	}
+}
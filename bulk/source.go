@@ -0,0 +1,110 @@
+package bulk
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// MaxRecordBytes bounds the size of one CSV row or NDJSON line, so that a
+// malformed file without line breaks cannot exhaust memory
+const MaxRecordBytes = 1 << 20
+
+// ErrRecordTooLarge is returned by the CSV and NDJSON sources for records
+// longer than MaxRecordBytes
+var ErrRecordTooLarge = errors.New("record exceeds MaxRecordBytes")
+
+// Source produces the records of a bulk job. Next returns io.EOF once the
+// input is exhausted.
+type Source interface {
+	Next() (Record, error)
+}
+
+// Sink receives validated records in input order
+type Sink interface {
+	Write(Item) error
+}
+
+// flusher is implemented by sinks that buffer output; Run flushes them once
+// all records are written
+type flusher interface {
+	Flush() error
+}
+
+// SinkFunc adapts an ordinary function to the Sink interface
+type SinkFunc func(Item) error
+
+// Write calls f(item)
+func (f SinkFunc) Write(item Item) error {
+	return f(item)
+}
+
+// sizer is implemented by sources that know how many records they hold
+type sizer interface {
+	Len() int64
+}
+
+// SliceSource is a Source reading from an in-memory list of addresses
+type SliceSource struct {
+	mu     sync.Mutex
+	emails []string
+	pos    int
+}
+
+// FromSlice returns a Source over emails
+func FromSlice(emails []string) *SliceSource {
+	return &SliceSource{emails: emails}
+}
+
+// Next implements Source
+func (s *SliceSource) Next() (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pos >= len(s.emails) {
+		return Record{}, io.EOF
+	}
+	s.pos++
+	return Record{Email: s.emails[s.pos-1]}, nil
+}
+
+// Len returns the total number of addresses
+func (s *SliceSource) Len() int64 {
+	return int64(len(s.emails))
+}
+
+// Collector is a Sink that keeps every item in memory
+type Collector struct {
+	mu    sync.Mutex
+	Items []Item
+}
+
+// Write implements Sink
+func (c *Collector) Write(item Item) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Items = append(c.Items, item)
+	return nil
+}
+
+// boundedReader fails once more than limit bytes are read since the last
+// call to mark
+type boundedReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func (b *boundedReader) Read(p []byte) (int, error) {
+	if b.read > b.limit {
+		return 0, ErrRecordTooLarge
+	}
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+// mark starts counting a new record
+func (b *boundedReader) mark() {
+	b.read = 0
+}
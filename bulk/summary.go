@@ -0,0 +1,147 @@
+package bulk
+
+import (
+	"sort"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// TopDomainsLimit is the number of domains listed in Summary.TopInvalidDomains
+const TopDomainsLimit = 10
+
+// invalidDomainsTracked bounds the number of distinct invalid domains
+// counted per run, keeping memory constant for any input size
+const invalidDomainsTracked = 1000
+
+// Summary is the aggregate report of a finished bulk run
+type Summary struct {
+	Processed int64                            `json:"processed"`
+	Elapsed   time.Duration                    `json:"elapsed_ns"`
+	Verdicts  map[emailvalidator.Verdict]int64 `json:"verdicts"`
+
+	// TopInvalidDomains lists the domains with the most invalid addresses.
+	// Counts are exact unless the run saw more than 1000 distinct invalid
+	// domains, in which case they may be overestimated.
+	TopInvalidDomains []DomainCount `json:"top_invalid_domains,omitempty"`
+
+	// Disposable and RoleAccounts count addresses flagged by the validator.
+	// Disposable addresses are only detected when the validator was built
+	// with WithDisposableCheck.
+	Disposable        int64   `json:"disposable"`
+	DisposablePercent float64 `json:"disposable_percent"`
+	RoleAccounts      int64   `json:"role_accounts"`
+	RolePercent       float64 `json:"role_percent"`
+	// Suggestions counts results carrying a typo suggestion
+	Suggestions int64 `json:"suggestions"`
+
+	// Suppressed counts records found on the suppression list; they are
+	// not included in Verdicts
+	Suppressed int64 `json:"suppressed,omitempty"`
+
+	// Duplicates counts records skipped by deduplication
+	Duplicates      int64            `json:"duplicates,omitempty"`
+	DuplicateGroups []DuplicateGroup `json:"duplicate_groups,omitempty"`
+
+	// DNSCache reports the per-run domain memo, including domains restored
+	// from a checkpoint or DomainCache
+	DNSCache emailvalidator.CacheStats `json:"dns_cache"`
+
+	// Estimate extrapolates the counts to the whole input when the run was
+	// sampled with WithSample
+	Estimate *Estimate `json:"estimate,omitempty"`
+}
+
+// DomainCount is the number of addresses found for one domain
+type DomainCount struct {
+	Domain string `json:"domain"`
+	Count  int64  `json:"count"`
+}
+
+// DuplicateGroup lists the records sharing one canonical address
+type DuplicateGroup struct {
+	Canonical string `json:"canonical"`
+	// Indexes holds the first record followed by its duplicates
+	Indexes []int64 `json:"indexes"`
+}
+
+// addSignals updates the summary counters for item
+func (t *tracker) addSignals(item Item) {
+	result := item.Result
+	if result.Verdict == emailvalidator.VerdictInvalid {
+		t.invalidDomains.add(domainOf(item.Email))
+	}
+	if result.Suggestion != "" {
+		t.suggestions++
+	}
+
+	disposable, role := false, false
+	for _, err := range result.Errors {
+		disposable = disposable || err.Code == emailvalidator.CodeDisposable
+	}
+	for _, w := range result.Warnings {
+		disposable = disposable || w.Code == emailvalidator.WarnDisposable
+		role = role || w.Code == emailvalidator.WarnRoleAccount
+	}
+	if disposable {
+		t.disposable++
+	}
+	if role {
+		t.roleAccounts++
+	}
+}
+
+// summary builds the final report
+func (t *tracker) summary() Summary {
+	progress := t.snapshot()
+	s := Summary{
+		Processed:         progress.Processed,
+		Elapsed:           progress.Elapsed,
+		Verdicts:          progress.Verdicts,
+		TopInvalidDomains: topDomains(t.invalidDomains.counts(), TopDomainsLimit),
+		Disposable:        t.disposable,
+		RoleAccounts:      t.roleAccounts,
+		Suggestions:       t.suggestions,
+		Suppressed:        t.suppressed,
+		Duplicates:        t.duplicates,
+		DuplicateGroups:   t.duplicateGroups(),
+	}
+	if s.Processed > 0 {
+		s.DisposablePercent = percent(s.Disposable, s.Processed)
+		s.RolePercent = percent(s.RoleAccounts, s.Processed)
+	}
+	return s
+}
+
+// topDomains returns the n domains with the highest counts
+func topDomains(counts map[string]int64, n int) []DomainCount {
+	domains := make([]DomainCount, 0, len(counts))
+	for domain, count := range counts {
+		domains = append(domains, DomainCount{Domain: domain, Count: count})
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].Count != domains[j].Count {
+			return domains[i].Count > domains[j].Count
+		}
+		return domains[i].Domain < domains[j].Domain
+	})
+	if len(domains) > n {
+		domains = domains[:n]
+	}
+	return domains
+}
+
+// percent returns part as a percentage of whole rounded to two decimals
+func percent(part, whole int64) float64 {
+	return float64(part*10000/whole) / 100
+}
+
+// duplicateGroups returns the duplicate report ordered by first occurrence
+func (t *tracker) duplicateGroups() []DuplicateGroup {
+	groups := make([]DuplicateGroup, 0, len(t.groups))
+	for _, group := range t.groups {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Indexes[0] < groups[j].Indexes[0] })
+	return groups
+}
@@ -0,0 +1,65 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestSummary(t *testing.T) {
+	emails := []string{
+		"jane@example.com",
+		"admin@example.com",
+		"user@mailinator.com",
+		"user@gmial.com",
+		"bad..dots@broken.com",
+		"also..bad@broken.com",
+		"a..b@other.com",
+		"invalid-email",
+	}
+	v := emailvalidator.New(emailvalidator.WithDisposableCheck(emailvalidator.SeverityWarning))
+
+	summary, err := New(v).Run(context.Background(), FromSlice(emails), &Collector{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Processed != 8 || summary.Verdicts[emailvalidator.VerdictInvalid] != 4 {
+		t.Errorf("unexpected counts: %+v", summary)
+	}
+	want := []DomainCount{{"broken.com", 2}, {"", 1}, {"other.com", 1}}
+	if len(summary.TopInvalidDomains) != len(want) {
+		t.Fatalf("unexpected top invalid domains: %+v", summary.TopInvalidDomains)
+	}
+	for i := range want {
+		if summary.TopInvalidDomains[i] != want[i] {
+			t.Errorf("top domain %d: got %+v, want %+v", i, summary.TopInvalidDomains[i], want[i])
+		}
+	}
+	if summary.Disposable != 1 || summary.DisposablePercent != 12.5 {
+		t.Errorf("unexpected disposable stats: %d %v", summary.Disposable, summary.DisposablePercent)
+	}
+	if summary.RoleAccounts != 1 || summary.Suggestions != 1 {
+		t.Errorf("unexpected role/suggestion counts: %d %d", summary.RoleAccounts, summary.Suggestions)
+	}
+
+	if _, err := json.Marshal(summary); err != nil {
+		t.Errorf("summary is not JSON-serializable: %v", err)
+	}
+}
+
+func TestTopCounterKeepsFrequentKeys(t *testing.T) {
+	c := newTopCounter(3)
+	for i := 0; i < 50; i++ {
+		c.add("frequent.com")
+		c.add(fmt.Sprintf("rare%d.com", i))
+	}
+
+	counts := c.counts()
+	if len(counts) != 3 || counts["frequent.com"] != 50 {
+		t.Fatalf("expected frequent.com to be kept with an exact count, got %v", counts)
+	}
+}
@@ -0,0 +1,154 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// SuppressionList holds addresses that must not be mailed, such as previous
+// hard bounces and unsubscribes, keyed by canonical address. It is safe for
+// concurrent reads once built.
+type SuppressionList struct {
+	reasons map[string]string
+}
+
+// NewSuppressionList returns an empty list
+func NewSuppressionList() *SuppressionList {
+	return &SuppressionList{reasons: make(map[string]string)}
+}
+
+// ReadSuppressionList reads a list with one address per line, optionally
+// followed by a comma and the reason it is suppressed:
+//
+//	jane@example.com,hard_bounce
+//	john@example.com,unsubscribed
+//
+// Blank lines, lines starting with # and lines whose first field holds no
+// @, such as a header row, are skipped.
+func ReadSuppressionList(r io.Reader) (*SuppressionList, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	list := NewSuppressionList()
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(row[0], "@") {
+			continue
+		}
+		reason := ""
+		if len(row) > 1 {
+			reason = strings.TrimSpace(row[1])
+		}
+		list.Add(row[0], reason)
+	}
+}
+
+// Add suppresses email for reason, which may be empty
+func (l *SuppressionList) Add(email, reason string) {
+	l.reasons[emailvalidator.Canonical(email)] = reason
+}
+
+// Lookup reports whether email is suppressed, and why
+func (l *SuppressionList) Lookup(email string) (reason string, ok bool) {
+	reason, ok = l.reasons[emailvalidator.Canonical(email)]
+	return reason, ok
+}
+
+// Len returns the number of suppressed addresses
+func (l *SuppressionList) Len() int {
+	return len(l.reasons)
+}
+
+// WithSuppressionList checks every record against list before anything
+// else. Matching records are reported as Suppressed with a zero Result,
+// without being validated, rate limited or looked up in DNS.
+func WithSuppressionList(list *SuppressionList) Option {
+	return func(j *Job) {
+		j.suppressed = list
+	}
+}
+
+// CleanListSink writes the address of every record fit for mailing, one per
+// line: records that are neither suppressed nor duplicates and whose
+// verdict is accepted. Combined with WithSuppressionList, the output is the
+// input list merged with the suppression list.
+type CleanListSink struct {
+	writer   *bufio.Writer
+	verdicts []emailvalidator.Verdict
+}
+
+// ToCleanList returns a CleanListSink writing to w the addresses whose
+// verdict is one of verdicts, or valid when none are given
+func ToCleanList(w io.Writer, verdicts ...emailvalidator.Verdict) *CleanListSink {
+	if len(verdicts) == 0 {
+		verdicts = []emailvalidator.Verdict{emailvalidator.VerdictValid}
+	}
+	return &CleanListSink{writer: bufio.NewWriter(w), verdicts: verdicts}
+}
+
+// Write implements Sink
+func (s *CleanListSink) Write(item Item) error {
+	if item.Suppressed || item.Duplicate || !s.accepts(item.Result.Verdict) {
+		return nil
+	}
+	if _, err := s.writer.WriteString(strings.TrimSpace(item.Email)); err != nil {
+		return err
+	}
+	return s.writer.WriteByte('\n')
+}
+
+func (s *CleanListSink) accepts(verdict emailvalidator.Verdict) bool {
+	for _, v := range s.verdicts {
+		if v == verdict {
+			return true
+		}
+	}
+	return false
+}
+
+// Flush writes any buffered data to the underlying writer
+func (s *CleanListSink) Flush() error {
+	return s.writer.Flush()
+}
+
+// MultiSink returns a Sink writing every item to each of sinks in turn,
+// stopping at the first error. Flushing it flushes each sink that buffers.
+func MultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+type multiSink []Sink
+
+func (m multiSink) Write(item Item) error {
+	for _, sink := range m {
+		if err := sink.Write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiSink) Flush() error {
+	for _, sink := range m {
+		if f, ok := sink.(flusher); ok {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
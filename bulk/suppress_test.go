@@ -0,0 +1,86 @@
+package bulk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestReadSuppressionList(t *testing.T) {
+	list, err := ReadSuppressionList(strings.NewReader("email,reason\n# exported 2024-01-01\nJane@Example.com, hard_bounce\n\njohn@example.com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", list.Len())
+	}
+	if reason, ok := list.Lookup(" jane@example.COM"); !ok || reason != "hard_bounce" {
+		t.Errorf("Lookup(jane) = %q, %v", reason, ok)
+	}
+	if reason, ok := list.Lookup("john@example.com"); !ok || reason != "" {
+		t.Errorf("Lookup(john) = %q, %v", reason, ok)
+	}
+	if _, ok := list.Lookup("email"); ok {
+		t.Error("header row suppressed")
+	}
+}
+
+func TestRunSuppressionList(t *testing.T) {
+	list := NewSuppressionList()
+	list.Add("bounced@example.com", "hard_bounce")
+	emails := []string{"ok@example.com", "Bounced@example.com", "invalid", "ok@example.com", "bounced@example.com"}
+
+	var validated []string
+	v := emailvalidator.ValidatorFunc(func(email string) emailvalidator.ValidationResult {
+		validated = append(validated, email)
+		return emailvalidator.New().Validate(email)
+	})
+
+	var out Collector
+	var clean strings.Builder
+	job := New(v, WithWorkers(1), WithDedupe(), WithSuppressionList(list))
+	summary, err := job.Run(context.Background(), FromSlice(emails), MultiSink(&out, ToCleanList(&clean)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(validated) != 2 {
+		t.Errorf("validated %q, want the first and the invalid address only", validated)
+	}
+	for _, i := range []int{1, 4} {
+		item := out.Items[i]
+		if !item.Suppressed || item.SuppressionReason != "hard_bounce" || item.Duplicate || item.Result.Verdict != "" {
+			t.Errorf("item %d = %+v, want suppressed", i, item)
+		}
+	}
+	if !out.Items[3].Duplicate || out.Items[3].Suppressed {
+		t.Errorf("item 3 = %+v, want a duplicate", out.Items[3])
+	}
+
+	if got := clean.String(); got != "ok@example.com\n" {
+		t.Errorf("clean list = %q", got)
+	}
+	if summary.Suppressed != 2 || summary.Processed != 5 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if n := summary.Verdicts[emailvalidator.VerdictValid] + summary.Verdicts[emailvalidator.VerdictInvalid]; n != 3 {
+		t.Errorf("verdicts %v count suppressed records", summary.Verdicts)
+	}
+}
+
+func TestCSVSinkSuppressed(t *testing.T) {
+	list := NewSuppressionList()
+	list.Add("jane@example.com", "unsubscribed")
+	source := FromCSV(strings.NewReader("name,email\nJane,jane@example.com\n"), "email")
+
+	var out strings.Builder
+	if _, err := New(emailvalidator.New(), WithSuppressionList(list)).Run(context.Background(), source, ToCSV(&out, source)); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,email,verdict,score,reason\nJane,jane@example.com,suppressed,,unsubscribed\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
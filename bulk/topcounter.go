@@ -0,0 +1,81 @@
+package bulk
+
+import "container/heap"
+
+// topCounter counts occurrences of keys in bounded memory using the
+// Space-Saving algorithm: once capacity distinct keys are tracked, a new key
+// replaces the least frequent one and inherits its count. Counts are exact
+// until capacity is exceeded and may overestimate afterwards, but any key
+// seen more often than the least tracked count is kept.
+type topCounter struct {
+	capacity int
+	index    map[string]*counted
+	byCount  countHeap
+}
+
+type counted struct {
+	key   string
+	count int64
+	pos   int
+}
+
+func newTopCounter(capacity int) *topCounter {
+	return &topCounter{capacity: capacity, index: make(map[string]*counted)}
+}
+
+// add counts one occurrence of key
+func (c *topCounter) add(key string) {
+	if entry, ok := c.index[key]; ok {
+		entry.count++
+		heap.Fix(&c.byCount, entry.pos)
+		return
+	}
+
+	if len(c.byCount) < c.capacity {
+		entry := &counted{key: key, count: 1}
+		c.index[key] = entry
+		heap.Push(&c.byCount, entry)
+		return
+	}
+
+	// Replace the least frequent key
+	entry := c.byCount[0]
+	delete(c.index, entry.key)
+	entry.key = key
+	entry.count++
+	c.index[key] = entry
+	heap.Fix(&c.byCount, 0)
+}
+
+// counts returns the tracked counts
+func (c *topCounter) counts() map[string]int64 {
+	counts := make(map[string]int64, len(c.index))
+	for key, entry := range c.index {
+		counts[key] = entry.count
+	}
+	return counts
+}
+
+// countHeap is a min-heap of entries ordered by count
+type countHeap []*counted
+
+func (h countHeap) Len() int           { return len(h) }
+func (h countHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h countHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos = i
+	h[j].pos = j
+}
+
+func (h *countHeap) Push(x interface{}) {
+	entry := x.(*counted)
+	entry.pos = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *countHeap) Pop() interface{} {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
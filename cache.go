@@ -0,0 +1,194 @@
+package emailvalidator
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultCache is an in-process LRU cache of validation results keyed by
+// canonical address. It is safe for concurrent use. Use Cached to put one
+// in front of a Validator.
+type ResultCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+
+	hits, misses, evictions uint64
+}
+
+type cacheEntry struct {
+	key     string
+	result  ValidationResult
+	expires time.Time
+}
+
+// NewResultCache creates a cache holding up to size results, each for at
+// most ttl. A ttl of zero keeps results until they are evicted.
+func NewResultCache(size int, ttl time.Duration) *ResultCache {
+	if size <= 0 {
+		size = 1
+	}
+	return &ResultCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached result for email
+func (c *ResultCache) Get(email string) (ValidationResult, bool) {
+	key := Canonical(email)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return ValidationResult{}, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.removeLocked(elem)
+		c.evictions++
+		c.misses++
+		return ValidationResult{}, false
+	}
+	c.order.MoveToFront(elem)
+	c.hits++
+	return entry.result, true
+}
+
+// Add stores result for email, evicting the least recently used result
+// when the cache is full
+func (c *ResultCache) Add(email string, result ValidationResult) {
+	key := Canonical(email)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.result = result
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, result: result, expires: expires})
+	for c.order.Len() > c.size {
+		c.removeLocked(c.order.Back())
+		c.evictions++
+	}
+}
+
+// Len returns the number of cached results, including expired ones not yet
+// removed
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// CacheStats implements CacheMetrics
+func (c *ResultCache) CacheStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Entries: c.order.Len()}
+}
+
+// Purge removes all cached results
+func (c *ResultCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+func (c *ResultCache) removeLocked(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// ResultStore holds validation results keyed by address. ResultCache is
+// the in-process implementation; the redisstore package shares results
+// between instances. Implementations must be safe for concurrent use and
+// report failures as misses.
+type ResultStore interface {
+	Get(email string) (ValidationResult, bool)
+	Add(email string, result ValidationResult)
+}
+
+// CachedValidator serves repeated addresses from a ResultStore
+type CachedValidator struct {
+	validator Validator
+	cache     ResultStore
+}
+
+// Cached returns a Validator that answers from cache when possible and
+// otherwise validates with v and stores the result. Addresses that differ
+// only in case share one result, including its Username and Domain. Results
+// whose DNS check could not complete are not cached, and neither are
+// addresses with surrounding whitespace, which fail validation while their
+// canonical form may not. Cached results are shared and must not be modified.
+func Cached(v Validator, cache ResultStore) *CachedValidator {
+	return &CachedValidator{validator: v, cache: cache}
+}
+
+// Validate implements Validator
+func (c *CachedValidator) Validate(email string) ValidationResult {
+	return c.ValidateContext(context.Background(), email)
+}
+
+// ValidateContext implements ContextValidator. The wrapped validator is
+// cancelled with ctx only if it implements ContextValidator too.
+func (c *CachedValidator) ValidateContext(ctx context.Context, email string) ValidationResult {
+	if strings.TrimSpace(email) != email {
+		return c.validate(ctx, email)
+	}
+	if result, ok := c.cache.Get(email); ok {
+		return result
+	}
+
+	result := c.validate(ctx, email)
+	if result.DNS.Status != StatusError {
+		c.cache.Add(email, result)
+	}
+	return result
+}
+
+func (c *CachedValidator) validate(ctx context.Context, email string) ValidationResult {
+	if cv, ok := c.validator.(ContextValidator); ok {
+		return cv.ValidateContext(ctx, email)
+	}
+	return c.validator.Validate(email)
+}
+
+// Batch implements BatchValidator when the wrapped validator does, sharing
+// the cache with the derived validator
+func (c *CachedValidator) Batch(memo *DomainMemo) Validator {
+	if batcher, ok := c.validator.(BatchValidator); ok {
+		return Cached(batcher.Batch(memo), c.cache)
+	}
+	return c
+}
+
+// Prefetch implements Prefetcher when the wrapped validator does
+func (c *CachedValidator) Prefetch(ctx context.Context, email string) {
+	if prefetcher, ok := c.validator.(Prefetcher); ok {
+		prefetcher.Prefetch(ctx, email)
+	}
+}
@@ -0,0 +1,89 @@
+package emailvalidator
+
+import (
+	"testing"
+	"time"
+)
+
+func countingValidator(calls *int) ValidatorFunc {
+	return func(email string) ValidationResult {
+		*calls++
+		return ValidationResult{IsValid: true, Normalized: Canonical(email)}
+	}
+}
+
+func TestCachedValidatorReusesResults(t *testing.T) {
+	var calls int
+	v := Cached(countingValidator(&calls), NewResultCache(10, 0))
+
+	v.Validate("jane@example.com")
+	v.Validate("Jane@Example.com")
+	v.Validate(" jane@example.com")
+
+	if calls != 2 {
+		t.Fatalf("expected 2 validations (one miss, one untrimmed), got %d", calls)
+	}
+}
+
+func TestResultCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewResultCache(2, 0)
+	cache.Add("a@example.com", ValidationResult{})
+	cache.Add("b@example.com", ValidationResult{})
+	cache.Get("a@example.com")
+	cache.Add("c@example.com", ValidationResult{})
+
+	if _, ok := cache.Get("b@example.com"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, email := range []string{"a@example.com", "c@example.com"} {
+		if _, ok := cache.Get(email); !ok {
+			t.Errorf("expected %s to be cached", email)
+		}
+	}
+}
+
+func TestResultCacheExpires(t *testing.T) {
+	now := time.Now()
+	cache := NewResultCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Add("a@example.com", ValidationResult{})
+	now = now.Add(59 * time.Second)
+	if _, ok := cache.Get("a@example.com"); !ok {
+		t.Fatal("expected a fresh entry")
+	}
+	now = now.Add(time.Second)
+	if _, ok := cache.Get("a@example.com"); ok || cache.Len() != 0 {
+		t.Fatal("expected the entry to expire")
+	}
+}
+
+func TestCachedValidatorSkipsIncompleteResults(t *testing.T) {
+	var calls int
+	v := Cached(ValidatorFunc(func(email string) ValidationResult {
+		calls++
+		return ValidationResult{DNS: CheckResult{Status: StatusError}, Verdict: VerdictUnknown}
+	}), NewResultCache(10, 0))
+
+	v.Validate("jane@example.com")
+	v.Validate("jane@example.com")
+	if calls != 2 {
+		t.Fatalf("expected results with failed DNS lookups to be revalidated, got %d calls", calls)
+	}
+}
+
+func TestResultCacheStats(t *testing.T) {
+	cache := NewResultCache(1, 0)
+	cache.Get("a@example.com")
+	cache.Add("a@example.com", ValidationResult{})
+	cache.Get("a@example.com")
+	cache.Add("b@example.com", ValidationResult{})
+
+	want := CacheStats{Hits: 1, Misses: 1, Evictions: 1, Entries: 1}
+	if got := cache.CacheStats(); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if ratio := want.HitRatio(); ratio != 0.5 {
+		t.Fatalf("expected hit ratio 0.5, got %v", ratio)
+	}
+}
@@ -0,0 +1,37 @@
+package emailvalidator
+
+// The methods below are chainable shorthands for With and the option of the
+// same name, for configuration that reads left to right:
+//
+//	v := emailvalidator.New().WithStrictMode().WithDNSCheck(emailvalidator.NewDNSChecker())
+//
+// Each returns a derived validator and leaves the receiver untouched, so
+// they mix freely with functional options.
+
+// WithStrictMode returns a copy of the validator with strict character
+// validation, like NewStrict
+func (v *EmailValidator) WithStrictMode() *EmailValidator {
+	return v.With(WithStrict(true))
+}
+
+// WithAllowedTLDs returns a copy of the validator accepting only tlds
+func (v *EmailValidator) WithAllowedTLDs(tlds []string) *EmailValidator {
+	return v.With(WithAllowedTLDs(tlds))
+}
+
+// WithBlockedDomains returns a copy of the validator rejecting domains
+func (v *EmailValidator) WithBlockedDomains(domains []string) *EmailValidator {
+	return v.With(WithBlockedDomains(domains))
+}
+
+// WithDisposableCheck returns a copy of the validator reporting disposable
+// providers with severity
+func (v *EmailValidator) WithDisposableCheck(severity Severity) *EmailValidator {
+	return v.With(WithDisposableCheck(severity))
+}
+
+// WithDNSCheck returns a copy of the validator checking domains with
+// checker
+func (v *EmailValidator) WithDNSCheck(checker *DNSChecker) *EmailValidator {
+	return v.With(WithDNSCheck(checker))
+}
@@ -0,0 +1,87 @@
+package emailvalidator
+
+import "time"
+
+// CheckStatus is the outcome of one validation stage
+type CheckStatus string
+
+// Statuses reported in CheckResult.Status
+const (
+	StatusPassed  CheckStatus = "passed"
+	StatusWarning CheckStatus = "warning"
+	StatusFailed  CheckStatus = "failed"
+	StatusError   CheckStatus = "error"
+	StatusSkipped CheckStatus = "skipped"
+)
+
+// CheckResult holds the findings of one validation stage. Every error and
+// warning also appears in the aggregate lists of ValidationResult.
+type CheckResult struct {
+	Status   CheckStatus       `json:"status"`
+	Duration time.Duration     `json:"duration_ns"`
+	Errors   []ValidationError `json:"errors,omitempty"`
+	Warnings []Warning         `json:"warnings,omitempty"`
+}
+
+// skipped returns a CheckResult for a stage that did not run
+func skipped() CheckResult {
+	return CheckResult{Status: StatusSkipped}
+}
+
+// stage records findings for one check into both the check's own section
+// and the aggregate result
+type stage struct {
+	result *ValidationResult
+	check  *CheckResult
+	start  time.Time
+	status CheckStatus
+}
+
+// begin starts timing the stage whose findings are stored in check
+func (r *ValidationResult) begin(check *CheckResult) *stage {
+	return &stage{result: r, check: check, start: time.Now()}
+}
+
+// addError records err as a failure of the stage
+func (s *stage) addError(err error) {
+	s.result.addError(err)
+	s.check.Errors = append(s.check.Errors, s.result.Errors[len(s.result.Errors)-1])
+}
+
+// addWarning records w against the stage
+func (s *stage) addWarning(w Warning) {
+	s.result.addWarning(w)
+	s.check.Warnings = append(s.check.Warnings, w)
+}
+
+// unavailable marks the stage as unable to reach a conclusion
+func (s *stage) unavailable() {
+	s.status = StatusError
+}
+
+// done stops timing and derives the status from the recorded findings
+func (s *stage) done() {
+	s.check.Duration = time.Since(s.start)
+	switch {
+	case s.status != "":
+		s.check.Status = s.status
+	case len(s.check.Errors) > 0:
+		s.check.Status = StatusFailed
+	case len(s.check.Warnings) > 0:
+		s.check.Status = StatusWarning
+	default:
+		s.check.Status = StatusPassed
+	}
+}
+
+// signalState converts the stage status into a scoring signal
+func (c CheckResult) signalState() signalState {
+	switch c.Status {
+	case StatusFailed:
+		return signalFail
+	case StatusPassed, StatusWarning:
+		return signalPass
+	default:
+		return signalUnknown
+	}
+}
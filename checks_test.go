@@ -0,0 +1,44 @@
+package emailvalidator
+
+import "testing"
+
+func TestCheckSections(t *testing.T) {
+	v := New(WithDisposableCheck(SeverityWarning))
+
+	result := v.Validate("admin@gmial.com")
+	if result.Syntax.Status != StatusPassed {
+		t.Errorf("syntax: got %s", result.Syntax.Status)
+	}
+	if result.DNS.Status != StatusSkipped {
+		t.Errorf("dns should be skipped, got %s", result.DNS.Status)
+	}
+	if result.Reputation.Status != StatusWarning || len(result.Reputation.Warnings) != 1 {
+		t.Errorf("reputation: got %+v", result.Reputation)
+	}
+	if result.Suggestions.Status != StatusWarning {
+		t.Errorf("suggestions: got %s", result.Suggestions.Status)
+	}
+
+	result = v.Validate("user..name@mailinator.com")
+	if result.Syntax.Status != StatusFailed || len(result.Syntax.Errors) != 1 {
+		t.Errorf("syntax: got %+v", result.Syntax)
+	}
+	if result.Reputation.Status != StatusWarning || result.Reputation.Warnings[0].Code != WarnDisposable {
+		t.Errorf("reputation: got %+v", result.Reputation)
+	}
+
+	result = v.Validate("invalid-email")
+	if result.Syntax.Status != StatusFailed || result.Reputation.Status != StatusSkipped {
+		t.Errorf("expected only syntax to run, got %s/%s", result.Syntax.Status, result.Reputation.Status)
+	}
+}
+
+func TestUnavailableStageYieldsUnknownVerdict(t *testing.T) {
+	score, verdict := DefaultScoreWeights().score(signals{
+		syntax:     signalPass,
+		incomplete: true,
+	})
+	if score != 100 || verdict != VerdictUnknown {
+		t.Errorf("got %v %s, want 100 unknown", score, verdict)
+	}
+}
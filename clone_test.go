@@ -0,0 +1,66 @@
+package emailvalidator
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestWithDoesNotMutateOriginal(t *testing.T) {
+	base := New(WithBlockedDomains([]string{"spam.com"}))
+	admin := base.With(WithStrict(true), WithBlockedDomains([]string{"spam.com", "example.org"}))
+
+	if !base.Validate("user@example.org").IsValid {
+		t.Error("base validator should not inherit options applied to a derived one")
+	}
+	if admin.Validate("user@example.org").IsValid {
+		t.Error("derived validator should block example.org")
+	}
+	if base.strictMode || !admin.strictMode {
+		t.Error("strict mode should only be set on the derived validator")
+	}
+}
+
+func TestCloneCopiesLists(t *testing.T) {
+	tlds := []string{"com"}
+	base := New(WithAllowedTLDs(tlds), WithBlockedDomains([]string{"spam.com"}))
+	tlds[0] = "org"
+
+	clone := base.Clone()
+	clone.blockedDomains.Add("other.com")
+	clone.allowTLDs[0] = "net"
+
+	if base.blockedDomains.Contains("other.com") || base.allowTLDs[0] != "com" {
+		t.Error("clone shares state with the original validator")
+	}
+}
+
+func TestConcurrentDerivation(t *testing.T) {
+	base := New(WithBlockedDomains([]string{"spam.com"}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			derived := base.With(WithStrict(true))
+			derived.Validate("user@spam.com")
+			base.Validate("user@example.com")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestChainedConfiguration(t *testing.T) {
+	base := New()
+	chained := base.WithStrictMode().WithBlockedDomains([]string{"spam.com"}).WithDisposableCheck(SeverityError)
+	optioned := New(WithStrict(true), WithBlockedDomains([]string{"spam.com"}), WithDisposableCheck(SeverityError))
+
+	for _, email := range []string{"user@spam.com", "user@mailinator.com", "user@example.com"} {
+		if got, want := chained.Validate(email).IsValid, optioned.Validate(email).IsValid; got != want {
+			t.Errorf("%s: chained valid = %v, with options %v", email, got, want)
+		}
+	}
+	if base.strictMode || base.disposableCheck || base.blockedDomains.Len() != 0 {
+		t.Error("chained methods modified the base validator")
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/LBFmuraiybatu/email_validator/bulk"
+)
+
+// runBulk validates a CSV or NDJSON file and writes the results, by default
+// in the input format, printing a summary to standard error. CSV output
+// keeps the input rows unless -columns is given.
+func runBulk(fs *flag.FlagSet) func(args []string) error {
+	vf := addValidatorFlags(fs)
+	in := fs.String("in", "-", "input file, or - for standard input")
+	out := fs.String("out", "-", "output file, or - for standard output")
+	format := fs.String("format", "", "input format, csv or ndjson; by default taken from the -in extension")
+	column := fs.String("column", "email", "CSV column or NDJSON field holding the address")
+	workers := fs.Int("workers", 0, "concurrent validations; defaults to the number of CPUs")
+	dedupe := fs.Bool("dedupe", false, "validate each canonical address once")
+	suppress := fs.String("suppress", "", "suppression list file of addresses to skip, one per line with an optional reason after a comma")
+	bounces := fs.String("bounces", "", "bounce export CSV from an email service provider; hard bounces are suppressed and, with -dns, unroutable domains fail without a lookup")
+	clean := fs.String("clean", "", "also write the valid addresses, minus duplicates and suppressed ones, to this file")
+	progress := fs.Bool("progress", false, "report progress on standard error")
+	of := addOutputFlags(fs, "", "email,verdict,score,reason")
+	return func(args []string) error {
+
+		if *format == "" {
+			*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*in)), ".")
+		}
+		if *format == "jsonl" {
+			*format = "ndjson"
+		}
+		if *format != "csv" && *format != "ndjson" {
+			return fmt.Errorf("unknown format %q; set -format to csv or ndjson", *format)
+		}
+
+		v, err := vf.validator()
+		if err != nil {
+			return err
+		}
+
+		var r io.Reader = os.Stdin
+		if *in != "-" {
+			f, err := os.Open(*in)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		var w io.Writer = os.Stdout
+		if *out != "-" {
+			f, err := os.Create(*out)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		var opts []bulk.Option
+		if *workers > 0 {
+			opts = append(opts, bulk.WithWorkers(*workers))
+		}
+		if *dedupe {
+			opts = append(opts, bulk.WithDedupe())
+		}
+		list := bulk.NewSuppressionList()
+		if *suppress != "" {
+			f, err := os.Open(*suppress)
+			if err != nil {
+				return err
+			}
+			list, err = bulk.ReadSuppressionList(f)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("reading suppression list: %w", err)
+			}
+		}
+		if *bounces != "" {
+			f, err := os.Open(*bounces)
+			if err != nil {
+				return err
+			}
+			report, err := bulk.ImportBounces(f, list)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("reading bounces: %w", err)
+			}
+			opts = append(opts, bulk.WithKnownDomains(report.Domains))
+		}
+		if list.Len() > 0 {
+			opts = append(opts, bulk.WithSuppressionList(list))
+		}
+		if *progress {
+			opts = append(opts, bulk.WithProgress(func(p bulk.Progress) {
+				fmt.Fprintf(os.Stderr, "\rprocessed %d (%.0f/s)", p.Processed, p.Rate)
+			}, time.Second))
+		}
+
+		var src bulk.Source = bulk.FromNDJSON(r, *column)
+		var sink bulk.Sink = bulk.ToNDJSON(w)
+		var rw resultWriter
+		columnsSet := false
+		fs.Visit(func(f *flag.Flag) { columnsSet = columnsSet || f.Name == "columns" })
+		switch {
+		case *of.format == "" && *format == "csv", *of.format == formatCSV && *format == "csv" && !columnsSet:
+			csvSource := bulk.FromCSV(r, *column)
+			src, sink = csvSource, bulk.ToCSV(w, csvSource)
+		case *of.format == "" && *format == "ndjson":
+		default:
+			if *format == "csv" {
+				src = bulk.FromCSV(r, *column)
+			}
+			if rw, err = of.newResultWriter(w); err != nil {
+				return err
+			}
+			sink = bulk.SinkFunc(func(item bulk.Item) error {
+				return rw.Write(item.Email, item.Result)
+			})
+		}
+
+		var cleanFile *os.File
+		if *clean != "" {
+			if cleanFile, err = os.Create(*clean); err != nil {
+				return err
+			}
+			defer cleanFile.Close()
+			sink = bulk.MultiSink(sink, bulk.ToCleanList(cleanFile))
+		}
+
+		// An interrupted run still writes the results completed so far
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		summary, err := bulk.New(v, opts...).Run(ctx, src, sink)
+		if *progress {
+			fmt.Fprintln(os.Stderr)
+		}
+
+		if err == nil && rw != nil {
+			err = rw.Close()
+		}
+		if err == nil && cleanFile != nil {
+			err = cleanFile.Close()
+		}
+
+		enc := json.NewEncoder(os.Stderr)
+		enc.SetIndent("", "  ")
+		enc.Encode(summary)
+		if err != nil {
+			return err
+		}
+		if f, ok := w.(*os.File); ok && f != os.Stdout {
+			return f.Close()
+		}
+		return nil
+	}
+}
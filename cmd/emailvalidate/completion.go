@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// shells are the shells completion generates scripts for
+var shells = []string{"bash", "zsh", "fish"}
+
+// flagValues are the completions of flags taking one of a fixed set of
+// values
+var flagValues = map[string][]string{
+	"output":        {formatTable, formatCSV, formatJSON, formatNDJSON},
+	"format":        {"csv", "ndjson"},
+	"privacy-relay": {"allow", "warn", "block"},
+	"emoji-domain":  {"allow", "warn", "block"},
+	"normalize":     {"canonical", "rfc", "provider", "aggressive"},
+	"dots":          {"reject", "warn", "normalize"},
+}
+
+// fileFlags are the flags naming a file or directory, completed with paths
+var fileFlags = map[string]bool{
+	"config":      true,
+	"in":          true,
+	"out":         true,
+	"api-keys":    true,
+	"job-dir":     true,
+	"ip-database": true,
+	"suppress":    true,
+	"bounces":     true,
+	"clean":       true,
+}
+
+// flagInfo describes a flag of a command for the generators
+type flagInfo struct {
+	name     string
+	usage    string
+	value    string // the value placeholder; empty for boolean flags
+	defValue string
+}
+
+// commandFlags returns the flags of cmd in lexical order
+func commandFlags(cmd command) []flagInfo {
+	fs, _ := cmd.flagSet(flag.ContinueOnError)
+	var flags []flagInfo
+	fs.VisitAll(func(f *flag.Flag) {
+		value, usage := flag.UnquoteUsage(f)
+		info := flagInfo{name: f.Name, usage: usage, value: value}
+		switch f.DefValue {
+		case "", "0", "false", "0s":
+		default:
+			info.defValue = f.DefValue
+		}
+		flags = append(flags, info)
+	})
+	return flags
+}
+
+// completion prints the completion script of the shell given as argument
+func completion(fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("usage: emailvalidate completion %s", strings.Join(shells, "|"))
+		}
+		w := bufio.NewWriter(os.Stdout)
+		switch args[0] {
+		case "bash":
+			writeBash(w)
+		case "zsh":
+			writeZsh(w)
+		case "fish":
+			writeFish(w)
+		default:
+			return fmt.Errorf("unknown shell %q; use %s", args[0], strings.Join(shells, ", "))
+		}
+		return w.Flush()
+	}
+}
+
+func writeBash(w io.Writer) {
+	var names []string
+	for _, cmd := range commands {
+		names = append(names, cmd.name)
+	}
+
+	fmt.Fprint(w, `# bash completion for emailvalidate
+
+_emailvalidate() {
+	local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}
+	if [ "$COMP_CWORD" -eq 1 ]; then
+`)
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprint(w, "\t\treturn\n\tfi\n\n\tcase $prev in\n")
+	names = names[:0]
+	for name := range flagValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "\t-%s)\n\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n\t\treturn\n\t\t;;\n", name, strings.Join(flagValues[name], " "))
+	}
+	fmt.Fprint(w, "\tesac\n\n\tcase ${COMP_WORDS[1]} in\n")
+	for _, cmd := range commands {
+		words := append([]string(nil), cmd.values...)
+		for _, f := range commandFlags(cmd) {
+			words = append(words, "-"+f.name)
+		}
+		if len(words) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\t%s)\n\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n\t\t;;\n", cmd.name, strings.Join(words, " "))
+	}
+	fmt.Fprint(w, `	esac
+}
+
+complete -o default -F _emailvalidate emailvalidate
+`)
+}
+
+func writeZsh(w io.Writer) {
+	fmt.Fprint(w, "#compdef emailvalidate\n\n_emailvalidate() {\n\tlocal -a commands\n\tcommands=(\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "\t\t'%s:%s'\n", cmd.name, zshQuote(cmd.summary))
+	}
+	fmt.Fprint(w, `	)
+	if (( CURRENT == 2 )); then
+		_describe command commands
+		return
+	fi
+
+	shift words
+	(( CURRENT-- ))
+	case $words[1] in
+`)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "\t%s)\n\t\t_arguments", cmd.name)
+		for _, f := range commandFlags(cmd) {
+			spec := fmt.Sprintf("-%s[%s]", f.name, zshQuote(zshEscape(f.usage)))
+			switch {
+			case f.value == "":
+			case flagValues[f.name] != nil:
+				spec += fmt.Sprintf(":%s:(%s)", f.value, strings.Join(flagValues[f.name], " "))
+			case fileFlags[f.name]:
+				spec += ":" + f.value + ":_files"
+			default:
+				spec += ":" + f.value + ":"
+			}
+			fmt.Fprintf(w, " \\\n\t\t\t'%s'", spec)
+		}
+		switch {
+		case cmd.values != nil:
+			fmt.Fprintf(w, " \\\n\t\t\t'1:%s:(%s)'", cmd.args, strings.Join(cmd.values, " "))
+		case cmd.args != "":
+			fmt.Fprintf(w, " \\\n\t\t\t'*:%s:'", strings.Trim(cmd.args, "[]. "))
+		}
+		fmt.Fprint(w, "\n\t\t;;\n")
+	}
+	fmt.Fprint(w, "\tesac\n}\n\n_emailvalidate \"$@\"\n")
+}
+
+func writeFish(w io.Writer) {
+	fmt.Fprint(w, "# fish completion for emailvalidate\n\ncomplete -c emailvalidate -f\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "complete -c emailvalidate -n __fish_use_subcommand -a %s -d '%s'\n", cmd.name, fishQuote(cmd.summary))
+	}
+	for _, cmd := range commands {
+		cond := "__fish_seen_subcommand_from " + cmd.name
+		if cmd.values != nil {
+			fmt.Fprintf(w, "complete -c emailvalidate -n '%s' -a '%s'\n", cond, strings.Join(cmd.values, " "))
+		}
+		for _, f := range commandFlags(cmd) {
+			line := fmt.Sprintf("complete -c emailvalidate -n '%s' -o %s -d '%s'", cond, f.name, fishQuote(f.usage))
+			switch {
+			case f.value == "":
+			case flagValues[f.name] != nil:
+				line += fmt.Sprintf(" -x -a '%s'", strings.Join(flagValues[f.name], " "))
+			case fileFlags[f.name]:
+				line += " -r -F"
+			default:
+				line += " -x"
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+// zshQuote escapes s for a single-quoted zsh word
+func zshQuote(s string) string {
+	return strings.ReplaceAll(s, "'", `'\''`)
+}
+
+// zshEscape escapes the characters special in an _arguments description
+func zshEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+}
+
+// fishQuote escapes s for a single-quoted fish word
+func fishQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s)
+}
+
+// manPage prints the man page of emailvalidate in roff
+func manPage(fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		w := bufio.NewWriter(os.Stdout)
+		writeMan(w, time.Now())
+		return w.Flush()
+	}
+}
+
+func writeMan(w io.Writer, date time.Time) {
+	fmt.Fprintf(w, ".TH EMAILVALIDATE 1 %q\n", date.Format("2006-01-02"))
+	fmt.Fprint(w, `.SH NAME
+emailvalidate \- validate email addresses
+.SH SYNOPSIS
+.B emailvalidate
+.I command
+.RI [ flags ]
+.RI [ arguments ]
+.SH DESCRIPTION
+.B emailvalidate
+checks the syntax, domain and, optionally, DNS records of email addresses,
+one at a time, in bulk or behind an HTTP API.
+.B validate
+and
+.B suggest
+read addresses one per line from standard input when none are given.
+.SH COMMANDS
+`)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, ".SS %s\n", roffEscape(cmd.name))
+		fmt.Fprintf(w, ".B emailvalidate %s\n", roffEscape(cmd.name))
+		if cmd.args != "" {
+			fmt.Fprintf(w, ".I %s\n", roffEscape(cmd.args))
+		}
+		fmt.Fprintf(w, ".PP\n%s.\n", roffEscape(strings.ToUpper(cmd.summary[:1])+cmd.summary[1:]))
+		for _, f := range commandFlags(cmd) {
+			fmt.Fprint(w, ".TP\n")
+			if f.value == "" {
+				fmt.Fprintf(w, ".B \\-%s\n", roffEscape(f.name))
+			} else {
+				fmt.Fprintf(w, ".BI \\-%s \" %s\"\n", roffEscape(f.name), roffEscape(f.value))
+			}
+			usage := f.usage
+			if f.defValue != "" {
+				usage += fmt.Sprintf(" (default %s)", f.defValue)
+			}
+			fmt.Fprintln(w, roffEscape(usage))
+		}
+	}
+	fmt.Fprintf(w, `.SH ENVIRONMENT
+.TP
+.B %sFLAG_NAME
+Sets the default of the flag named flag-name, such as
+.B %sDNS_TIMEOUT
+for
+.BR \-dns\-timeout .
+.TP
+.B %sWEBHOOK_SECRET
+Secret signing the webhooks of
+.BR serve .
+.SH FILES
+.TP
+.I ~/%s
+Flag defaults, keyed by flag name.
+Command-line flags win over the environment, which wins over this file.
+.SH EXIT STATUS
+.TP
+0
+Success; every address is valid or risky.
+.TP
+1
+.B validate
+found an invalid address.
+.TP
+2
+Usage or runtime error.
+.TP
+3
+.B validate
+could not verify an address and found no invalid one.
+`, envPrefix, envPrefix, envPrefix, roffEscape(defaultsFile))
+}
+
+// roffEscape escapes backslashes and hyphens, and lines starting with a
+// control character
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompletion(t *testing.T) {
+	for name, write := range map[string]func(*bytes.Buffer){
+		"bash": func(b *bytes.Buffer) { writeBash(b) },
+		"zsh":  func(b *bytes.Buffer) { writeZsh(b) },
+		"fish": func(b *bytes.Buffer) { writeFish(b) },
+		"man":  func(b *bytes.Buffer) { writeMan(b, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) },
+	} {
+		var b bytes.Buffer
+		write(&b)
+		out := b.String()
+		for _, cmd := range commands {
+			if !strings.Contains(out, cmd.name) {
+				t.Errorf("%s: command %s missing", name, cmd.name)
+			}
+		}
+		flag := "dns-timeout"
+		if name == "man" {
+			flag = `\-dns\-timeout`
+		}
+		if !strings.Contains(out, flag) {
+			t.Errorf("%s: flag %s missing", name, flag)
+		}
+
+		if bin, err := exec.LookPath(name); err == nil && name != "man" {
+			// Checks the syntax of the script without running it
+			cmd := exec.Command(bin, "-n")
+			cmd.Stdin = &b
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("%s -n: %v\n%s", name, err, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/internal/flatyaml"
+)
+
+// defaultsFile is the file in the home directory holding flag defaults
+const defaultsFile = ".emailvalidate.yaml"
+
+// envPrefix is the prefix of environment variables holding flag defaults
+const envPrefix = "EMAILVALIDATE_"
+
+// parseFlags parses args after setting the defaults of fs from
+// ~/.emailvalidate.yaml and then from EMAILVALIDATE_* variables, so that
+// the command line wins over the environment, which wins over the file.
+// Keys are flag names, such as "dns-timeout: 2s" in the file or
+// EMAILVALIDATE_DNS_TIMEOUT=2s in the environment; lists in the file may
+// use YAML syntax, and are comma-separated otherwise. Keys naming flags of
+// other commands are ignored.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if home, err := os.UserHomeDir(); err == nil {
+		if err := applyDefaultsFile(fs, filepath.Join(home, defaultsFile)); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		key := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := os.LookupEnv(key); ok && err == nil {
+			if setErr := fs.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("invalid %s: %v", key, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return fs.Parse(args)
+}
+
+// applyDefaultsFile sets the flags of fs named in the YAML file at path,
+// which may not exist
+func applyDefaultsFile(fs *flag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	values, err := flatyaml.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if fs.Lookup(key) == nil {
+			continue
+		}
+		value := fmt.Sprint(values[key])
+		if items, ok := values[key].([]interface{}); ok {
+			parts := make([]string, len(items))
+			for i, item := range items {
+				parts[i] = fmt.Sprint(item)
+			}
+			value = strings.Join(parts, ",")
+		}
+		if err := fs.Set(key, value); err != nil {
+			return fmt.Errorf("%s: invalid %s: %v", path, key, err)
+		}
+	}
+	return nil
+}
+
+// validatorFlags are the flags configuring the validator of every command
+type validatorFlags struct {
+	fs             *flag.FlagSet
+	config         *string
+	dns            *bool
+	dnsTimeout     *time.Duration
+	dnsServer      *string
+	disposable     *bool
+	strict         *bool
+	blockedDomains *string
+	allowedTLDs    *string
+	gravatar       *bool
+	reputation     *bool
+	organization   *bool
+	ipDatabase     *string
+	parking        *bool
+	acceptAll      *string
+	privacyRelay   *string
+	heuristics     *bool
+	tldRisk        *bool
+	tldPolicy      *string
+	emojiDomain    *string
+	smtputf8       *bool
+	looseHosts     *bool
+	analyze        *bool
+	extractName    *bool
+	personalName   *bool
+	normalize      *string
+	dots           *string
+}
+
+// addValidatorFlags registers the validator flags on fs
+func addValidatorFlags(fs *flag.FlagSet) *validatorFlags {
+	return &validatorFlags{
+		fs:             fs,
+		config:         fs.String("config", "", "JSON or YAML validator configuration file"),
+		dns:            fs.Bool("dns", false, "verify MX/A records"),
+		dnsTimeout:     fs.Duration("dns-timeout", 0, "timeout of each DNS lookup"),
+		dnsServer:      fs.String("dns-server", "", "DNS server to query, as host:port, instead of the system resolver"),
+		disposable:     fs.Bool("disposable", false, "reject disposable domains"),
+		strict:         fs.Bool("strict", false, "apply strict syntax rules"),
+		blockedDomains: fs.String("blocked-domains", "", "comma-separated domains to reject"),
+		allowedTLDs:    fs.String("allowed-tlds", "", "comma-separated top-level domains to accept; all by default"),
+		gravatar:       fs.Bool("gravatar", false, "look up valid addresses on Gravatar"),
+		reputation:     fs.Bool("domain-reputation", false, "rate the domains of valid addresses"),
+		organization:   fs.Bool("organization", false, "find the organizations behind the domains of valid addresses"),
+		parking:        fs.Bool("parking", false, "reject parked domains; requires -dns"),
+		ipDatabase:     fs.String("ip-database", "", "ip2asn TSV file locating the mail hosts of valid addresses"),
+		tldRisk:        fs.Bool("tld-risk", false, "lower the score of addresses on frequently abused top-level domains"),
+		tldPolicy:      fs.String("tld-policy", "", "comma-separated category=policy pairs for top-level domains, such as high_abuse=block,brand=warn"),
+		heuristics:     fs.Bool("disposable-heuristics", false, "estimate whether unlisted domains of valid addresses are disposable"),
+		personalName:   fs.Bool("personal-name", false, "flag whether local parts look like a person's name"),
+		extractName:    fs.Bool("extract-name", false, "extract probable first and last names from local parts"),
+		analyze:        fs.Bool("analyze-local-part", false, "report the length, digit ratio, separators and entropy of local parts"),
+		smtputf8:       fs.Bool("smtputf8", false, "accept internationalized local parts"),
+		looseHosts:     fs.Bool("loose-hostnames", false, "accept underscores in domain names outside -strict"),
+		emojiDomain:    fs.String("emoji-domain", "", "treatment of emoji domains: allow, warn or block"),
+		privacyRelay:   fs.String("privacy-relay", "", "treatment of privacy relay addresses: allow, warn or block"),
+		normalize:      fs.String("normalize", "", "normalization of addresses: canonical, rfc, provider or aggressive"),
+		dots:           fs.String("dots", "", "treatment of leading, trailing and repeated dots in local parts outside -strict: reject, warn or normalize"),
+		acceptAll:      fs.String("accept-all-domains", "", "comma-separated domains accepting any local part; \"default\" for well-known providers"),
+	}
+}
+
+// load returns the configuration from the -config file and the
+// environment, overridden by the flags that were set
+func (f *validatorFlags) load() (emailvalidator.Config, error) {
+	cfg, err := emailvalidator.LoadConfig(*f.config)
+	if err != nil {
+		return cfg, err
+	}
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "dns":
+			cfg.DNSCheck = *f.dns
+		case "dns-timeout":
+			cfg.DNSTimeout = emailvalidator.Duration(*f.dnsTimeout)
+		case "disposable":
+			cfg.DisposableCheck = "off"
+			if *f.disposable {
+				cfg.DisposableCheck = "error"
+			}
+		case "strict":
+			cfg.StrictMode = *f.strict
+		case "blocked-domains":
+			cfg.BlockedDomains = splitList(*f.blockedDomains)
+		case "allowed-tlds":
+			cfg.AllowedTLDs = splitList(*f.allowedTLDs)
+		case "gravatar":
+			cfg.GravatarCheck = *f.gravatar
+		case "domain-reputation":
+			cfg.DomainReputation = *f.reputation
+		case "organization":
+			cfg.Organization = *f.organization
+		case "parking":
+			cfg.ParkingCheck = *f.parking
+		case "ip-database":
+			cfg.IPDatabase = *f.ipDatabase
+		case "tld-risk":
+			cfg.TLDRiskCheck = *f.tldRisk
+		case "tld-policy":
+			cfg.TLDPolicy = make(map[string]string)
+			for _, pair := range splitList(*f.tldPolicy) {
+				category, policy, _ := strings.Cut(pair, "=")
+				cfg.TLDPolicy[strings.TrimSpace(category)] = strings.TrimSpace(policy)
+			}
+		case "disposable-heuristics":
+			cfg.DisposableHeuristics = *f.heuristics
+		case "personal-name":
+			cfg.PersonalNameCheck = *f.personalName
+		case "extract-name":
+			cfg.NameExtraction = *f.extractName
+		case "analyze-local-part":
+			cfg.LocalPartAnalysis = *f.analyze
+		case "smtputf8":
+			cfg.SMTPUTF8 = *f.smtputf8
+		case "loose-hostnames":
+			cfg.LooseHostnames = *f.looseHosts
+		case "emoji-domain":
+			cfg.EmojiDomain = *f.emojiDomain
+		case "privacy-relay":
+			cfg.PrivacyRelay = *f.privacyRelay
+		case "normalize":
+			cfg.Normalization = *f.normalize
+		case "dots":
+			cfg.DotPolicy = *f.dots
+		case "accept-all-domains":
+			cfg.AcceptAllDomains = splitList(*f.acceptAll)
+		}
+	})
+	return cfg, nil
+}
+
+// resolver returns a resolver querying -dns-server, or nil for the system
+// resolver
+func (f *validatorFlags) resolver() *net.Resolver {
+	server := *f.dnsServer
+	if server == "" {
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// checker returns the DNS checker for cfg, querying -dns-server if set
+func (f *validatorFlags) checker(cfg emailvalidator.Config) *emailvalidator.DNSChecker {
+	checker := cfg.DNSChecker()
+	if resolver := f.resolver(); resolver != nil {
+		checker = checker.WithResolver(resolver)
+	}
+	return checker
+}
+
+// options returns the validator options for cfg
+func (f *validatorFlags) options(cfg emailvalidator.Config) ([]emailvalidator.Option, error) {
+	opts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DNSCheck {
+		// Replaces the checker of the configuration
+		opts = append(opts, emailvalidator.WithDNSCheck(f.checker(cfg)))
+	}
+	if resolver := f.resolver(); resolver != nil && cfg.ParkingCheck {
+		opts = append(opts, emailvalidator.WithParkingCheck(emailvalidator.NewParkingDetector().WithResolver(resolver)))
+	}
+	return opts, nil
+}
+
+// validator builds the configured validator
+func (f *validatorFlags) validator() (*emailvalidator.EmailValidator, error) {
+	cfg, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := f.options(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return emailvalidator.New(opts...), nil
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
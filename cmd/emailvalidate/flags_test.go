@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestValidatorFlags(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(config, []byte(`{"strict_mode": true, "disposable_check": "warning"}`), 0o600)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	vf := addValidatorFlags(fs)
+	if err := fs.Parse([]string{"-config", config, "-disposable"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := vf.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flags given on the command line win; the others keep the file's values
+	if !cfg.StrictMode || cfg.DisposableCheck != "error" || cfg.DNSCheck {
+		t.Fatalf("got %+v", cfg)
+	}
+}
+
+func TestParseFlagsDefaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	os.WriteFile(filepath.Join(home, defaultsFile), []byte(`
+dns: true
+dns-timeout: 2s
+strict: true
+blocked-domains:
+  - spam.example
+  - junk.example
+addr: ":9090"   # flag of another command
+`), 0o600)
+	t.Setenv("EMAILVALIDATE_DNS_TIMEOUT", "3s")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	vf := addValidatorFlags(fs)
+	if err := parseFlags(fs, []string{"-strict=false"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := vf.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file sets dns and the list, the environment overrides the
+	// timeout and the command line overrides strict
+	if !cfg.DNSCheck || cfg.DNSTimeout != emailvalidator.Duration(3*time.Second) || cfg.StrictMode ||
+		len(cfg.BlockedDomains) != 2 || cfg.BlockedDomains[1] != "junk.example" {
+		t.Fatalf("got %+v", cfg)
+	}
+
+	t.Setenv("EMAILVALIDATE_DNS", "maybe")
+	if err := parseFlags(flag.NewFlagSet("test", flag.ContinueOnError), nil); err != nil {
+		t.Fatalf("flags absent from the set must be ignored: %v", err)
+	}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	addValidatorFlags(fs)
+	if err := parseFlags(fs, nil); err == nil {
+		t.Fatal("accepted EMAILVALIDATE_DNS=maybe")
+	}
+}
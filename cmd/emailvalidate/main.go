@@ -0,0 +1,143 @@
+// Command emailvalidate wraps the email validator library.
+//
+// Usage:
+//
+//	emailvalidate validate [-output table|csv|json|ndjson] [-columns list] [email ...]
+//	emailvalidate bulk [-in file] [-out file] [-format csv|ndjson] [-column email]
+//	emailvalidate suggest [email ...]
+//	emailvalidate serve [-addr :8080] [-max-batch n] [-api-keys file]
+//	emailvalidate completion bash|zsh|fish
+//	emailvalidate man
+//
+// validate and suggest read addresses one per line from standard input
+// when none are given. validate, suggest and bulk print results in the
+// format chosen with -output, with the columns chosen with -columns for
+// csv and table. All commands but suggest take -config, -dns, -disposable and
+// -strict to configure the validator.
+//
+// Flag defaults are read from ~/.emailvalidate.yaml, whose keys are flag
+// names, and then from EMAILVALIDATE_* environment variables, such as
+// EMAILVALIDATE_DNS_TIMEOUT for -dns-timeout; flags on the command line
+// win:
+//
+//	dns: true
+//	dns-server: 1.1.1.1:53
+//	dns-timeout: 2s
+//	blocked-domains: [spam.example, junk.example]
+//	output: csv
+//
+// completion prints a shell completion script and man prints a man page,
+// both generated from the commands and their flags:
+//
+//	emailvalidate completion bash > /etc/bash_completion.d/emailvalidate
+//	emailvalidate man > /usr/local/share/man/man1/emailvalidate.1
+//
+// Exit status:
+//
+//	0  success; every address is valid or risky
+//	1  validate found an invalid address
+//	2  usage or runtime error
+//	3  validate could not verify an address, e.g. DNS was unreachable,
+//	   and found no invalid one
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// exitStatus is returned by commands to exit with a status other than 0
+// or 2 without printing an error
+type exitStatus int
+
+func (s exitStatus) Error() string {
+	return fmt.Sprintf("exit status %d", int(s))
+}
+
+// Exit statuses of validate
+const (
+	exitInvalid     exitStatus = 1
+	exitUnavailable exitStatus = 3
+)
+
+// command is a subcommand. setup registers its flags on fs and returns the
+// function running it with the arguments left after parsing.
+type command struct {
+	name    string
+	args    string
+	summary string
+	// values are the completions of the positional arguments
+	values []string
+	setup  func(fs *flag.FlagSet) func(args []string) error
+}
+
+// commands are the subcommands in the order they are listed
+var commands = []command{
+	{name: "validate", args: "[email ...]", summary: "validate addresses", setup: validate},
+	{name: "bulk", summary: "validate a CSV or NDJSON file", setup: runBulk},
+	{name: "suggest", args: "[email ...]", summary: "correct likely domain typos", setup: suggest},
+	{name: "serve", summary: "run the HTTP validation API", setup: serve},
+}
+
+func init() {
+	// Appended here as they read commands themselves
+	commands = append(commands,
+		command{name: "completion", args: "bash|zsh|fish", summary: "print a shell completion script", values: shells, setup: completion},
+		command{name: "man", summary: "print the man page", setup: manPage},
+	)
+}
+
+// lookup returns the command called name
+func lookup(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// flagSet returns the flag set of cmd and the function running it
+func (cmd command) flagSet(errorHandling flag.ErrorHandling) (*flag.FlagSet, func(args []string) error) {
+	fs := flag.NewFlagSet(cmd.name, errorHandling)
+	return fs, cmd.setup(fs)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := lookup(os.Args[1])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "emailvalidate: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	fs, run := cmd.flagSet(flag.ExitOnError)
+	err := parseFlags(fs, os.Args[2:])
+	if err == nil {
+		err = run(fs.Args())
+	}
+	var status exitStatus
+	if errors.As(err, &status) {
+		os.Exit(int(status))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "emailvalidate %s: %v\n", os.Args[1], err)
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: emailvalidate <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s  %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run emailvalidate <command> -h for the flags of a command.")
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/bulk"
+)
+
+// Output formats accepted by -output
+const (
+	formatTable  = "table"
+	formatCSV    = "csv"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// columns are the result fields selectable with -columns for the csv and
+// table formats
+var columns = map[string]func(email string, r emailvalidator.ValidationResult) string{
+	"email":      func(email string, r emailvalidator.ValidationResult) string { return email },
+	"normalized": func(email string, r emailvalidator.ValidationResult) string { return r.Normalized },
+	"domain":     func(email string, r emailvalidator.ValidationResult) string { return r.Domain },
+	"valid":      func(email string, r emailvalidator.ValidationResult) string { return strconv.FormatBool(r.IsValid) },
+	"verdict":    func(email string, r emailvalidator.ValidationResult) string { return string(r.Verdict) },
+	"score": func(email string, r emailvalidator.ValidationResult) string {
+		return strconv.FormatFloat(r.Score, 'f', -1, 64)
+	},
+	"reason":     func(email string, r emailvalidator.ValidationResult) string { return bulk.Reason(r) },
+	"suggestion": func(email string, r emailvalidator.ValidationResult) string { return r.Suggestion },
+	"breaches":   func(email string, r emailvalidator.ValidationResult) string { return strings.Join(r.Breaches, ";") },
+	"domain_score": func(email string, r emailvalidator.ValidationResult) string {
+		if r.DomainReputation == nil {
+			return ""
+		}
+		return strconv.Itoa(r.DomainReputation.Score)
+	},
+	"organization": func(email string, r emailvalidator.ValidationResult) string {
+		if r.Organization == nil {
+			return ""
+		}
+		return r.Organization.Name
+	},
+	"org_type": func(email string, r emailvalidator.ValidationResult) string {
+		if r.Organization == nil {
+			return ""
+		}
+		return string(r.Organization.Type)
+	},
+	"mail_countries": func(email string, r emailvalidator.ValidationResult) string {
+		if r.MailHosting == nil {
+			return ""
+		}
+		return strings.Join(r.MailHosting.Countries, ";")
+	},
+	"accept_all": func(email string, r emailvalidator.ValidationResult) string {
+		return strconv.FormatBool(r.AcceptAll)
+	},
+	"likely_disposable": func(email string, r emailvalidator.ValidationResult) string {
+		return strconv.FormatFloat(r.LikelyDisposable, 'f', 2, 64)
+	},
+	"tld_risk": func(email string, r emailvalidator.ValidationResult) string {
+		return strconv.FormatFloat(r.TLDRisk, 'f', -1, 64)
+	},
+	"entropy": func(email string, r emailvalidator.ValidationResult) string {
+		if r.LocalPart == nil {
+			return ""
+		}
+		return strconv.FormatFloat(r.LocalPart.Entropy, 'f', -1, 64)
+	},
+	"first_name": func(email string, r emailvalidator.ValidationResult) string {
+		if r.Name == nil {
+			return ""
+		}
+		return r.Name.First
+	},
+	"last_name": func(email string, r emailvalidator.ValidationResult) string {
+		if r.Name == nil {
+			return ""
+		}
+		return r.Name.Last
+	},
+	"personal_name": func(email string, r emailvalidator.ValidationResult) string {
+		if r.PersonalName == nil {
+			return ""
+		}
+		return strconv.FormatBool(*r.PersonalName)
+	},
+	"privacy_relay": func(email string, r emailvalidator.ValidationResult) string { return r.PrivacyRelay },
+	"gravatar": func(email string, r emailvalidator.ValidationResult) string {
+		if r.HasGravatar == nil {
+			return ""
+		}
+		return strconv.FormatBool(*r.HasGravatar)
+	},
+}
+
+// outputFlags are the flags selecting how results are printed
+type outputFlags struct {
+	format  *string
+	columns *string
+}
+
+// addOutputFlags registers -output and -columns on fs with the given
+// defaults
+func addOutputFlags(fs *flag.FlagSet, format, cols string) *outputFlags {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &outputFlags{
+		format:  fs.String("output", format, "output format: table, csv, json or ndjson"),
+		columns: fs.String("columns", cols, "comma-separated csv and table columns: "+strings.Join(names, ", ")),
+	}
+}
+
+// resultWriter prints validation results
+type resultWriter interface {
+	Write(email string, result emailvalidator.ValidationResult) error
+	// Close writes any buffered output
+	Close() error
+}
+
+// newResultWriter returns a resultWriter printing to w as selected by the
+// flags
+func (f *outputFlags) newResultWriter(w io.Writer) (resultWriter, error) {
+	var cols []string
+	for _, name := range strings.Split(*f.columns, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		cols = append(cols, name)
+	}
+
+	switch *f.format {
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		header := make([]string, len(cols))
+		for i, name := range cols {
+			header[i] = strings.ToUpper(name)
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		return &columnWriter{cols: cols, write: func(row []string) error {
+			_, err := fmt.Fprintln(tw, strings.Join(row, "\t"))
+			return err
+		}, flush: tw.Flush}, nil
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(cols)
+		return &columnWriter{cols: cols, write: cw.Write, flush: func() error {
+			cw.Flush()
+			return cw.Error()
+		}}, nil
+	case formatJSON:
+		return &jsonWriter{w: w}, nil
+	case formatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", *f.format)
+}
+
+// columnWriter prints the selected columns of each result as a row
+type columnWriter struct {
+	cols  []string
+	write func([]string) error
+	flush func() error
+}
+
+func (c *columnWriter) Write(email string, result emailvalidator.ValidationResult) error {
+	row := make([]string, len(c.cols))
+	for i, name := range c.cols {
+		row[i] = columns[name](email, result)
+	}
+	return c.write(row)
+}
+
+func (c *columnWriter) Close() error {
+	return c.flush()
+}
+
+// outputRecord is the JSON encoding of one result
+type outputRecord struct {
+	Email  string                          `json:"email"`
+	Result emailvalidator.ValidationResult `json:"result"`
+}
+
+// ndjsonWriter prints one JSON object per line
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(email string, result emailvalidator.ValidationResult) error {
+	return n.enc.Encode(outputRecord{Email: email, Result: result})
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+// jsonWriter prints a JSON array, streaming its elements
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(email string, result emailvalidator.ValidationResult) error {
+	data, err := json.Marshal(outputRecord{Email: email, Result: result})
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if j.count == 0 {
+		sep = "[\n"
+	}
+	j.count++
+	_, err = fmt.Fprintf(j.w, "%s%s", sep, data)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestResultWriters(t *testing.T) {
+	v := emailvalidator.New()
+	emails := []string{"jane@gmial.com", "bad@"}
+
+	tests := []struct {
+		format, columns string
+		want            string
+	}{
+		{formatCSV, "email,valid,suggestion", "email,valid,suggestion\njane@gmial.com,true,jane@gmail.com\nbad@,false,\n"},
+		{formatTable, "email,verdict", "EMAIL           VERDICT\njane@gmial.com  valid\nbad@            invalid\n"},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		of := addOutputFlags(fs, tt.format, tt.columns)
+		var out strings.Builder
+		rw, err := of.newResultWriter(&out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, email := range emails {
+			rw.Write(email, v.Validate(email))
+		}
+		if err := rw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s: got\n%s", tt.format, out.String())
+		}
+	}
+
+	for _, format := range []string{formatJSON, formatNDJSON} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		of := addOutputFlags(fs, format, "email")
+		var out strings.Builder
+		rw, _ := of.newResultWriter(&out)
+		for _, email := range emails {
+			rw.Write(email, v.Validate(email))
+		}
+		rw.Close()
+
+		var records []outputRecord
+		data := out.String()
+		if format == formatNDJSON {
+			data = "[" + strings.ReplaceAll(strings.TrimSpace(data), "\n", ",") + "]"
+		}
+		if err := json.Unmarshal([]byte(data), &records); err != nil {
+			t.Fatalf("%s: %v\n%s", format, err, out.String())
+		}
+		if len(records) != 2 || records[1].Email != "bad@" || records[1].Result.IsValid {
+			t.Errorf("%s: got %+v", format, records)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/prommetrics"
+	"github.com/LBFmuraiybatu/email_validator/redisstore"
+	"github.com/LBFmuraiybatu/email_validator/server"
+)
+
+// serve runs the HTTP validation API until interrupted
+func serve(fs *flag.FlagSet) func(args []string) error {
+	addr := fs.String("addr", ":8080", "listen address")
+	vf := addValidatorFlags(fs)
+	maxBatch := fs.Int("max-batch", server.DefaultMaxBatch, "maximum addresses per batch request")
+	keysFile := fs.String("api-keys", "", "JSON file of API keys to require")
+	readyDomain := fs.String("ready-domain", "gmail.com", "domain resolved by /readyz when -dns is set")
+	jobDir := fs.String("job-dir", "", "directory for asynchronous bulk jobs; enables /v1/jobs")
+	webhookURL := fs.String("webhook-url", "", "URL notified when a bulk job finishes")
+	publicURL := fs.String("public-url", "", "base URL of the server in job links")
+	redisURL := fs.String("redis", "", "redis:// URL of a Redis shared by all instances for caches and lists")
+	redisBlocklist := fs.String("redis-blocklist", "", "Redis set of blocked domains, loaded at startup")
+	return func(args []string) error {
+
+		metrics := prommetrics.New()
+		serverOpts := []server.Option{server.WithMaxBatch(*maxBatch), server.WithMetrics(metrics)}
+		if *keysFile != "" {
+			keys, err := server.LoadAPIKeys(*keysFile)
+			if err != nil {
+				return err
+			}
+			serverOpts = append(serverOpts, server.WithAPIKeys(keys...))
+		}
+		if *jobDir != "" {
+			serverOpts = append(serverOpts, server.WithJobs(*jobDir))
+		}
+		if *webhookURL != "" {
+			// The secret is read from the environment to keep it out of ps output
+			serverOpts = append(serverOpts, server.WithWebhook(*webhookURL, []byte(os.Getenv("EMAILVALIDATE_WEBHOOK_SECRET"))))
+		}
+		if *publicURL != "" {
+			serverOpts = append(serverOpts, server.WithPublicURL(*publicURL))
+		}
+
+		cfg, err := vf.load()
+		if err != nil {
+			return err
+		}
+		opts, err := vf.options(cfg)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, emailvalidator.WithMetrics(metrics))
+		var redis *redisstore.Client
+		if *redisURL != "" {
+			client, err := redisstore.Open(*redisURL)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			redis = client
+		}
+		if *redisBlocklist != "" {
+			if redis == nil {
+				return errors.New("-redis-blocklist requires -redis")
+			}
+			blocked, err := redisstore.NewBlocklist(redis, *redisBlocklist).Load(context.Background())
+			if err != nil {
+				return err
+			}
+			opts = append(opts, emailvalidator.WithBlockedDomainSet(blocked))
+		}
+		if cfg.DNSCheck {
+			checker := vf.checker(cfg)
+			if redis != nil {
+				domains := redisstore.NewDomainCache(redis, "emailvalidate:", time.Hour)
+				metrics.RegisterCache("redis_domains", domains)
+				checker = checker.WithStore(domains)
+			}
+			opts = append(opts, emailvalidator.WithDNSCheck(checker))
+			serverOpts = append(serverOpts, server.WithReadinessCheck("resolver", server.ResolverCheck(checker, *readyDomain)))
+		}
+
+		var validator emailvalidator.Validator = emailvalidator.New(opts...)
+		if redis != nil {
+			results := redisstore.NewResultCache(redis, "emailvalidate:", 24*time.Hour)
+			metrics.RegisterCache("redis_results", results)
+			validator = emailvalidator.Cached(validator, results)
+		}
+
+		handler := server.New(validator, serverOpts...)
+		defer handler.Close()
+		srv := &http.Server{
+			Addr:              *addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      60 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			srv.Shutdown(shutdown)
+		}()
+
+		log.Printf("listening on %s", *addr)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
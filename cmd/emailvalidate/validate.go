@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// validate checks the addresses given as arguments, or one per line on
+// standard input. It exits with exitInvalid if any address is invalid, or
+// else with exitUnavailable if any could not be verified.
+func validate(fs *flag.FlagSet) func(args []string) error {
+	vf := addValidatorFlags(fs)
+	of := addOutputFlags(fs, formatTable, "email,verdict,reason,suggestion")
+	return func(args []string) error {
+		v, err := vf.validator()
+		if err != nil {
+			return err
+		}
+
+		out := bufio.NewWriter(os.Stdout)
+		defer out.Flush()
+		rw, err := of.newResultWriter(out)
+		if err != nil {
+			return err
+		}
+
+		invalid, unavailable := false, false
+		err = eachAddress(args, os.Stdin, func(email string) error {
+			result := v.Validate(email)
+			switch result.Verdict {
+			case emailvalidator.VerdictInvalid:
+				invalid = true
+			case emailvalidator.VerdictUnknown:
+				unavailable = true
+			}
+			return rw.Write(email, result)
+		})
+		if err != nil {
+			return err
+		}
+		if err := rw.Close(); err != nil {
+			return err
+		}
+		switch {
+		case invalid:
+			return exitInvalid
+		case unavailable:
+			return exitUnavailable
+		}
+		return nil
+	}
+}
+
+// suggest prints the likely intended address of each address with a
+// domain typo, and the address unchanged otherwise. With -output it
+// prints results like validate instead.
+func suggest(fs *flag.FlagSet) func(args []string) error {
+	of := addOutputFlags(fs, "", "email,suggestion")
+	return func(args []string) error {
+		v := emailvalidator.New(emailvalidator.WithTypoSuggestions(true))
+		out := bufio.NewWriter(os.Stdout)
+		defer out.Flush()
+
+		if *of.format == "" {
+			return eachAddress(args, os.Stdin, func(email string) error {
+				suggestion := v.Validate(email).Suggestion
+				if suggestion == "" {
+					suggestion = email
+				}
+				_, err := fmt.Fprintln(out, suggestion)
+				return err
+			})
+		}
+
+		rw, err := of.newResultWriter(out)
+		if err != nil {
+			return err
+		}
+		err = eachAddress(args, os.Stdin, func(email string) error {
+			return rw.Write(email, v.Validate(email))
+		})
+		if err != nil {
+			return err
+		}
+		return rw.Close()
+	}
+}
+
+// eachAddress calls fn with each of args, or with each non-empty line of
+// stdin when there are none
+func eachAddress(args []string, stdin io.Reader, fn func(string) error) error {
+	if len(args) > 0 {
+		for _, email := range args {
+			if err := fn(email); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		if email := strings.TrimSpace(scanner.Text()); email != "" {
+			if err := fn(email); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
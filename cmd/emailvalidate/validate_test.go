@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// runValidate runs the validate command with args and stdin, returning
+// what it printed and the error it returned
+func runValidate(t *testing.T, args []string, stdin string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	in := filepath.Join(dir, "stdin")
+	if err := os.WriteFile(in, []byte(stdin), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	inFile, err := os.Open(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inFile.Close()
+	outFile, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outFile.Close()
+
+	oldIn, oldOut := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = inFile, outFile
+	defer func() { os.Stdin, os.Stdout = oldIn, oldOut }()
+
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	run := validate(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	err = run(fs.Args())
+
+	out, rerr := os.ReadFile(outFile.Name())
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	return string(out), err
+}
+
+func TestValidateCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+		err   error
+	}{
+		{"valid", []string{"-output", "csv", "-columns", "email,verdict", "jane@example.com"}, "", "email,verdict\njane@example.com,valid\n", nil},
+		{"invalid", []string{"-output", "csv", "-columns", "email,verdict", "jane@example.com", "bad@"}, "", "email,verdict\njane@example.com,valid\nbad@,invalid\n", exitInvalid},
+		{"stdin", []string{"-output", "csv", "-columns", "email,verdict"}, "jane@example.com\n\n  bad@  \n", "email,verdict\njane@example.com,valid\nbad@,invalid\n", exitInvalid},
+		{"unavailable", []string{"-output", "csv", "-columns", "email,verdict", "-dns", "-dns-server", "127.0.0.1:1", "-dns-timeout", "200ms", "jane@example.com"}, "", "email,verdict\njane@example.com,unknown\n", exitUnavailable},
+	}
+	for _, tt := range tests {
+		out, err := runValidate(t, tt.args, tt.stdin)
+		if err != tt.err {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.err)
+		}
+		if out != tt.want {
+			t.Errorf("%s: got output\n%s", tt.name, out)
+		}
+	}
+}
+
+func TestValidateCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"output format", []string{"-output", "xml", "jane@example.com"}},
+		{"config", []string{"-config", filepath.Join(t.TempDir(), "missing.json"), "jane@example.com"}},
+	}
+	for _, tt := range tests {
+		out, err := runValidate(t, tt.args, "")
+		var status exitStatus
+		if err == nil || errors.As(err, &status) {
+			t.Errorf("%s: got %v, want an error exiting with 2", tt.name, err)
+		}
+		if out != "" {
+			t.Errorf("%s: printed %q", tt.name, out)
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package emailvalidator
 
 import (
+	"math"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// CommonPatterns provides detection for common email patterns
+// CommonPatterns provides detection for common email patterns. It holds no
+// state and is safe for concurrent use.
 type CommonPatterns struct{}
 
 // NewCommonPatterns creates a new CommonPatterns instance
@@ -13,9 +18,14 @@ func NewCommonPatterns() *CommonPatterns {
 	return &CommonPatterns{}
 }
 
-// IsDisposable checks if the email is from a known disposable email provider
+// IsDisposable checks if the email is from a known disposable email
+// provider. Input without an "@" has no domain and is not disposable.
 func (c *CommonPatterns) IsDisposable(email string) bool {
-	domain := strings.ToLower(strings.Split(email, "@")[1])
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
 	
 	// Common disposable email domains (partial list)
 	disposableDomains := []string{
@@ -36,44 +46,202 @@ func (c *CommonPatterns) IsDisposable(email string) bool {
 
 // IsRoleAccount checks if the email is a role-based account
 func (c *CommonPatterns) IsRoleAccount(email string) bool {
-	localPart := strings.ToLower(strings.Split(email, "@")[0])
-	
-	roleAccounts := []string{
-		"admin", "administrator", "webmaster", "postmaster",
-		"hostmaster", "abuse", "noc", "security", "info",
-		"sales", "support", "contact", "help", "mail",
-		"hello", "noreply", "no-reply", "newsletter",
-	}
+	return isRoleAccount(strings.Split(email, "@")[0])
+}
+
+// roleAccounts lists local parts used by role-based accounts
+var roleAccounts = map[string]bool{
+	"admin": true, "administrator": true, "webmaster": true, "postmaster": true,
+	"hostmaster": true, "abuse": true, "noc": true, "security": true, "info": true,
+	"sales": true, "support": true, "contact": true, "help": true, "mail": true,
+	"hello": true, "noreply": true, "no-reply": true, "newsletter": true,
+}
+
+// isRoleAccount reports whether localPart names a role-based account
+func isRoleAccount(localPart string) bool {
+	return roleAccounts[strings.ToLower(localPart)]
+}
+
+// commonPatterns classifies normalized local parts, with the confidence
+// that a matching local part is of the kind. Letters are any script's, with
+// their combining marks, so josé.müller is a first.last too. It is ordered
+// from the most specific pattern, which wins ties.
+var commonPatterns = []struct {
+	pattern     *regexp.Regexp
+	patternType string
+	confidence  float64
+}{
+	{regexp.MustCompile(`^test`), "test_account", 0.9},
+	{regexp.MustCompile(`^demo`), "demo_account", 0.9},
+	{regexp.MustCompile(`^\p{Nd}+$`), "numeric_only", 1},
+	{regexp.MustCompile(`^(?:\p{L}\p{M}*){1,2}\p{Nd}+$`), "initials_with_numbers", 0.7},
+	{regexp.MustCompile(`^(?:\p{L}\p{M}*)+\p{Nd}+$`), "name_with_numbers", 0.6},
+	{regexp.MustCompile(`^(?:\p{L}\p{M}*)+\.(?:\p{L}\p{M}*)+\p{Nd}+$`), "first.last_with_numbers", 0.8},
+	{regexp.MustCompile(`^(?:\p{L}\p{M}*)+\.(?:\p{L}\p{M}*)+$`), "first.last", 0.8},
+}
+
+// PatternMatch is one classification of a local part
+type PatternMatch struct {
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+}
+
+// ClassifyPatterns returns every pattern the local part of email matches,
+// most confident first, so that tester1 is both a test_account and a
+// name_with_numbers. It returns none for local parts of no known pattern.
+func (c *CommonPatterns) ClassifyPatterns(email string) []PatternMatch {
+	localPart := normalizeLocalPart(strings.Split(email, "@")[0])
 	
-	for _, role := range roleAccounts {
-		if localPart == role {
-			return true
+	var matches []PatternMatch
+	for _, p := range commonPatterns {
+		if p.pattern.MatchString(localPart) {
+			matches = append(matches, PatternMatch{Label: p.patternType, Confidence: p.confidence})
 		}
 	}
-	
-	return false
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+	return matches
 }
 
-// HasCommonPattern checks for common email patterns
+// HasCommonPattern returns the label of the most confident pattern of the
+// local part of email, or "custom" when it matches none.
+//
+// Deprecated: use ClassifyPatterns, which reports every matching pattern
+// with its confidence.
 func (c *CommonPatterns) HasCommonPattern(email string) string {
-	localPart := strings.ToLower(strings.Split(email, "@")[0])
-	
-	patterns := map[string]string{
-		`^\d+$`:                              "numeric_only",
-		`^[a-z]+\.[a-z]+$`:                   "first.last",
-		`^[a-z]+\d+$`:                        "name_with_numbers",
-		`^[a-z]+\.[a-z]+\d+$`:                "first.last_with_numbers",
-		`^[a-z]{1,2}\d+$`:                    "initials_with_numbers",
-		`^test`:                              "test_account",
-		`^demo`:                              "demo_account",
+	if matches := c.ClassifyPatterns(email); len(matches) > 0 {
+		return matches[0].Label
 	}
-	
-	for pattern, patternType := range patterns {
-		matched, _ := regexp.MatchString(pattern, localPart)
-		if matched {
-			return patternType
+	return "custom"
+}
+
+// LocalPartAnalysis describes the make-up of a local part as features for
+// fraud models
+type LocalPartAnalysis struct {
+	// Length is the number of characters
+	Length int `json:"length"`
+	// DigitRatio is the share of characters that are digits
+	DigitRatio float64 `json:"digit_ratio"`
+	// Separators counts the dots, hyphens, underscores and plus signs
+	Separators map[string]int `json:"separators,omitempty"`
+	// Entropy is the Shannon entropy of the characters, ignoring case, in
+	// bits per character
+	Entropy float64 `json:"entropy"`
+}
+
+// localPartSeparators are the characters counted in
+// LocalPartAnalysis.Separators
+const localPartSeparators = ".-_+"
+
+// AnalyzeLocalPart returns the features of the local part of email, or of
+// email itself when it has no "@"
+func (c *CommonPatterns) AnalyzeLocalPart(email string) LocalPartAnalysis {
+	local := email
+	if at := strings.LastIndexByte(email, '@'); at >= 0 {
+		local = email[:at]
+	}
+	return analyzeLocalPart(strings.ToLower(local))
+}
+
+// analyzeLocalPart returns the features of a lower-cased local part
+func analyzeLocalPart(local string) LocalPartAnalysis {
+	var a LocalPartAnalysis
+	counts := make(map[rune]int)
+	digits := 0
+	for _, char := range local {
+		a.Length++
+		counts[char]++
+		if unicode.IsDigit(char) {
+			digits++
+		}
+		if strings.ContainsRune(localPartSeparators, char) {
+			if a.Separators == nil {
+				a.Separators = make(map[string]int)
+			}
+			a.Separators[string(char)]++
 		}
 	}
-	
-	return "custom"
-}
\ No newline at end of file
+	if a.Length == 0 {
+		return a
+	}
+
+	a.DigitRatio = round3(float64(digits) / float64(a.Length))
+	for _, n := range counts {
+		p := float64(n) / float64(a.Length)
+		a.Entropy -= p * math.Log2(p)
+	}
+	a.Entropy = round3(a.Entropy)
+	return a
+}
+
+// round3 rounds x to three decimals, keeping reports stable
+func round3(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
+
+// PersonName is a name extracted from a local part. First is a single
+// capital letter when the local part only has an initial. Confidence, from
+// 0 to 1, is how likely the local part is to be the person's name.
+type PersonName struct {
+	First      string  `json:"first"`
+	Last       string  `json:"last"`
+	Confidence float64 `json:"confidence"`
+}
+
+// namePatterns extract first and last names from local parts, with the
+// confidence of a match
+var namePatterns = []struct {
+	pattern    *regexp.Regexp
+	confidence float64
+}{
+	{regexp.MustCompile(`^((?:\p{L}\p{M}*){2,})\.((?:\p{L}\p{M}*){2,})(\p{Nd}*)$`), 0.8},
+	{regexp.MustCompile(`^((?:\p{L}\p{M}*){2,})[_-]((?:\p{L}\p{M}*){2,})(\p{Nd}*)$`), 0.6},
+	{regexp.MustCompile(`^(\p{L}\p{M}*)[._-]((?:\p{L}\p{M}*){2,})(\p{Nd}*)$`), 0.4},
+}
+
+// ExtractName returns the probable name of the owner of email from a
+// first.last, first_last or f.lastname local part, with any trailing
+// digits lowering the confidence. Role accounts such as sales.team yield
+// no name.
+func (c *CommonPatterns) ExtractName(email string) (PersonName, bool) {
+	local := email
+	if at := strings.LastIndexByte(email, '@'); at >= 0 {
+		local = email[:at]
+	}
+	return extractName(local)
+}
+
+// extractName returns the probable name in a local part
+func extractName(local string) (PersonName, bool) {
+	local = normalizeLocalPart(local)
+	for _, p := range namePatterns {
+		m := p.pattern.FindStringSubmatch(local)
+		if m == nil || roleAccounts[m[1]] || roleAccounts[m[2]] {
+			continue
+		}
+		name := PersonName{First: capitalize(m[1]), Last: capitalize(m[2]), Confidence: p.confidence}
+		if m[3] != "" {
+			name.Confidence = round3(name.Confidence - 0.1)
+		}
+		return name, true
+	}
+	return PersonName{}, false
+}
+
+// capitalize title-cases the first letter of word
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToTitle(r)) + word[size:]
+}
+
+// normalizeLocalPart lower-cases local and folds full-width forms, such as
+// ｊｏｈｎ, to their ASCII counterparts so that patterns see one spelling
+func normalizeLocalPart(local string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '\uff01' && r <= '\uff5e' {
+			r -= '\uff01' - '!'
+		}
+		return unicode.ToLower(r)
+	}, local)
+}
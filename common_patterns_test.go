@@ -0,0 +1,98 @@
+package emailvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHasCommonPattern(t *testing.T) {
+	cases := map[string]string{
+		"12345@example.com":      "numeric_only",
+		"jane.doe@example.com":   "first.last",
+		"jane42@example.com":     "name_with_numbers",
+		"jane.doe42@example.com": "first.last_with_numbers",
+		"jd42@example.com":       "initials_with_numbers",
+		"tester@example.com":     "test_account",
+		"demo1@example.com":      "demo_account",
+		"j_d@example.com":        "custom",
+	}
+
+	patterns := NewCommonPatterns()
+	for email, want := range cases {
+		// Repeat to catch order-dependent results
+		for i := 0; i < 10; i++ {
+			if got := patterns.HasCommonPattern(email); got != want {
+				t.Fatalf("HasCommonPattern(%q) = %q, want %q", email, got, want)
+			}
+		}
+	}
+}
+
+func TestClassifyPatterns(t *testing.T) {
+	tests := map[string][]PatternMatch{
+		"tester1@example.com":    {{"test_account", 0.9}, {"name_with_numbers", 0.6}},
+		"jd42@example.com":       {{"initials_with_numbers", 0.7}, {"name_with_numbers", 0.6}},
+		"12345@example.com":      {{"numeric_only", 1}},
+		"j_d@example.com":        nil,
+		"josé.müller@example.de": {{"first.last", 0.8}},
+		"ｔｅｓｔ１@example.com":      {{"test_account", 0.9}, {"name_with_numbers", 0.6}},
+		"иван77@example.ru":      {{"name_with_numbers", 0.6}},
+	}
+	patterns := NewCommonPatterns()
+	for email, want := range tests {
+		if got := patterns.ClassifyPatterns(email); !reflect.DeepEqual(got, want) {
+			t.Errorf("ClassifyPatterns(%q) = %v, want %v", email, got, want)
+		}
+	}
+}
+
+func TestAnalyzeLocalPart(t *testing.T) {
+	patterns := NewCommonPatterns()
+	tests := map[string]LocalPartAnalysis{
+		"AaBb@example.com": {Length: 4, Entropy: 1},
+		"jane.doe+news4@example.com": {
+			Length:     14,
+			DigitRatio: 0.071,
+			Separators: map[string]int{".": 1, "+": 1},
+			Entropy:    3.325,
+		},
+		"1234":         {Length: 4, DigitRatio: 1, Entropy: 2},
+		"@example.com": {},
+	}
+	for email, want := range tests {
+		if got := patterns.AnalyzeLocalPart(email); !reflect.DeepEqual(got, want) {
+			t.Errorf("AnalyzeLocalPart(%q) = %+v, want %+v", email, got, want)
+		}
+	}
+
+	r := New(WithLocalPartAnalysis(true)).Validate("jane42@example.com")
+	if r.LocalPart == nil || r.LocalPart.Length != 6 || r.LocalPart.DigitRatio != 0.333 {
+		t.Errorf("LocalPart = %+v", r.LocalPart)
+	}
+}
+
+func TestExtractName(t *testing.T) {
+	patterns := NewCommonPatterns()
+	tests := map[string]*PersonName{
+		"jane.doe@example.com":        {First: "Jane", Last: "Doe", Confidence: 0.8},
+		"Jane.Doe42@example.com":      {First: "Jane", Last: "Doe", Confidence: 0.7},
+		"jane_doe@example.com":        {First: "Jane", Last: "Doe", Confidence: 0.6},
+		"j.doe@example.com":           {First: "J", Last: "Doe", Confidence: 0.4},
+		"ÉLODIE.ŁUKASZ@example.pl":    {First: "Élodie", Last: "Łukasz", Confidence: 0.8},
+		"e\u0301mile.zola@example.fr": {First: "E\u0301mile", Last: "Zola", Confidence: 0.8},
+		"sales.team@example.com":      nil,
+		"jane@example.com":            nil,
+		"x.y@example.com":             nil,
+	}
+	for email, want := range tests {
+		got, ok := patterns.ExtractName(email)
+		if want == nil && ok || want != nil && got != *want {
+			t.Errorf("ExtractName(%q) = %+v, %v, want %+v", email, got, ok, want)
+		}
+	}
+
+	r := New(WithNameExtraction(true)).Validate("jane.doe@example.com")
+	if r.Name == nil || r.Name.First != "Jane" {
+		t.Errorf("Name = %+v", r.Name)
+	}
+}
@@ -0,0 +1,94 @@
+package emailvalidator
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// The tests in this file share one instance between goroutines and are
+// meant to be run with the race detector: go test -race
+
+// hammer calls fn from several goroutines at once
+func hammer(t *testing.T, fn func(i int)) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				fn(g*50 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+var concurrentEmails = []string{
+	"jane.doe@example.com",
+	"admin@example.org",
+	"user@mailinator.com",
+	"someone@gmial.com",
+	"bad..dots@example.com",
+	"no-at-sign",
+}
+
+func TestValidatorConcurrentUse(t *testing.T) {
+	v := New(
+		WithBlockedDomains([]string{"blocked.com"}),
+		WithAllowedTLDs([]string{"com", "org"}),
+		WithDisposableCheck(SeverityWarning),
+		WithDNSCheck(NewDNSChecker().WithResolver(staticResolver{})),
+	)
+	batch := v.Batch(nil)
+
+	hammer(t, func(i int) {
+		email := concurrentEmails[i%len(concurrentEmails)]
+		v.Validate(email)
+		batch.Validate(email)
+		v.With(WithStrict(i%2 == 0)).Validate(email)
+	})
+}
+
+func TestDNSCheckerConcurrentUse(t *testing.T) {
+	checker := NewDNSChecker().WithResolver(staticResolver{})
+	memo := NewDomainMemo().Limit(2)
+	memoized := checker.Memoized(memo)
+
+	hammer(t, func(i int) {
+		checker.IsDomainValid("example.com")
+		memoized.IsDomainValid("example.com")
+		memo.Snapshot()
+		// Deriving a checker must not modify the shared one
+		checker.WithTimeout(time.Duration(i) * time.Millisecond)
+	})
+
+	if checker.timeout != 5*time.Second {
+		t.Fatalf("shared checker was modified: timeout %v", checker.timeout)
+	}
+}
+
+func TestRulesAndPatternsConcurrentUse(t *testing.T) {
+	domains := map[string]bool{"mailinator.com": true}
+	rules := []ValidationRule{NewFormatRule(), NewLengthRule(), NewDisposableDomainRule(domains)}
+	// The rule keeps its own copy of the list
+	domains["example.com"] = true
+
+	common := NewCommonPatterns()
+	providers := NewEmailPatterns()
+
+	hammer(t, func(i int) {
+		email := concurrentEmails[i%len(concurrentEmails)]
+		for _, rule := range rules {
+			rule.Validate(email)
+		}
+		common.IsRoleAccount(email)
+		common.HasCommonPattern(email)
+		providers.MatchesProviderPattern(email)
+	})
+
+	if err := rules[2].Validate("jane@example.com"); err != nil {
+		t.Fatalf("rule saw a change made after construction: %v", err)
+	}
+}
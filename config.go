@@ -0,0 +1,387 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LBFmuraiybatu/email_validator/internal/flatyaml"
+)
+
+// EnvPrefix is the prefix of environment variables read by LoadConfig
+const EnvPrefix = "EMAILVALIDATOR_"
+
+// Config holds every validator setting in a serializable form so that
+// validators can be configured from files or the environment. Like the
+// options they map to, length limits below 1 restore the defaults.
+type Config struct {
+	StrictMode           bool               `json:"strict_mode"`
+	MaxLength            int                `json:"max_length"`
+	MaxLocalPartLength   int                `json:"max_local_part_length"`
+	MaxDomainLength      int                `json:"max_domain_length"`
+	MinTLDLength         int                `json:"min_tld_length"`
+	AllowedTLDs          []string           `json:"allowed_tlds"`
+	BlockedDomains       []string           `json:"blocked_domains"`
+	AllowIPAddresses     bool               `json:"allow_ip_addresses"`
+	SMTPUTF8             bool               `json:"smtputf8"`
+	LooseHostnames       bool               `json:"loose_hostnames"`
+	DisposableCheck      string             `json:"disposable_check"`
+	PrivacyRelay         string             `json:"privacy_relay"`
+	EmojiDomain          string             `json:"emoji_domain"`
+	DisposableHeuristics bool               `json:"disposable_heuristics"`
+	TypoSuggestions      bool               `json:"typo_suggestions"`
+	SuggestionDomains    map[string]float64 `json:"suggestion_domains"`
+	Normalization        string             `json:"normalization"`
+	DotPolicy            string             `json:"dot_policy"`
+	LocalPartAnalysis    bool               `json:"local_part_analysis"`
+	NameExtraction       bool               `json:"name_extraction"`
+	PersonalNameCheck    bool               `json:"personal_name_check"`
+	NameLocales          []string           `json:"name_locales"`
+	DNSCheck             bool               `json:"dns_check"`
+	DNSTimeout           Duration           `json:"dns_timeout"`
+	GravatarCheck        bool               `json:"gravatar_check"`
+	HIBPAPIKey           string             `json:"hibp_api_key"`
+	DomainReputation     bool               `json:"domain_reputation"`
+	Organization         bool               `json:"organization"`
+	IPDatabase           string             `json:"ip_database"`
+	ParkingCheck         bool               `json:"parking_check"`
+	AcceptAllDomains     []string           `json:"accept_all_domains"`
+	TLDRiskCheck         bool               `json:"tld_risk_check"`
+	TLDRisk              map[string]float64 `json:"tld_risk"`
+	TLDPolicy            map[string]string  `json:"tld_policy"`
+}
+
+// Duration is a time.Duration that decodes from strings such as "5s"
+// or from a number of seconds
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch value := raw.(type) {
+	case string:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(value * float64(time.Second))
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// DefaultConfig returns the configuration used by New
+func DefaultConfig() Config {
+	return Config{
+		MaxLength:          DefaultMaxLength,
+		MaxLocalPartLength: DefaultMaxLocalPartLength,
+		MaxDomainLength:    DefaultMaxDomainLength,
+		MinTLDLength:       DefaultMinTLDLength,
+		DisposableCheck:    "off",
+		TypoSuggestions:    true,
+		DNSTimeout:         Duration(5 * time.Second),
+	}
+}
+
+// LoadConfig reads a JSON or YAML configuration file on top of the defaults
+// and then applies EMAILVALIDATOR_* environment overrides. An empty path
+// loads the defaults and environment only.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("reading config: %v", err)
+		}
+		if err := cfg.decode(path, data); err != nil {
+			return cfg, fmt.Errorf("parsing config %s: %v", path, err)
+		}
+	}
+
+	if err := cfg.ApplyEnv(EnvPrefix); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// decode parses data according to the file extension of path
+func (c *Config) decode(path string, data []byte) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return json.Unmarshal(data, c)
+	case ".yaml", ".yml":
+		values, err := flatyaml.Parse(data)
+		if err != nil {
+			return err
+		}
+		// Round-trip through JSON so both formats share the same field tags
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(encoded, c)
+	default:
+		return fmt.Errorf("unsupported config format %q", filepath.Ext(path))
+	}
+}
+
+// ApplyEnv overrides fields from environment variables named prefix plus the
+// upper-cased JSON field name, e.g. EMAILVALIDATOR_MAX_LENGTH. Lists are
+// comma-separated, and maps are comma-separated key=value pairs.
+func (c *Config) ApplyEnv(prefix string) error {
+	rv := reflect.ValueOf(c).Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		tag := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+		key := prefix + strings.ToUpper(tag)
+		raw, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setField(rv.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// setField assigns a string value to a Config field
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == reflect.TypeOf(Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		m := reflect.MakeMap(field.Type())
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(item, "=")
+			if !ok {
+				return fmt.Errorf("%q is not key=value", item)
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setField(elem, strings.TrimSpace(value)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), elem)
+		}
+		field.Set(m)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// Options converts the configuration into validator options
+func (c Config) Options() ([]Option, error) {
+	opts := []Option{
+		WithStrict(c.StrictMode),
+		WithMaxLength(c.MaxLength),
+		WithMaxLocalPartLength(c.MaxLocalPartLength),
+		WithMaxDomainLength(c.MaxDomainLength),
+		WithMinTLDLength(c.MinTLDLength),
+		WithIPAddresses(c.AllowIPAddresses),
+		WithSMTPUTF8(c.SMTPUTF8),
+		WithLooseHostnames(c.LooseHostnames),
+		WithTypoSuggestions(c.TypoSuggestions),
+		WithLocalPartAnalysis(c.LocalPartAnalysis),
+		WithNameExtraction(c.NameExtraction),
+	}
+	if len(c.SuggestionDomains) > 0 {
+		opts = append(opts, WithSuggestionDomains(c.SuggestionDomains))
+	}
+	if len(c.AllowedTLDs) > 0 {
+		opts = append(opts, WithAllowedTLDs(c.AllowedTLDs))
+	}
+	if len(c.BlockedDomains) > 0 {
+		opts = append(opts, WithBlockedDomains(c.BlockedDomains))
+	}
+	if c.DNSCheck {
+		opts = append(opts, WithDNSCheck(c.DNSChecker()))
+	}
+	if c.ParkingCheck {
+		if !c.DNSCheck {
+			return nil, fmt.Errorf("parking_check requires dns_check")
+		}
+		opts = append(opts, WithParkingCheck(nil))
+	}
+	if len(c.AcceptAllDomains) > 0 {
+		// "default" stands for DefaultAcceptAllDomains
+		var domains []string
+		for _, domain := range c.AcceptAllDomains {
+			if domain == "default" {
+				domains = append(domains, DefaultAcceptAllDomains...)
+			} else {
+				domains = append(domains, domain)
+			}
+		}
+		opts = append(opts, WithAcceptAllDomains(domains...))
+	}
+	categories := make([]string, 0, len(c.TLDPolicy))
+	for category := range c.TLDPolicy {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		switch TLDCategory(category) {
+		case TLDLegacy, TLDGeneric, TLDCountry, TLDBrand, TLDHighAbuse:
+		default:
+			return nil, fmt.Errorf("invalid tld_policy category %q (want legacy, generic, country, brand or high_abuse)", category)
+		}
+		policy, err := ParsePolicy(strings.ToLower(c.TLDPolicy[category]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tld_policy %q for %s (want allow, warn or block)", c.TLDPolicy[category], category)
+		}
+		opts = append(opts, WithTLDPolicy(TLDCategory(category), policy))
+	}
+	if c.TLDRiskCheck || len(c.TLDRisk) > 0 {
+		opts = append(opts, WithTLDRisk(c.TLDRisk))
+	}
+	if c.PersonalNameCheck {
+		var dictionaries []*NameDictionary
+		for _, locale := range c.NameLocales {
+			d, err := LoadNameDictionary(locale)
+			if err != nil {
+				return nil, fmt.Errorf("invalid name_locales: %v", err)
+			}
+			dictionaries = append(dictionaries, d)
+		}
+		opts = append(opts, WithPersonalNameDetector(NewPersonalNameDetector(dictionaries...)))
+	}
+	if c.DisposableHeuristics {
+		opts = append(opts, WithDisposableHeuristics(nil))
+	}
+	if c.GravatarCheck {
+		opts = append(opts, WithGravatarCheck(nil))
+	}
+	if c.HIBPAPIKey != "" {
+		opts = append(opts, WithBreachCheck(NewBreachChecker(c.HIBPAPIKey)))
+	}
+	if c.DomainReputation {
+		opts = append(opts, WithDomainReputation(nil))
+	}
+	if c.Organization {
+		opts = append(opts, WithOrganization(nil))
+	}
+	if c.IPDatabase != "" {
+		db, err := LoadIPRanges(c.IPDatabase)
+		if err != nil {
+			return nil, fmt.Errorf("loading ip_database: %v", err)
+		}
+		opts = append(opts, WithMailHosting(NewMailHostingLocator(db)))
+	}
+
+	switch check := strings.ToLower(c.DisposableCheck); check {
+	case "", "off":
+	default:
+		severity, err := ParseSeverity(check)
+		if err != nil {
+			return nil, fmt.Errorf("invalid disposable_check %q (want off, info, warning or error)", c.DisposableCheck)
+		}
+		opts = append(opts, WithDisposableCheck(severity))
+	}
+
+	if c.EmojiDomain != "" {
+		policy, err := ParsePolicy(strings.ToLower(c.EmojiDomain))
+		if err != nil {
+			return nil, fmt.Errorf("invalid emoji_domain %q (want allow, warn or block)", c.EmojiDomain)
+		}
+		opts = append(opts, WithEmojiDomainPolicy(policy))
+	}
+	if c.Normalization != "" {
+		n, err := ParseNormalization(strings.ToLower(c.Normalization))
+		if err != nil {
+			return nil, fmt.Errorf("invalid normalization %q (want canonical, rfc, provider or aggressive)", c.Normalization)
+		}
+		opts = append(opts, WithNormalization(n))
+	}
+	if c.DotPolicy != "" {
+		policy, err := ParseDotPolicy(strings.ToLower(c.DotPolicy))
+		if err != nil {
+			return nil, fmt.Errorf("invalid dot_policy %q (want reject, warn or normalize)", c.DotPolicy)
+		}
+		opts = append(opts, WithDotPolicy(policy))
+	}
+	if c.PrivacyRelay != "" {
+		policy, err := ParsePolicy(strings.ToLower(c.PrivacyRelay))
+		if err != nil {
+			return nil, fmt.Errorf("invalid privacy_relay %q (want allow, warn or block)", c.PrivacyRelay)
+		}
+		opts = append(opts, WithPrivacyRelayPolicy(policy))
+	}
+
+	return opts, nil
+}
+
+// NewFromConfig creates a validator from a configuration
+func NewFromConfig(cfg Config) (*EmailValidator, error) {
+	opts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+	return New(opts...), nil
+}
+
+// DNSChecker creates a DNSChecker using the configured timeout
+func (c Config) DNSChecker() *DNSChecker {
+	checker := NewDNSChecker()
+	if c.DNSTimeout > 0 {
+		checker = checker.WithTimeout(time.Duration(c.DNSTimeout))
+	}
+	return checker
+}
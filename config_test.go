@@ -0,0 +1,100 @@
+package emailvalidator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigYAML(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+# validator settings
+strict_mode: true
+max_local_part_length: 32
+allowed_tlds: [com, org]
+blocked_domains:
+  - spam.com
+  - "fake.org" # quoted
+disposable_check: error
+typo_suggestions: false
+dns_timeout: 2s
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cfg.StrictMode || cfg.MaxLocalPartLength != 32 || cfg.MaxLength != DefaultMaxLength {
+		t.Errorf("unexpected scalar values: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.AllowedTLDs, []string{"com", "org"}) {
+		t.Errorf("unexpected allowed TLDs: %v", cfg.AllowedTLDs)
+	}
+	if !reflect.DeepEqual(cfg.BlockedDomains, []string{"spam.com", "fake.org"}) {
+		t.Errorf("unexpected blocked domains: %v", cfg.BlockedDomains)
+	}
+	if cfg.TypoSuggestions || time.Duration(cfg.DNSTimeout) != 2*time.Second {
+		t.Errorf("unexpected toggles: %+v", cfg)
+	}
+
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Validate("user@spam.com").IsValid || v.Validate("user@mailinator.com").IsValid {
+		t.Error("configured lists and rules were not applied")
+	}
+}
+
+func TestLoadConfigJSONWithEnv(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"max_length": 100, "blocked_domains": ["spam.com"]}`)
+	t.Setenv("EMAILVALIDATOR_MAX_LENGTH", "120")
+	t.Setenv("EMAILVALIDATOR_BLOCKED_DOMAINS", "a.com, b.com")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxLength != 120 {
+		t.Errorf("expected env override of max_length, got %d", cfg.MaxLength)
+	}
+	if !reflect.DeepEqual(cfg.BlockedDomains, []string{"a.com", "b.com"}) {
+		t.Errorf("unexpected blocked domains: %v", cfg.BlockedDomains)
+	}
+}
+
+func TestConfigRejectsInvalidValues(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DisposableCheck = "sometimes"
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Error("expected an error for an invalid disposable_check value")
+	}
+
+	if _, err := LoadConfig(writeConfig(t, "config.toml", "")); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
+
+func TestConfigLengthLimitsFallBack(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxLength, cfg.MaxLocalPartLength, cfg.MaxDomainLength = 0, -1, -253
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.maxLength != DefaultMaxLength || v.maxLocalLength != DefaultMaxLocalPartLength || v.maxDomainLength != DefaultMaxDomainLength {
+		t.Errorf("got limits %d/%d/%d", v.maxLength, v.maxLocalLength, v.maxDomainLength)
+	}
+}
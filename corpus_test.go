@@ -0,0 +1,169 @@
+package emailvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+// profile is a strictness profile the corpus runs against
+type profile int
+
+const (
+	lenient profile = 1 << iota
+	strict
+	lenientUTF8
+	strictUTF8
+
+	allProfiles  = lenient | strict | lenientUTF8 | strictUTF8
+	utf8Profiles = lenientUTF8 | strictUTF8
+	noProfiles   = profile(0)
+)
+
+// profiles builds the validator of each profile, without network checks
+var profiles = map[profile]func() *EmailValidator{
+	lenient:     func() *EmailValidator { return New() },
+	strict:      func() *EmailValidator { return NewStrict() },
+	lenientUTF8: func() *EmailValidator { return New(WithSMTPUTF8(true)) },
+	strictUTF8:  func() *EmailValidator { return NewStrict(WithSMTPUTF8(true)) },
+}
+
+var profileNames = map[profile]string{
+	lenient:     "lenient",
+	strict:      "strict",
+	lenientUTF8: "lenient+smtputf8",
+	strictUTF8:  "strict+smtputf8",
+}
+
+// corpus lists tricky addresses with the profiles that accept them. The
+// validator supports the dot-atom form of RFC 5322 only: quoted local
+// parts, comments, address literals and single-label domains are rejected
+// although the RFCs allow them, and entries from RFC examples say so.
+var corpus = []struct {
+	address string
+	valid   profile
+	source  string
+}{
+	// Ordinary addresses
+	{"simple@example.com", allProfiles, "RFC 5322"},
+	{"very.common@example.com", allProfiles, "RFC 5322"},
+	{"x@example.com", allProfiles, "one-letter local part"},
+	{"long.email-address-with-hyphens@and.subdomains.example.com", allProfiles, "RFC 5322"},
+	{"user.name+tag+sorting@example.com", allProfiles, "subaddress"},
+	{"JANE.DOE@EXAMPLE.COM", allProfiles, "upper case"},
+	{"1234567890@example.com", allProfiles, "numeric local part"},
+	{"jane@sub-domain.example.co.uk", allProfiles, "hyphenated label"},
+	{"jane@123.example.com", allProfiles, "numeric label"},
+	{"jane@xn--bcher-kva.example", allProfiles, "punycode label"},
+	{"user-@example.org", allProfiles, "trailing hyphen in local part"},
+
+	// Special characters of RFC 3696 section 3
+	{"customer/department=shipping@example.com", allProfiles, "RFC 3696"},
+	{"$A12345@example.com", allProfiles, "RFC 3696"},
+	{"!def!xyz%abc@example.com", allProfiles, "RFC 3696"},
+	{"_somename@example.com", allProfiles, "RFC 3696"},
+	{"name/surname@example.com", allProfiles, "RFC 5322"},
+	{"mailhost!username@example.org", allProfiles, "bang path"},
+	{"user%example.com@example.org", allProfiles, "percent hack"},
+	{"#!$%&'*+-/=?^_`{}|~@example.org", allProfiles, "every atext symbol"},
+
+	// Length limits of RFC 5321
+	{strings.Repeat("a", 64) + "@example.com", allProfiles, "64-octet local part"},
+	{strings.Repeat("a", 65) + "@example.com", noProfiles, "65-octet local part"},
+	{strings.Repeat("é", 32) + "@example.com", utf8Profiles, "64-octet local part of 32 characters"},
+	{strings.Repeat("é", 33) + "@example.com", noProfiles, "66-octet local part of 33 characters"},
+	{"jane@" + strings.Repeat("a", 63) + ".com", allProfiles, "63-octet label"},
+	{"jane@" + strings.Repeat("a", 64) + ".com", noProfiles, "64-octet label"},
+	{"jane@" + longDomain(245) + ".com", allProfiles, "254-octet address"},
+	{"jane@" + longDomain(246) + ".com", noProfiles, "255-octet address"},
+
+	// Structure
+	{"", noProfiles, "empty input"},
+	{"plainaddress", noProfiles, "no @"},
+	{"@example.com", noProfiles, "empty local part"},
+	{"jane@", noProfiles, "empty domain"},
+	{"jane@@example.com", noProfiles, "doubled @"},
+	{"A@b@c@example.com", noProfiles, "RFC 3696: unquoted @"},
+	{"mailto:jane@example.com", noProfiles, "pasted mailto link"},
+	{"Jane Doe <jane@example.com>", noProfiles, "display name"},
+	{" jane@example.com", noProfiles, "bug report: leading space"},
+	{"jane@example.com ", noProfiles, "bug report: trailing space"},
+	{"jane@example.com\n", noProfiles, "bug report: trailing newline"},
+	{"jane\x00@example.com", noProfiles, "NUL byte"},
+
+	// Dots
+	{"john..doe@example.com", noProfiles, "consecutive dots"},
+	{".jane@example.com", noProfiles, "leading dot"},
+	{"jane.@example.com", noProfiles, "trailing dot"},
+	{"jane@example..com", noProfiles, "empty label"},
+	{"jane@.example.com", noProfiles, "leading empty label"},
+	{"jane@example.com.", noProfiles, "bug report: root dot"},
+
+	// Domain characters
+	{"jane@-example.com", noProfiles, "label starting with hyphen"},
+	{"jane@example-.com", noProfiles, "label ending with hyphen"},
+	{"i.like.underscores@but_they_are_not_allowed.com", noProfiles, "underscore in domain"},
+	{"jane@exa mple.com", noProfiles, "space in domain"},
+	{"jane@exämple.com", noProfiles, "unencoded IDN domain"},
+	{"我買@屋企.香港", noProfiles, "RFC 6530: unencoded IDN domain"},
+
+	// Top-level domains
+	{"a@b.c", noProfiles, "one-letter TLD"},
+	{"jane@example.io", allProfiles, "two-letter TLD"},
+	{"user@example.123", noProfiles, "numeric TLD"},
+	{"jane@example.c0m", allProfiles, "TLD with a digit"},
+	{"jane@192.168.2.1", noProfiles, "bare IPv4 domain"},
+
+	// Forms the RFCs allow but the validator does not support
+	{`"john..doe"@example.org`, noProfiles, "RFC 5322: quoted string"},
+	{`"very.unusual.@.unusual.com"@example.com`, noProfiles, "RFC 5322: quoted string"},
+	{`Abc\@def@example.com`, noProfiles, "RFC 3696: quoted pair"},
+	{"jane(comment)@example.com", noProfiles, "RFC 5322: comment"},
+	{"jane@example.com(comment)", noProfiles, "RFC 5322: comment"},
+	{"admin@mailserver1", noProfiles, "RFC 5321: single-label domain"},
+	{"jane@localhost", noProfiles, "single-label domain"},
+	{"user@[192.168.2.1]", noProfiles, "RFC 5321: address literal"},
+	{"user@[IPv6:2001:db8::1]", noProfiles, "RFC 5321: address literal"},
+
+	// Characters outside atext
+	{`a"b(c)d,e:f;g<h>i[j\k]l@example.com`, noProfiles, "specials"},
+	{`just"not"right@example.com`, noProfiles, "unbalanced quotes"},
+	{`this is"not\allowed@example.com`, noProfiles, "space and backslash"},
+	{"jane\u200b@example.com", noProfiles, "bug report: zero-width space"},
+	{"😀@example.com", noProfiles, "emoji local part"},
+
+	// Internationalized local parts of RFC 6531
+	{"josé@example.com", utf8Profiles, "accented letter"},
+	{"用户@example.com", utf8Profiles, "RFC 6530"},
+	{"δοκιμή@example.com", utf8Profiles, "Greek"},
+	{"ｊａｎｅ@example.com", utf8Profiles, "full-width letters"},
+	{"user²@example.com", lenientUTF8, "superscript digit, not a decimal digit"},
+}
+
+// longDomain returns a domain of n octets made of 63-octet labels
+func longDomain(n int) string {
+	var labels []string
+	for n > 0 {
+		size := n
+		if size > 63 {
+			size = 63
+		}
+		labels = append(labels, strings.Repeat("a", size))
+		n -= size + 1
+	}
+	return strings.Join(labels, ".")
+}
+
+func TestCorpus(t *testing.T) {
+	for p, build := range profiles {
+		v := build()
+		for _, c := range corpus {
+			want := c.valid&p != 0
+			if got := v.Validate(c.address).IsValid; got != want {
+				t.Errorf("%s: %q (%s): valid = %v, want %v", profileNames[p], c.address, c.source, got, want)
+			}
+			if got := v.IsValidSyntax(c.address); got != want {
+				t.Errorf("%s: IsValidSyntax(%q) (%s) = %v, want %v", profileNames[p], c.address, c.source, got, want)
+			}
+		}
+	}
+}
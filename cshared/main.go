@@ -0,0 +1,71 @@
+// Command cshared builds the offline checks of the validator as a C shared
+// library, so that services in other languages embed it without a network
+// hop:
+//
+//	go build -buildmode=c-shared -o libemailvalidator.so ./cshared
+//
+// The library exports
+//
+//	char *ValidateJSON(char *email);
+//	char *Configure(char *config);
+//	void FreeString(char *s);
+//
+// ValidateJSON returns the JSON encoding of the ValidationResult of email.
+// Configure replaces the validator used by later calls with one built from
+// the JSON encoding of emailvalidator.Config, and returns NULL or an error
+// message. Strings returned by the library must be released with
+// FreeString. From Python:
+//
+//	lib = ctypes.CDLL("./libemailvalidator.so")
+//	lib.ValidateJSON.restype = ctypes.c_void_p
+//	lib.FreeString.argtypes = [ctypes.c_void_p]
+//	ptr = lib.ValidateJSON(b"jane@gmial.com")
+//	result = json.loads(ctypes.string_at(ptr))
+//	lib.FreeString(ptr)
+//
+// Only the checks made without network requests can be configured, as
+// lookups would block the calling thread; Configure refuses the others.
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"unsafe"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/internal/offline"
+)
+
+// validator serves ValidateJSON; Configure swaps it
+var validator atomic.Pointer[emailvalidator.EmailValidator]
+
+func init() {
+	validator.Store(emailvalidator.New())
+}
+
+//export ValidateJSON
+func ValidateJSON(email *C.char) *C.char {
+	// A ValidationResult always encodes
+	data, _ := json.Marshal(validator.Load().Validate(C.GoString(email)))
+	return C.CString(string(data))
+}
+
+//export Configure
+func Configure(config *C.char) *C.char {
+	v, err := offline.FromConfig(C.GoString(config))
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	validator.Store(v)
+	return nil
+}
+
+//export FreeString
+func FreeString(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func main() {}
@@ -0,0 +1,39 @@
+package emailvalidator
+
+import (
+	"embed"
+	"strings"
+)
+
+// Data files embedded in the package
+var (
+	//go:embed data/tlds.txt
+	tldData string
+
+	//go:embed data/names/*.txt
+	nameData embed.FS
+)
+
+// dataSection is a named list of words in a data file
+type dataSection struct {
+	name  string
+	words []string
+}
+
+// parseSections reads data files: "[name]" lines start sections, which
+// hold whitespace-separated words, and "#" lines are comments
+func parseSections(data string) []dataSection {
+	var sections []dataSection
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			sections = append(sections, dataSection{name: line[1 : len(line)-1]})
+		case len(sections) > 0:
+			last := &sections[len(sections)-1]
+			last.words = append(last.words, strings.Fields(line)...)
+		}
+	}
+	return sections
+}
@@ -0,0 +1,165 @@
+package emailvalidator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultDisposableMXBackends are the domains of MX hosts serving
+// disposable mail services, which also receive the mail of their many
+// unlisted domains
+var DefaultDisposableMXBackends = []string{
+	"mailinator.com",
+	"guerrillamail.com",
+	"yopmail.com",
+	"tempmail.com",
+	"10minutemail.com",
+	"mail.tm",
+	"temp-mail.org",
+	"dropmail.me",
+	"mailnesia.com",
+	"emailondeck.com",
+}
+
+// Weights of the disposable heuristics, combined as independent
+// probabilities
+const (
+	likelihoodMXBackend      = 0.9
+	likelihoodYoungCatchAll  = 0.6 // registered less than 30 days ago, wildcard DNS
+	likelihoodYoungDomain    = 0.25
+	likelihoodThrowawayLabel = 0.4
+)
+
+// DisposableHeuristics estimates how likely unlisted domains are to belong
+// to disposable services: from MX hosts on known disposable backends, very
+// young domains with catch-all DNS, and numeric throwaway subdomains.
+// Catch-all behaviour is approximated by wildcard DNS, as the validator
+// does not probe mail servers. Estimates are cached per domain. A value is
+// safe for concurrent use; its With methods return modified copies.
+type DisposableHeuristics struct {
+	resolver ReputationResolver
+	age      DomainAgeFunc
+	backends *DomainSet
+	timeout  time.Duration
+	cache    *ttlCache[string, float64]
+}
+
+// NewDisposableHeuristics creates a DisposableHeuristics using the default
+// resolver, RDAP registration dates and DefaultDisposableMXBackends
+func NewDisposableHeuristics() *DisposableHeuristics {
+	return &DisposableHeuristics{
+		resolver: net.DefaultResolver,
+		age:      RDAPRegistration(http.DefaultClient, DefaultRDAPURL),
+		backends: NewDomainSet(DefaultDisposableMXBackends...),
+		timeout:  5 * time.Second,
+		cache:    newTTLCache[string, float64](10000, 6*time.Hour),
+	}
+}
+
+// WithResolver returns a copy using resolver for lookups
+func (h *DisposableHeuristics) WithResolver(resolver ReputationResolver) *DisposableHeuristics {
+	c := *h
+	c.resolver = resolver
+	return &c
+}
+
+// WithDomainAge returns a copy looking up registration dates with age; nil
+// disables the age heuristics
+func (h *DisposableHeuristics) WithDomainAge(age DomainAgeFunc) *DisposableHeuristics {
+	c := *h
+	c.age = age
+	return &c
+}
+
+// WithMXBackends returns a copy recognizing MX hosts under domains instead
+// of DefaultDisposableMXBackends. It starts with an empty cache.
+func (h *DisposableHeuristics) WithMXBackends(domains ...string) *DisposableHeuristics {
+	c := *h
+	c.backends = NewDomainSet(domains...)
+	c.cache = newTTLCache[string, float64](10000, 6*time.Hour)
+	return &c
+}
+
+// Likelihood returns the probability, from 0 to 1, that domain belongs to
+// a disposable service. Failed lookups leave their heuristic out; the
+// estimate is then not cached.
+func (h *DisposableHeuristics) Likelihood(ctx context.Context, domain string) float64 {
+	domain = strings.ToLower(domain)
+	if p, ok := h.cache.get(domain); ok {
+		return p
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	conclusive := true
+	unlikely := 1.0
+	weigh := func(p float64) { unlikely *= 1 - p }
+
+	if isThrowawayLabel(domain) {
+		weigh(likelihoodThrowawayLabel)
+	}
+
+	mx, err := h.resolver.LookupMX(ctx, domain)
+	conclusive = conclusive && (err == nil || isNotFound(err))
+	for _, record := range mx {
+		host := strings.TrimSuffix(record.Host, ".")
+		if h.backends.Match(host) || disposableDomains.Match(host) {
+			weigh(likelihoodMXBackend)
+			break
+		}
+	}
+
+	if h.age != nil {
+		registered, err := h.age(ctx, domain)
+		if err != nil {
+			conclusive = false
+		} else if time.Since(registered) < 30*24*time.Hour {
+			label := make([]byte, 8)
+			rand.Read(label)
+			addrs, err := h.resolver.LookupHost(ctx, hex.EncodeToString(label)+"."+domain)
+			switch {
+			case err == nil && len(addrs) > 0:
+				weigh(likelihoodYoungCatchAll)
+			case err == nil || isNotFound(err):
+				weigh(likelihoodYoungDomain)
+			default:
+				conclusive = false
+			}
+		}
+	}
+
+	p := 1 - unlikely
+	if conclusive {
+		h.cache.add(domain, p)
+	}
+	return p
+}
+
+// CacheStats implements CacheMetrics for the cache of estimates
+func (h *DisposableHeuristics) CacheStats() CacheStats {
+	return h.cache.stats()
+}
+
+// isThrowawayLabel reports whether the first label of a subdomain looks
+// generated, ending in at least four digits that make up half of it, such
+// as 20240611.example.com or box48213.example.com
+func isThrowawayLabel(domain string) bool {
+	label, parent, _ := strings.Cut(domain, ".")
+	if !strings.Contains(parent, ".") {
+		return false
+	}
+	digits := len(label) - len(strings.TrimRight(label, "0123456789"))
+	return digits >= 4 && digits*2 >= len(label)
+}
+
+// checkDisposableHeuristics estimates whether an unlisted domain is
+// disposable
+func (v *EmailValidator) checkDisposableHeuristics(ctx context.Context, domain string, result *ValidationResult) {
+	result.LikelyDisposable = v.disposableHeuristics.Likelihood(ctx, domain)
+}
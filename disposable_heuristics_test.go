@@ -0,0 +1,60 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/LBFmuraiybatu/email_validator/testutil"
+)
+
+func TestDisposableHeuristics(t *testing.T) {
+	registered := map[string]time.Time{
+		"wildcard.example": time.Now().Add(-48 * time.Hour),
+		"fresh.example":    time.Now().Add(-48 * time.Hour),
+	}
+	age := func(ctx context.Context, domain string) (time.Time, error) {
+		if t, ok := registered[domain]; ok {
+			return t, nil
+		}
+		return time.Now().AddDate(-10, 0, 0), nil
+	}
+	resolver := testutil.NewResolver()
+	resolver.AddMX("good.example", "mx.good.example.")
+	resolver.AddMX("burner.example", "mail.mailinator.com.")
+	resolver.AddMX("*.burner.example", "mail.mailinator.com.")
+	resolver.AddIP("*.wildcard.example", "192.0.2.1")
+	h := NewDisposableHeuristics().
+		WithResolver(resolver).
+		WithDomainAge(age)
+
+	tests := map[string]float64{
+		"good.example":            0,
+		"burner.example":          likelihoodMXBackend,
+		"wildcard.example":        likelihoodYoungCatchAll,
+		"fresh.example":           likelihoodYoungDomain,
+		"box48213.good.example":   likelihoodThrowawayLabel,
+		"mail2.good.example":      0,
+		"20240611.burner.example": 1 - (1-likelihoodMXBackend)*(1-likelihoodThrowawayLabel),
+	}
+	for domain, want := range tests {
+		if got := h.Likelihood(context.Background(), domain); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Likelihood(%q) = %v, want %v", domain, got, want)
+		}
+	}
+
+	failing := h.WithDomainAge(func(ctx context.Context, domain string) (time.Time, error) {
+		return time.Time{}, errors.New("rdap down")
+	})
+	if got := failing.Likelihood(context.Background(), "burner.example"); got != likelihoodMXBackend {
+		t.Errorf("with failing age lookups: %v", got)
+	}
+
+	v := New(WithDisposableHeuristics(h))
+	r := v.Validate("jane@burner.example")
+	if r.LikelyDisposable != likelihoodMXBackend || r.Score >= New().Validate("jane@burner.example").Score {
+		t.Errorf("burner.example: likelihood %v, score %v", r.LikelyDisposable, r.Score)
+	}
+}
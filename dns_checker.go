@@ -1,59 +1,170 @@
 package emailvalidator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
+	"sync"
 	"time"
 )
 
-// DNSChecker provides DNS validation for email domains
+// Resolver performs the DNS lookups used by DNSChecker. *net.Resolver
+// implements it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// DomainStore holds the conclusive outcomes of domain lookups, so that
+// several processes share them. Domains are lower case. Implementations
+// must be safe for concurrent use and report failures as misses.
+type DomainStore interface {
+	LookupDomain(domain string) (valid, ok bool)
+	StoreDomain(domain string, valid bool)
+}
+
+// DNSChecker provides DNS validation for email domains. A checker is safe
+// for concurrent use; WithTimeout, WithResolver, WithStore and Memoized
+// return modified copies instead of changing a checker that may be shared.
 type DNSChecker struct {
-	timeout time.Duration
+	timeout  time.Duration
+	resolver Resolver
+	memo     *DomainMemo
+	store    DomainStore
 }
 
 // NewDNSChecker creates a new DNSChecker instance
 func NewDNSChecker() *DNSChecker {
 	return &DNSChecker{
-		timeout: 5 * time.Second,
+		timeout:  5 * time.Second,
+		resolver: net.DefaultResolver,
 	}
 }
 
-// WithTimeout sets the DNS lookup timeout
+// WithResolver returns a copy of the checker using resolver for lookups.
+// The checker itself is left unchanged.
+func (d *DNSChecker) WithResolver(resolver Resolver) *DNSChecker {
+	c := *d
+	c.resolver = resolver
+	return &c
+}
+
+// WithStore returns a copy of the checker that consults store before each
+// lookup and records conclusive outcomes in it. Transient failures are not
+// stored, so they are retried.
+func (d *DNSChecker) WithStore(store DomainStore) *DNSChecker {
+	c := *d
+	c.store = store
+	return &c
+}
+
+// Memoized returns a copy of the checker that records the outcome of
+// IsDomainValid per domain in memo, so that each domain is looked up once
+// even when queried concurrently. A nil memo starts a fresh one. It is meant
+// for batch runs.
+func (d *DNSChecker) Memoized(memo *DomainMemo) *DNSChecker {
+	if memo == nil {
+		memo = NewDomainMemo()
+	}
+	c := *d
+	c.memo = memo
+	return &c
+}
+
+// WithTimeout returns a copy of the checker with the given lookup timeout.
+// The checker itself is left unchanged.
 func (d *DNSChecker) WithTimeout(timeout time.Duration) *DNSChecker {
-	d.timeout = timeout
-	return d
+	c := *d
+	c.timeout = timeout
+	return &c
 }
 
 // HasMXRecords checks if the domain has MX records
 func (d *DNSChecker) HasMXRecords(domain string) (bool, error) {
-	mxRecords, err := net.LookupMX(domain)
+	return d.hasMXRecords(context.Background(), domain)
+}
+
+func (d *DNSChecker) hasMXRecords(ctx context.Context, domain string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	
+	mxRecords, err := d.resolver.LookupMX(ctx, domain)
 	if err != nil {
-		return false, fmt.Errorf("DNS lookup failed: %v", err)
+		return false, wrapLookupError(err)
 	}
 	return len(mxRecords) > 0, nil
 }
 
 // HasARecords checks if the domain has A records (fallback for domains without MX)
 func (d *DNSChecker) HasARecords(domain string) (bool, error) {
-	ips, err := net.LookupIP(domain)
+	return d.hasARecords(context.Background(), domain)
+}
+
+func (d *DNSChecker) hasARecords(ctx context.Context, domain string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	
+	ips, err := d.resolver.LookupIPAddr(ctx, domain)
 	if err != nil {
-		return false, fmt.Errorf("DNS lookup failed: %v", err)
+		return false, wrapLookupError(err)
 	}
 	return len(ips) > 0, nil
 }
 
 // IsDomainValid checks if the domain exists and can receive emails
 func (d *DNSChecker) IsDomainValid(domain string) (bool, error) {
+	return d.IsDomainValidContext(context.Background(), domain)
+}
+
+// IsDomainValidContext is like IsDomainValid but gives up when ctx is done
+func (d *DNSChecker) IsDomainValidContext(ctx context.Context, domain string) (bool, error) {
+	valid, _, err := d.check(ctx, domain)
+	return valid, err
+}
+
+// check is IsDomainValidContext, also reporting whether the outcome came
+// from the memo
+func (d *DNSChecker) check(ctx context.Context, domain string) (valid, cached bool, err error) {
+	if d.memo != nil {
+		return d.memo.do(ctx, strings.ToLower(domain), func() (bool, error) {
+			valid, _, err := d.lookupStored(ctx, domain)
+			return valid, err
+		})
+	}
+	return d.lookupStored(ctx, domain)
+}
+
+// lookupStored is lookupDomain behind the store, when there is one
+func (d *DNSChecker) lookupStored(ctx context.Context, domain string) (valid, cached bool, err error) {
+	if d.store == nil {
+		valid, err = d.lookupDomain(ctx, domain)
+		return valid, false, err
+	}
+
+	key := strings.ToLower(domain)
+	if valid, ok := d.store.LookupDomain(key); ok {
+		return valid, true, nil
+	}
+	valid, err = d.lookupDomain(ctx, domain)
+	if (err == nil || errors.Is(err, ErrDomainNotFound)) && ctx.Err() == nil {
+		d.store.StoreDomain(key, valid)
+	}
+	return valid, false, err
+}
+
+// lookupDomain checks MX records, falling back to A/AAAA records
+func (d *DNSChecker) lookupDomain(ctx context.Context, domain string) (bool, error) {
 	// First check for MX records
-	hasMX, err := d.HasMXRecords(domain)
-	if err != nil {
+	hasMX, err := d.hasMXRecords(ctx, domain)
+	if err != nil && !errors.Is(err, ErrDomainNotFound) {
 		return false, err
 	}
 
 	// If no MX records, check for A records
 	if !hasMX {
-		hasA, err := d.HasARecords(domain)
+		hasA, err := d.hasARecords(ctx, domain)
 		if err != nil {
 			return false, err
 		}
@@ -67,9 +178,138 @@ func (d *DNSChecker) IsDomainValid(domain string) (bool, error) {
 func (d *DNSChecker) ValidateEmailDomain(email string) (bool, error) {
 	parts := strings.Split(email, "@")
 	if len(parts) != 2 {
-		return false, fmt.Errorf("invalid email format")
+		return false, ErrInvalidFormat
 	}
 
 	domain := parts[1]
 	return d.IsDomainValid(domain)
-}
\ No newline at end of file
+}
+
+// wrapLookupError wraps a resolver error with ErrDomainNotFound for
+// NXDOMAIN answers and ErrDNSLookup otherwise
+func wrapLookupError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return fmt.Errorf("%w: %v", ErrDomainNotFound, err)
+	}
+	return fmt.Errorf("%w: %v", ErrDNSLookup, err)
+}
+
+// DomainMemo deduplicates concurrent and repeated DNS lookups per domain.
+// It is safe for concurrent use.
+// Its conclusive outcomes can be exported with Snapshot and loaded into a
+// new memo with Restore, e.g. to resume an interrupted batch.
+type DomainMemo struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry
+	// slots bounds the number of lookups in flight when set
+	slots chan struct{}
+
+	hits, misses uint64
+}
+
+type memoEntry struct {
+	done  chan struct{}
+	valid bool
+	err   error
+}
+
+// NewDomainMemo creates an empty DomainMemo
+func NewDomainMemo() *DomainMemo {
+	return &DomainMemo{entries: make(map[string]*memoEntry)}
+}
+
+// Limit caps the number of lookups running at once to n and returns m.
+// Callers waiting on a domain already being looked up do not use a slot.
+// It must be called before the memo is used.
+func (m *DomainMemo) Limit(n int) *DomainMemo {
+	if n > 0 {
+		m.slots = make(chan struct{}, n)
+	}
+	return m
+}
+
+// Snapshot returns whether each looked-up domain can receive mail. Lookups
+// that failed for transient reasons are left out so they are retried.
+func (m *DomainMemo) Snapshot() map[string]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]bool, len(m.entries))
+	for domain, entry := range m.entries {
+		select {
+		case <-entry.done:
+		default:
+			continue
+		}
+		if entry.err == nil || errors.Is(entry.err, ErrDomainNotFound) {
+			snapshot[domain] = entry.valid
+		}
+	}
+	return snapshot
+}
+
+// CacheStats implements CacheMetrics. Callers that waited for a lookup
+// already in flight count as hits.
+func (m *DomainMemo) CacheStats() CacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return CacheStats{Hits: m.hits, Misses: m.misses, Entries: len(m.entries)}
+}
+
+// Restore records previously exported outcomes
+func (m *DomainMemo) Restore(domains map[string]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for domain, valid := range domains {
+		entry := &memoEntry{done: make(chan struct{}), valid: valid}
+		close(entry.done)
+		m.entries[strings.ToLower(domain)] = entry
+	}
+}
+
+// do returns the remembered outcome for domain, running lookup only for the
+// first caller, and whether it was remembered. An outcome reached after ctx
+// was done is passed to the waiting callers but not remembered.
+func (m *DomainMemo) do(ctx context.Context, domain string, lookup func() (bool, error)) (valid, cached bool, err error) {
+	m.mu.Lock()
+	entry, ok := m.entries[domain]
+	if ok {
+		m.hits++
+	} else {
+		m.misses++
+		entry = &memoEntry{done: make(chan struct{})}
+		m.entries[domain] = entry
+	}
+	m.mu.Unlock()
+
+	if ok {
+		// Waiters give up with their own context, not the lookup's
+		select {
+		case <-entry.done:
+			return entry.valid, true, entry.err
+		case <-ctx.Done():
+			return false, false, ctx.Err()
+		}
+	}
+
+	if m.slots != nil {
+		select {
+		case m.slots <- struct{}{}:
+			defer func() { <-m.slots }()
+		case <-ctx.Done():
+			entry.err = ctx.Err()
+		}
+	}
+	if entry.err == nil {
+		entry.valid, entry.err = lookup()
+	}
+	if ctx.Err() != nil {
+		m.mu.Lock()
+		delete(m.entries, domain)
+		m.mu.Unlock()
+	}
+	close(entry.done)
+	return entry.valid, false, entry.err
+}
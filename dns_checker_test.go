@@ -0,0 +1,113 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapStore is an in-memory DomainStore
+type mapStore struct {
+	mu      sync.Mutex
+	domains map[string]bool
+}
+
+func (s *mapStore) LookupDomain(domain string) (valid, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	valid, ok = s.domains[domain]
+	return valid, ok
+}
+
+func (s *mapStore) StoreDomain(domain string, valid bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.domains[domain] = valid
+}
+
+// flakyResolver fails every lookup with a timeout
+type flakyResolver struct{}
+
+func (flakyResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+}
+
+func (flakyResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true}
+}
+
+func TestDNSCheckerStore(t *testing.T) {
+	store := &mapStore{domains: map[string]bool{"stored.example": false}}
+	checker := NewDNSChecker().WithResolver(staticResolver{}).WithStore(store)
+
+	// Stored outcomes are used without a lookup
+	if valid, cached, err := checker.check(context.Background(), "Stored.Example"); valid || !cached || err != nil {
+		t.Fatalf("stored domain: %v %v %v", valid, cached, err)
+	}
+	if valid, cached, err := checker.check(context.Background(), "Example.com"); !valid || cached || err != nil {
+		t.Fatalf("looked-up domain: %v %v %v", valid, cached, err)
+	}
+	if valid, ok := store.LookupDomain("example.com"); !valid || !ok {
+		t.Fatal("outcome not stored")
+	}
+
+	// Transient failures are not stored
+	flaky := checker.WithResolver(flakyResolver{})
+	if _, err := flaky.IsDomainValid("down.example"); !errors.Is(err, ErrDNSLookup) {
+		t.Fatalf("got %v", err)
+	}
+	if _, ok := store.LookupDomain("down.example"); ok {
+		t.Fatal("transient failure stored")
+	}
+}
+
+func TestDomainMemoSlotHonoursContext(t *testing.T) {
+	memo := NewDomainMemo().Limit(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go memo.do(context.Background(), "slow.example", func() (bool, error) {
+		close(started)
+		<-release
+		return true, nil
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := memo.do(ctx, "other.example", func() (bool, error) {
+		t.Error("lookup ran without a slot")
+		return true, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	if _, ok := memo.Snapshot()["other.example"]; ok {
+		t.Error("cancelled lookup remembered")
+	}
+}
+
+func TestDomainMemoWaiterHonoursContext(t *testing.T) {
+	memo := NewDomainMemo()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go memo.do(context.Background(), "slow.example", func() (bool, error) {
+		close(started)
+		<-release
+		return true, nil
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := memo.do(ctx, "slow.example", func() (bool, error) {
+		t.Error("lookup ran twice")
+		return true, nil
+	}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,22 @@
+// Package emailvalidator validates email addresses: their syntax, whether
+// their domain receives mail, and signals of risk such as disposable
+// providers, role accounts and suspicious patterns.
+//
+//	v := emailvalidator.New(emailvalidator.WithDNSCheck(emailvalidator.NewDNSChecker()))
+//	result := v.Validate("jane.doe@example.com")
+//	if !result.IsValid {
+//		fmt.Println(result.Reason())
+//	}
+//
+// # Compatibility
+//
+// From v1.0.0 the module follows semantic versioning. Within major version
+// 1, an exported identifier is not removed or renamed and its signature does
+// not change; identifiers that are superseded are marked Deprecated and kept.
+// The result JSON follows ResultSchemaVersion: minor versions only add
+// fields and enum values. Error and warning codes are stable once released.
+//
+// Not covered are the exact text of messages, the scores of ScoreWeights
+// defaults, the contents of built-in lists such as disposable and TLD
+// data, which are updated in minor releases, and the internal packages.
+package emailvalidator
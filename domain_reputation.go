@@ -0,0 +1,404 @@
+package emailvalidator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDNSBLs are the domain blocklists queried by a
+// DomainReputationChecker unless WithDNSBLs is given
+var DefaultDNSBLs = []string{"dbl.spamhaus.org", "multi.uribl.com"}
+
+// DefaultRDAPURL is the RDAP bootstrap service used to look up the
+// registration date of domains
+const DefaultRDAPURL = "https://rdap.org/"
+
+// ReputationResolver performs the DNS lookups of a DomainReputationChecker.
+// *net.Resolver implements it.
+type ReputationResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DomainAgeFunc returns the registration time of domain
+type DomainAgeFunc func(ctx context.Context, domain string) (time.Time, error)
+
+// DomainReputation is the reputation of the domain of an address, from 0
+// (certainly abusive) to 100. Lookups that failed are left out of the
+// score and reported by Incomplete.
+type DomainReputation struct {
+	Score      int        `json:"score"`
+	Listings   []string   `json:"listings,omitempty"`
+	Registered *time.Time `json:"registered,omitempty"`
+	MX         bool       `json:"mx"`
+	SPF        bool       `json:"spf"`
+	DMARC      bool       `json:"dmarc"`
+	Wildcard   bool       `json:"wildcard"`
+	Incomplete bool       `json:"incomplete,omitempty"`
+
+	// failed holds the hygiene lookups that could not be completed
+	failed hygieneCheck
+}
+
+// hygieneCheck is a set of the MX, SPF, DMARC and wildcard lookups
+type hygieneCheck uint8
+
+const (
+	checkMX hygieneCheck = 1 << iota
+	checkSPF
+	checkDMARC
+	checkWildcard
+)
+
+// Penalties subtracted from a perfect domain reputation
+const (
+	penaltyListed      = 50
+	penaltyNewDomain   = 30 // registered less than 30 days ago
+	penaltyYoungDomain = 10 // registered less than a year ago
+	penaltyNoMX        = 10
+	penaltyNoSPF       = 10
+	penaltyNoDMARC     = 10
+	penaltyWildcard    = 15
+)
+
+// DomainReputationChecker rates domains by their DNSBL listings,
+// registration age, mail hygiene (MX, SPF and DMARC records) and wildcard
+// DNS. Ratings are cached per domain. A checker is safe for concurrent use.
+type DomainReputationChecker struct {
+	resolver ReputationResolver
+	dnsbls   []string
+	age      DomainAgeFunc
+	timeout  time.Duration
+	size     int
+	ttl      time.Duration
+
+	cache *ttlCache[string, DomainReputation]
+}
+
+// DomainReputationOption configures a DomainReputationChecker
+type DomainReputationOption func(*DomainReputationChecker)
+
+// WithReputationResolver performs DNS lookups with resolver instead of
+// net.DefaultResolver
+func WithReputationResolver(resolver ReputationResolver) DomainReputationOption {
+	return func(c *DomainReputationChecker) {
+		c.resolver = resolver
+	}
+}
+
+// WithDNSBLs queries the given domain blocklist zones instead of
+// DefaultDNSBLs; none disables blocklist lookups. The return codes of
+// Spamhaus DBL and URIBL zones are decoded as those lists document them;
+// other zones list a domain with any answer in 127.0.0.0/8.
+func WithDNSBLs(zones ...string) DomainReputationOption {
+	return func(c *DomainReputationChecker) {
+		c.dnsbls = zones
+	}
+}
+
+// WithDomainAge looks up registration dates with age instead of RDAP; nil
+// disables age lookups
+func WithDomainAge(age DomainAgeFunc) DomainReputationOption {
+	return func(c *DomainReputationChecker) {
+		c.age = age
+	}
+}
+
+// WithReputationTimeout sets the timeout of all the lookups for one domain.
+// The default is 5 seconds.
+func WithReputationTimeout(timeout time.Duration) DomainReputationOption {
+	return func(c *DomainReputationChecker) {
+		c.timeout = timeout
+	}
+}
+
+// WithReputationCache keeps up to size ratings for ttl each. The default
+// is 10000 ratings for 6 hours; a size of zero disables caching.
+func WithReputationCache(size int, ttl time.Duration) DomainReputationOption {
+	return func(c *DomainReputationChecker) {
+		c.size, c.ttl = size, ttl
+	}
+}
+
+// NewDomainReputationChecker creates a DomainReputationChecker
+func NewDomainReputationChecker(opts ...DomainReputationOption) *DomainReputationChecker {
+	c := &DomainReputationChecker{
+		resolver: net.DefaultResolver,
+		dnsbls:   DefaultDNSBLs,
+		age:      RDAPRegistration(http.DefaultClient, DefaultRDAPURL),
+		timeout:  5 * time.Second,
+		size:     10000,
+		ttl:      6 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.cache = newTTLCache[string, DomainReputation](c.size, c.ttl)
+	return c
+}
+
+// Reputation rates domain. Its lookups run concurrently.
+func (c *DomainReputationChecker) Reputation(ctx context.Context, domain string) DomainReputation {
+	domain = strings.ToLower(domain)
+	if rep, ok := c.cache.get(domain); ok {
+		return rep
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		rep DomainReputation
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+	)
+	// run calls lookup concurrently, which records its findings with mu held
+	// and reports whether it reached a conclusion; check names the hygiene
+	// lookup, if any, left out of the score otherwise
+	run := func(check hygieneCheck, lookup func() bool) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !lookup() {
+				mu.Lock()
+				rep.Incomplete = true
+				rep.failed |= check
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, zone := range c.dnsbls {
+		zone := zone
+		run(0, func() bool {
+			addrs, err := c.resolver.LookupHost(ctx, domain+"."+zone)
+			if err != nil {
+				return isNotFound(err)
+			}
+			listed, ok := dnsblListed(zone, addrs)
+			if !ok {
+				return false
+			}
+			if listed {
+				mu.Lock()
+				rep.Listings = append(rep.Listings, zone)
+				mu.Unlock()
+			}
+			return true
+		})
+	}
+	if c.age != nil {
+		run(0, func() bool {
+			registered, err := c.age(ctx, domain)
+			if err != nil {
+				return false
+			}
+			mu.Lock()
+			rep.Registered = &registered
+			mu.Unlock()
+			return true
+		})
+	}
+	run(checkMX, func() bool {
+		mx, err := c.resolver.LookupMX(ctx, domain)
+		mu.Lock()
+		rep.MX = err == nil && len(mx) > 0
+		mu.Unlock()
+		return err == nil || isNotFound(err)
+	})
+	run(checkSPF, func() bool {
+		found, err := c.hasTXT(ctx, domain, "v=spf1")
+		mu.Lock()
+		rep.SPF = found
+		mu.Unlock()
+		return err == nil
+	})
+	run(checkDMARC, func() bool {
+		found, err := c.hasTXT(ctx, "_dmarc."+domain, "v=DMARC1")
+		mu.Lock()
+		rep.DMARC = found
+		mu.Unlock()
+		return err == nil
+	})
+	run(checkWildcard, func() bool {
+		label := make([]byte, 8)
+		rand.Read(label)
+		addrs, err := c.resolver.LookupHost(ctx, hex.EncodeToString(label)+"."+domain)
+		mu.Lock()
+		rep.Wildcard = err == nil && len(addrs) > 0
+		mu.Unlock()
+		return err == nil || isNotFound(err)
+	})
+	wg.Wait()
+
+	sort.Strings(rep.Listings)
+	rep.Score = rep.score(time.Now())
+	if !rep.Incomplete {
+		c.cache.add(domain, rep)
+	}
+	return rep
+}
+
+// CacheStats implements CacheMetrics for the cache of ratings
+func (c *DomainReputationChecker) CacheStats() CacheStats {
+	return c.cache.stats()
+}
+
+// score derives the 0–100 score of the findings at now
+func (r DomainReputation) score(now time.Time) int {
+	score := 100
+	if len(r.Listings) > 0 {
+		score -= penaltyListed
+	}
+	if r.Registered != nil {
+		switch age := now.Sub(*r.Registered); {
+		case age < 30*24*time.Hour:
+			score -= penaltyNewDomain
+		case age < 365*24*time.Hour:
+			score -= penaltyYoungDomain
+		}
+	}
+	if !r.MX && r.failed&checkMX == 0 {
+		score -= penaltyNoMX
+	}
+	if !r.SPF && r.failed&checkSPF == 0 {
+		score -= penaltyNoSPF
+	}
+	if !r.DMARC && r.failed&checkDMARC == 0 {
+		score -= penaltyNoDMARC
+	}
+	if r.Wildcard {
+		score -= penaltyWildcard
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+// hasTXT reports whether name has a TXT record starting with prefix,
+// ignoring case
+func (c *DomainReputationChecker) hasTXT(ctx context.Context, name, prefix string) (bool, error) {
+	records, err := c.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, record := range records {
+		if len(record) >= len(prefix) && strings.EqualFold(record[:len(prefix)], prefix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// dnsblListed decodes the answer of zone for a domain, reporting whether
+// it is a listing, and ok false when the list answered with an error
+// code instead, such as for queries refused through public resolvers.
+// Error codes are 127.255.255.0/24 and answers outside 127.0.0.0/8 for
+// every list, as well as:
+//
+//	Spamhaus DBL  anything but 127.0.1.2 to 127.0.1.254, the listings
+//	URIBL         127.0.0.1; listings are a bitmask of lists in 127.0.0.2 and up
+func dnsblListed(zone string, addrs []string) (listed, ok bool) {
+	errored := false
+	for _, addr := range addrs {
+		ip, err := netip.ParseAddr(addr)
+		if err != nil || !ip.Is4() {
+			errored = true
+			continue
+		}
+		b := ip.As4()
+		switch {
+		case b[0] != 127 || b[1] == 255 && b[2] == 255:
+			errored = true
+		case isSpamhausDBL(zone):
+			if b[1] == 0 && b[2] == 1 && b[3] >= 2 && b[3] < 255 {
+				return true, true
+			}
+			errored = true
+		case isURIBL(zone):
+			if b[1] == 0 && b[2] == 0 && b[3]&^1 != 0 {
+				return true, true
+			}
+			errored = true
+		default:
+			return true, true
+		}
+	}
+	return false, !errored
+}
+
+// isSpamhausDBL reports whether zone is the Spamhaus domain blocklist,
+// including its Data Query Service mirrors
+func isSpamhausDBL(zone string) bool {
+	return zone == "dbl.spamhaus.org" || strings.HasSuffix(zone, ".dbl.dq.spamhaus.net")
+}
+
+// isURIBL reports whether zone is one of the URIBL lists
+func isURIBL(zone string) bool {
+	return strings.HasSuffix(zone, ".uribl.com")
+}
+
+// isNotFound reports whether err is an NXDOMAIN or empty answer
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// RDAPRegistration returns a DomainAgeFunc reading the registration event
+// of domains from the RDAP service at baseURL, such as DefaultRDAPURL
+func RDAPRegistration(client *http.Client, baseURL string) DomainAgeFunc {
+	return func(ctx context.Context, domain string) (time.Time, error) {
+		url := strings.TrimSuffix(baseURL, "/") + "/domain/" + domain
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return time.Time{}, err
+		}
+		req.Header.Set("Accept", "application/rdap+json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("rdap: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return time.Time{}, fmt.Errorf("rdap: unexpected status %s", resp.Status)
+		}
+
+		var body struct {
+			Events []struct {
+				Action string    `json:"eventAction"`
+				Date   time.Time `json:"eventDate"`
+			} `json:"events"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return time.Time{}, fmt.Errorf("rdap: %v", err)
+		}
+		for _, event := range body.Events {
+			if event.Action == "registration" {
+				return event.Date, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("rdap: no registration event for %s", domain)
+	}
+}
+
+// checkDomainReputation rates the domain of the address
+func (v *EmailValidator) checkDomainReputation(ctx context.Context, domain string, result *ValidationResult) {
+	rep := v.reputationChecker.Reputation(ctx, domain)
+	result.DomainReputation = &rep
+}
@@ -0,0 +1,166 @@
+package emailvalidator
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// reputationResolver answers from fixed records; other names do not exist
+type reputationResolver struct {
+	hosts   map[string][]string
+	txt     map[string][]string
+	mx      map[string]bool
+	lookups atomic.Int32
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *reputationResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.lookups.Add(1)
+	if r.mx[name] {
+		return []*net.MX{{Host: "mx." + name, Pref: 10}}, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *reputationResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	r.lookups.Add(1)
+	if txt, ok := r.txt[name]; ok {
+		return txt, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *reputationResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups.Add(1)
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	// wildcard.example answers for every subdomain
+	if strings.HasSuffix(host, ".wildcard.example") {
+		return []string{"192.0.2.1"}, nil
+	}
+	return nil, notFound(host)
+}
+
+func TestDomainReputation(t *testing.T) {
+	resolver := &reputationResolver{
+		hosts: map[string][]string{
+			"spam.example.dbl.spamhaus.org": {"127.0.1.2"},
+			"spam.example.multi.uribl.com":  nil,
+		},
+		txt: map[string][]string{
+			"good.example":        {"google-site-verification=x", "v=spf1 -all"},
+			"_dmarc.good.example": {"v=DMARC1; p=reject"},
+		},
+		mx: map[string]bool{"good.example": true, "spam.example": true, "wildcard.example": true},
+	}
+	registered := map[string]time.Time{
+		"good.example": time.Now().AddDate(-10, 0, 0),
+		"spam.example": time.Now().AddDate(0, 0, -3),
+	}
+	checker := NewDomainReputationChecker(
+		WithReputationResolver(resolver),
+		WithDNSBLs("dbl.spamhaus.org", "multi.uribl.com"),
+		WithDomainAge(func(ctx context.Context, domain string) (time.Time, error) {
+			return registered[domain], nil
+		}),
+	)
+
+	tests := []struct {
+		domain   string
+		score    int
+		listings []string
+	}{
+		{"Good.Example", 100, nil},
+		// listed, 3 days old, no SPF nor DMARC
+		{"spam.example", 100 - 50 - 30 - 10 - 10, []string{"dbl.spamhaus.org"}},
+		// no SPF nor DMARC, wildcard
+		{"wildcard.example", 100 - 10 - 10 - 15, nil},
+	}
+	for _, tt := range tests {
+		rep := checker.Reputation(context.Background(), tt.domain)
+		if rep.Score != tt.score || !reflect.DeepEqual(rep.Listings, tt.listings) || rep.Incomplete {
+			t.Errorf("%s: got %+v", tt.domain, rep)
+		}
+	}
+
+	// Ratings are cached, and attached to valid addresses only
+	before := resolver.lookups.Load()
+	v := New(WithDomainReputation(checker))
+	if r := v.Validate("jane@good.example"); r.DomainReputation == nil || r.DomainReputation.Score != 100 {
+		t.Fatalf("got %+v", r.DomainReputation)
+	}
+	if r := v.Validate("jane@@good.example"); r.DomainReputation != nil {
+		t.Fatal("invalid address rated")
+	}
+	if resolver.lookups.Load() != before {
+		t.Fatal("cached rating looked up again")
+	}
+
+	// Error codes leave the rating incomplete rather than listed
+	resolver.hosts["good.example.dbl.spamhaus.org"] = []string{"127.255.255.254"}
+	checker = NewDomainReputationChecker(WithReputationResolver(resolver), WithDNSBLs("dbl.spamhaus.org"), WithDomainAge(nil))
+	if rep := checker.Reputation(context.Background(), "good.example"); rep.Listings != nil || !rep.Incomplete {
+		t.Errorf("refused query: got %+v", rep)
+	}
+}
+
+func TestDNSBLListed(t *testing.T) {
+	tests := []struct {
+		zone   string
+		addrs  []string
+		listed bool
+		ok     bool
+	}{
+		{"dbl.spamhaus.org", []string{"127.0.1.2"}, true, true},
+		{"dbl.spamhaus.org", []string{"127.0.1.106"}, true, true},
+		{"key.dbl.dq.spamhaus.net", []string{"127.0.1.4"}, true, true},
+		{"dbl.spamhaus.org", []string{"127.0.0.2"}, false, false},
+		{"dbl.spamhaus.org", []string{"127.0.1.255"}, false, false},
+		{"dbl.spamhaus.org", []string{"127.255.255.254"}, false, false},
+		{"multi.uribl.com", []string{"127.0.0.2"}, true, true},
+		{"multi.uribl.com", []string{"127.0.0.14"}, true, true},
+		{"multi.uribl.com", []string{"127.0.0.1"}, false, false},
+		{"multi.uribl.com", []string{"127.0.1.2"}, false, false},
+		{"bl.example", []string{"127.0.0.2"}, true, true},
+		{"bl.example", []string{"127.255.255.252"}, false, false},
+		{"bl.example", []string{"192.0.2.1"}, false, false},
+		{"bl.example", nil, false, true},
+	}
+	for _, tt := range tests {
+		listed, ok := dnsblListed(tt.zone, tt.addrs)
+		if listed != tt.listed || ok != tt.ok {
+			t.Errorf("%s %v: got listed %v, ok %v", tt.zone, tt.addrs, listed, ok)
+		}
+	}
+}
+
+func TestRDAPRegistration(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domain/example.com" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"events":[{"eventAction":"last changed","eventDate":"2024-01-01T00:00:00Z"},{"eventAction":"registration","eventDate":"1995-08-14T04:00:00Z"}]}`))
+	}))
+	defer srv.Close()
+
+	age := RDAPRegistration(srv.Client(), srv.URL)
+	got, err := age(context.Background(), "example.com")
+	if err != nil || !got.Equal(time.Date(1995, 8, 14, 4, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	if _, err := age(context.Background(), "missing.example"); err == nil {
+		t.Fatal("expected an error for a missing domain")
+	}
+}
@@ -0,0 +1,304 @@
+package emailvalidator
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DomainSet is a set of domain names stored as a trie keyed by labels from
+// the right, so that "mail.example.com" and "shop.example.com" share the
+// "com" and "example" nodes. Names below a node that share no further label
+// with another name are kept as plain strings instead of a chain of nodes,
+// which keeps large lists compact. It answers exact and suffix queries
+// without allocating. Names are compared case-insensitively and a trailing
+// dot is ignored.
+//
+// Lookups are safe for concurrent use; Add must not run concurrently with
+// other methods. Add keeps the children and leaves of a node sorted, so
+// filling a large set one name at a time is slow; NewDomainSet builds the
+// set from a whole list at once.
+type DomainSet struct {
+	root domainNode
+	size int
+}
+
+// domainNode holds the names below one label. A name is either reached
+// through a child or stored in leaves, never both: no leaf ends with the
+// label of a child.
+type domainNode struct {
+	label    string
+	terminal bool
+	// children are sorted by label
+	children []*domainNode
+	// leaves hold the remaining part of names, sorted by compareReversed
+	leaves []string
+}
+
+// NewDomainSet creates a set holding domains. The names are sorted once
+// and the trie is built in a single pass over them, so that lists of
+// millions of domains load in O(n log n).
+func NewDomainSet(domains ...string) *DomainSet {
+	names := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if name := normalizeDomain(domain); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, compareReversed)
+	names = slices.Compact(names)
+
+	s := &DomainSet{size: len(names)}
+	s.root.build(names)
+	return s
+}
+
+// Len returns the number of domains in the set
+func (s *DomainSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return s.size
+}
+
+// Add inserts domain into the set
+func (s *DomainSet) Add(domain string) {
+	rest := normalizeDomain(domain)
+	if rest == "" {
+		return
+	}
+
+	n := &s.root
+	for {
+		label := lastLabel(rest)
+		if child := n.child(label); child != nil {
+			if len(rest) == len(label) {
+				if !child.terminal {
+					child.terminal = true
+					s.size++
+				}
+				return
+			}
+			n, rest = child, parentOf(rest, label)
+			continue
+		}
+
+		lo, hi := n.leafRange(label)
+		if lo == hi {
+			n.insertLeaf(strings.Clone(rest))
+			s.size++
+			return
+		}
+		for _, leaf := range n.leaves[lo:hi] {
+			if leaf == rest {
+				return
+			}
+		}
+
+		// Another name shares this label: move them below a new child
+		child := &domainNode{label: strings.Clone(label)}
+		for _, leaf := range n.leaves[lo:hi] {
+			if len(leaf) == len(label) {
+				child.terminal = true
+			} else {
+				child.leaves = append(child.leaves, parentOf(leaf, label))
+			}
+		}
+		n.leaves = append(n.leaves[:lo], n.leaves[hi:]...)
+		n.insertChild(child)
+	}
+}
+
+// Contains reports whether domain itself is in the set
+func (s *DomainSet) Contains(domain string) bool {
+	if s == nil {
+		return false
+	}
+	rest := normalizeDomain(domain)
+	if rest == "" {
+		return false
+	}
+
+	n := &s.root
+	for {
+		label := lastLabel(rest)
+		child := n.child(label)
+		if child == nil {
+			return n.hasLeaf(rest)
+		}
+		if len(rest) == len(label) {
+			return child.terminal
+		}
+		n, rest = child, parentOf(rest, label)
+	}
+}
+
+// Match reports whether domain or one of its parent domains is in the set,
+// e.g. "mail.example.com" matches a set holding "example.com"
+func (s *DomainSet) Match(domain string) bool {
+	if s == nil {
+		return false
+	}
+	rest := normalizeDomain(domain)
+	if rest == "" {
+		return false
+	}
+
+	n := &s.root
+	for {
+		label := lastLabel(rest)
+		child := n.child(label)
+		if child == nil {
+			break
+		}
+		if child.terminal {
+			return true
+		}
+		if len(rest) == len(label) {
+			return false
+		}
+		n, rest = child, parentOf(rest, label)
+	}
+
+	// Try every suffix of the remaining name against the leaves
+	for i := len(rest) - 1; i >= 0; i-- {
+		if rest[i] == '.' && n.hasLeaf(rest[i+1:]) {
+			return true
+		}
+	}
+	return n.hasLeaf(rest)
+}
+
+// Clone returns a deep copy of the set
+func (s *DomainSet) Clone() *DomainSet {
+	if s == nil {
+		return nil
+	}
+	return &DomainSet{root: *s.root.clone(), size: s.size}
+}
+
+func (n *domainNode) clone() *domainNode {
+	c := &domainNode{label: n.label, terminal: n.terminal}
+	if n.leaves != nil {
+		c.leaves = append([]string(nil), n.leaves...)
+	}
+	if n.children != nil {
+		c.children = make([]*domainNode, len(n.children))
+		for i, child := range n.children {
+			c.children[i] = child.clone()
+		}
+	}
+	return c
+}
+
+// child returns the child for label, or nil
+func (n *domainNode) child(label string) *domainNode {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label >= label
+	})
+	if i < len(n.children) && n.children[i].label == label {
+		return n.children[i]
+	}
+	return nil
+}
+
+// build fills the empty node n with names, relative to n, sorted by
+// compareReversed and without duplicates. Like Add, it stores a name as a
+// leaf unless another name shares its last label. It reuses names.
+func (n *domainNode) build(names []string) {
+	for len(names) > 0 {
+		label := lastLabel(names[0])
+		end := 1
+		for end < len(names) && lastLabel(names[end]) == label {
+			end++
+		}
+		group := names[:end]
+		names = names[end:]
+		if len(group) == 1 {
+			n.leaves = append(n.leaves, strings.Clone(group[0]))
+			continue
+		}
+
+		child := &domainNode{label: strings.Clone(label)}
+		// The name that is the label itself sorts first
+		if len(group[0]) == len(label) {
+			child.terminal = true
+			group = group[1:]
+		}
+		for i, name := range group {
+			group[i] = parentOf(name, label)
+		}
+		child.build(group)
+		n.children = append(n.children, child)
+	}
+}
+
+func (n *domainNode) insertChild(child *domainNode) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label >= child.label
+	})
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+// leafRange returns the range of leaves whose last label is label
+func (n *domainNode) leafRange(label string) (int, int) {
+	lo := sort.Search(len(n.leaves), func(i int) bool {
+		return lastLabel(n.leaves[i]) >= label
+	})
+	hi := sort.Search(len(n.leaves), func(i int) bool {
+		return lastLabel(n.leaves[i]) > label
+	})
+	return lo, hi
+}
+
+func (n *domainNode) hasLeaf(name string) bool {
+	i := sort.Search(len(n.leaves), func(i int) bool {
+		return compareReversed(n.leaves[i], name) >= 0
+	})
+	return i < len(n.leaves) && n.leaves[i] == name
+}
+
+func (n *domainNode) insertLeaf(name string) {
+	i := sort.Search(len(n.leaves), func(i int) bool {
+		return compareReversed(n.leaves[i], name) >= 0
+	})
+	n.leaves = append(n.leaves, "")
+	copy(n.leaves[i+1:], n.leaves[i:])
+	n.leaves[i] = name
+}
+
+// compareReversed orders names label by label from the right
+func compareReversed(a, b string) int {
+	for {
+		la, lb := lastLabel(a), lastLabel(b)
+		if c := strings.Compare(la, lb); c != 0 {
+			return c
+		}
+		switch {
+		case len(a) == len(la) && len(b) == len(lb):
+			return 0
+		case len(a) == len(la):
+			return -1
+		case len(b) == len(lb):
+			return 1
+		}
+		a, b = parentOf(a, la), parentOf(b, lb)
+	}
+}
+
+// normalizeDomain lower-cases domain and strips a trailing dot
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
+
+// lastLabel returns the rightmost label of name
+func lastLabel(name string) string {
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+// parentOf strips label, the last label of name, and its dot
+func parentOf(name, label string) string {
+	return name[:len(name)-len(label)-1]
+}
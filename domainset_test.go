@@ -0,0 +1,161 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestDomainSet(t *testing.T) {
+	set := NewDomainSet("example.com", "Mail.Example.com", "spam.co.uk", "co.jp.", "example.com")
+	if set.Len() != 4 {
+		t.Fatalf("expected 4 domains, got %d", set.Len())
+	}
+
+	exact := map[string]bool{
+		"example.com":        true,
+		"EXAMPLE.COM.":       true,
+		"mail.example.com":   true,
+		"spam.co.uk":         true,
+		"co.jp":              true,
+		"com":                false,
+		"co.uk":              false,
+		"a.mail.example.com": false,
+		"other.com":          false,
+		"":                   false,
+		"a..example.com":     false,
+	}
+	for domain, want := range exact {
+		if got := set.Contains(domain); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", domain, got, want)
+		}
+	}
+
+	suffix := map[string]bool{
+		"example.com":     true,
+		"a.b.example.com": true,
+		"shop.co.jp":      true,
+		"spam.co.uk":      true,
+		"eggs.co.uk":      false,
+		"xexample.com":    false,
+		"com":             false,
+	}
+	for domain, want := range suffix {
+		if got := set.Match(domain); got != want {
+			t.Errorf("Match(%q) = %v, want %v", domain, got, want)
+		}
+	}
+}
+
+func TestDomainSetCloneIsIndependent(t *testing.T) {
+	set := NewDomainSet("example.com")
+	clone := set.Clone()
+	clone.Add("mail.example.com")
+
+	if set.Contains("mail.example.com") || !clone.Contains("mail.example.com") {
+		t.Fatal("clone shares nodes with the original set")
+	}
+}
+
+func TestNewDomainSetMatchesAdd(t *testing.T) {
+	domains := largeDomainList(5000)
+	domains = append(domains, "com", "Example.COM.", "example.com", "a.b.example.com", "b.example.com", "co.uk", "", ".")
+	rand.New(rand.NewSource(1)).Shuffle(len(domains), func(i, j int) {
+		domains[i], domains[j] = domains[j], domains[i]
+	})
+
+	built := NewDomainSet(domains...)
+	added := &DomainSet{}
+	for _, domain := range domains {
+		added.Add(domain)
+	}
+	if built.Len() != added.Len() {
+		t.Fatalf("built %d domains, added %d", built.Len(), added.Len())
+	}
+	if !sameTrie(&built.root, &added.root) {
+		t.Fatal("built trie differs from the one filled by Add")
+	}
+}
+
+// sameTrie reports whether a and b hold the same labels, names and leaves
+func sameTrie(a, b *domainNode) bool {
+	if a.label != b.label || a.terminal != b.terminal || !slices.Equal(a.leaves, b.leaves) || len(a.children) != len(b.children) {
+		return false
+	}
+	for i := range a.children {
+		if !sameTrie(a.children[i], b.children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// largeDomainList mimics a disposable list: many names under few TLDs
+func largeDomainList(n int) []string {
+	tlds := []string{"com", "net", "org", "io", "co.uk", "xyz"}
+	domains := make([]string, n)
+	for i := range domains {
+		domains[i] = fmt.Sprintf("mail%d.provider%d.%s", i%7, i, tlds[i%len(tlds)])
+	}
+	return domains
+}
+
+// heapGrowth returns the bytes retained by build's result
+func heapGrowth(build func() interface{}) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	keep := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(keep)
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+func BenchmarkNewDomainSet(b *testing.B) {
+	for _, n := range []int{100000, 1000000} {
+		domains := largeDomainList(n)
+		rand.New(rand.NewSource(1)).Shuffle(len(domains), func(i, j int) {
+			domains[i], domains[j] = domains[j], domains[i]
+		})
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewDomainSet(domains...)
+			}
+		})
+	}
+}
+
+func BenchmarkDomainSetMemory(b *testing.B) {
+	domains := largeDomainList(100000)
+	for i := 0; i < b.N; i++ {
+		size := heapGrowth(func() interface{} { return NewDomainSet(domains...) })
+		b.ReportMetric(float64(size)/float64(len(domains)), "bytes/domain")
+	}
+}
+
+func BenchmarkDomainMapMemory(b *testing.B) {
+	domains := largeDomainList(100000)
+	for i := 0; i < b.N; i++ {
+		size := heapGrowth(func() interface{} {
+			m := make(map[string]bool)
+			for _, domain := range domains {
+				// Copy as a list loaded from a file would own its strings
+				m[string([]byte(domain))] = true
+			}
+			return m
+		})
+		b.ReportMetric(float64(size)/float64(len(domains)), "bytes/domain")
+	}
+}
+
+func BenchmarkDomainSetContains(b *testing.B) {
+	set := NewDomainSet(largeDomainList(100000)...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.Contains("mail3.provider50000.com")
+	}
+}
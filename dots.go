@@ -0,0 +1,71 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DotPolicy is how local parts with a leading, trailing or repeated dot
+// are treated outside strict mode. RFC 5322 forbids them unquoted, but
+// some providers, Gmail among them, deliver to such addresses anyway.
+// Strict mode always rejects them.
+type DotPolicy string
+
+const (
+	// DotsReject rejects the addresses. It is the default.
+	DotsReject DotPolicy = "reject"
+	// DotsWarn accepts the addresses with a WarnLocalPartDots warning
+	DotsWarn DotPolicy = "warn"
+	// DotsNormalize accepts the addresses with an informational
+	// WarnLocalPartDots warning, and drops the stray dots from
+	// ValidationResult.Normalized
+	DotsNormalize DotPolicy = "normalize"
+)
+
+// ParseDotPolicy parses "reject", "warn" and "normalize"
+func ParseDotPolicy(name string) (DotPolicy, error) {
+	switch p := DotPolicy(name); p {
+	case DotsReject, DotsWarn, DotsNormalize:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid dot policy %q", name)
+	}
+}
+
+// relaxedDots reports whether misplaced dots are accepted
+func (v *EmailValidator) relaxedDots() bool {
+	return !v.strictMode && (v.dotPolicy == DotsWarn || v.dotPolicy == DotsNormalize)
+}
+
+// hasStrayDots reports whether local has a leading, trailing or repeated
+// dot
+func hasStrayDots(local string) bool {
+	return strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") || strings.Contains(local, "..")
+}
+
+// checkDots warns about the stray dots of an address accepted by the dot
+// policy
+func (v *EmailValidator) checkDots(s *stage, p ParsedEmail) {
+	if !v.relaxedDots() || !hasStrayDots(p.Local) {
+		return
+	}
+	severity := SeverityWarning
+	if v.dotPolicy == DotsNormalize {
+		severity = SeverityInfo
+	}
+	s.addWarning(newWarning(WarnLocalPartDots, severity, "Local part has a leading, trailing or repeated dot").withParam("local_part", redactPart(p.Local)))
+}
+
+// dropStrayDots removes the leading and trailing dots of the local part
+// of email and collapses its runs of dots
+func dropStrayDots(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 || !hasStrayDots(email[:at]) {
+		return email
+	}
+	local := strings.Trim(email[:at], ".")
+	for strings.Contains(local, "..") {
+		local = strings.ReplaceAll(local, "..", ".")
+	}
+	return local + email[at:]
+}
@@ -0,0 +1,60 @@
+package emailvalidator
+
+import "testing"
+
+func TestDotPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		v          *EmailValidator
+		email      string
+		valid      bool
+		severity   Severity
+		normalized string
+	}{
+		{"default", New(), "jane..doe@example.com", false, 0, "jane..doe@example.com"},
+		{"warn", New(WithDotPolicy(DotsWarn)), "Jane..Doe@example.com", true, SeverityWarning, "jane..doe@example.com"},
+		{"warn leading", New(WithDotPolicy(DotsWarn)), ".jane@example.com", true, SeverityWarning, ".jane@example.com"},
+		{"normalize", New(WithDotPolicy(DotsNormalize)), ".jane...doe.@example.com", true, SeverityInfo, "jane.doe@example.com"},
+		{"normalize clean", New(WithDotPolicy(DotsNormalize)), "jane.doe@example.com", true, -1, "jane.doe@example.com"},
+		{"dots only", New(WithDotPolicy(DotsNormalize)), "...@example.com", false, 0, "...@example.com"},
+		{"strict", NewStrict(WithDotPolicy(DotsWarn)), "jane..doe@example.com", false, 0, "jane..doe@example.com"},
+	}
+	for _, tt := range tests {
+		r := tt.v.Validate(tt.email)
+		if r.IsValid != tt.valid || r.Normalized != tt.normalized {
+			t.Errorf("%s: valid %v, normalized %q, errors %v", tt.name, r.IsValid, r.Normalized, r.Errors)
+		}
+		if got := tt.v.IsValidSyntax(tt.email); got != tt.valid {
+			t.Errorf("%s: IsValidSyntax = %v", tt.name, got)
+		}
+		var warning *Warning
+		for i := range r.Warnings {
+			if r.Warnings[i].Code == WarnLocalPartDots {
+				warning = &r.Warnings[i]
+			}
+		}
+		switch {
+		case tt.valid && tt.severity >= 0 && (warning == nil || warning.Severity != tt.severity):
+			t.Errorf("%s: dots warning %+v", tt.name, warning)
+		case (!tt.valid || tt.severity < 0) && warning != nil:
+			t.Errorf("%s: unexpected dots warning %+v", tt.name, warning)
+		}
+	}
+}
+
+func TestDotPolicyConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DotPolicy = "Normalize"
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := v.Validate("jane..doe@example.com"); !r.IsValid || r.Normalized != "jane.doe@example.com" {
+		t.Errorf("configured policy not applied: %+v", r)
+	}
+
+	cfg.DotPolicy = "allow"
+	if _, err := cfg.Options(); err == nil {
+		t.Error("expected an error for an unknown dot policy")
+	}
+}
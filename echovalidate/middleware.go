@@ -0,0 +1,80 @@
+// Package echovalidate provides Echo middleware equivalent to httpvalidate.
+// The type parameters let it return an echo.MiddlewareFunc without this
+// module depending on Echo:
+//
+//	e.Use(echovalidate.Middleware[echo.Context, echo.HandlerFunc]("email"))
+//
+// Rejected requests get the same responses as from httpvalidate. Set
+// Config.ErrorHandler to hand them to Echo's HTTPErrorHandler instead:
+//
+//	ErrorHandler: func(c echo.Context, err *httpvalidate.Error) error {
+//		return echo.NewHTTPError(err.Status, err.Body).SetInternal(err)
+//	},
+package echovalidate
+
+import (
+	"net/http"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/httpvalidate"
+)
+
+// Context is the part of echo.Context used by the middleware
+type Context interface {
+	Request() *http.Request
+	SetRequest(r *http.Request)
+	JSON(code int, i interface{}) error
+}
+
+// Config configures MiddlewareWithConfig
+type Config[C Context] struct {
+	// Skipper skips the middleware for requests it returns true for
+	Skipper func(c C) bool
+	// Fields names the fields to validate, as for httpvalidate.MiddlewareFor
+	Fields []string
+	// Validator defaults to emailvalidator.New()
+	Validator emailvalidator.Validator
+	// ErrorHandler handles rejected requests. The default responds with
+	// err.Body as JSON.
+	ErrorHandler func(c C, err *httpvalidate.Error) error
+}
+
+// Middleware validates the named fields of each request with a default
+// validator
+func Middleware[C Context, H ~func(C) error](fields ...string) func(H) H {
+	return MiddlewareWithConfig[C, H](Config[C]{Fields: fields})
+}
+
+// MiddlewareWithConfig returns middleware validating requests as described
+// by config. Handlers of accepted requests can read the results with
+// Results.
+func MiddlewareWithConfig[C Context, H ~func(C) error](config Config[C]) func(H) H {
+	if config.Validator == nil {
+		config.Validator = emailvalidator.New()
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = func(c C, err *httpvalidate.Error) error {
+			return c.JSON(err.Status, err.Body)
+		}
+	}
+
+	return func(next H) H {
+		return func(c C) error {
+			if config.Skipper != nil && config.Skipper(c) {
+				return next(c)
+			}
+			checked, err := httpvalidate.Check(config.Validator, nil, c.Request(), config.Fields...)
+			if err != nil {
+				return config.ErrorHandler(c, err)
+			}
+			c.SetRequest(checked)
+			return next(c)
+		}
+	}
+}
+
+// Results returns the validation results of the fields checked for the
+// request of c, keyed by field name
+func Results(c Context) map[string]emailvalidator.ValidationResult {
+	return httpvalidate.Results(c.Request())
+}
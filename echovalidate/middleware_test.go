@@ -0,0 +1,73 @@
+package echovalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LBFmuraiybatu/email_validator/httpvalidate"
+)
+
+// context stands in for echo.Context
+type context struct {
+	req *http.Request
+	rec *httptest.ResponseRecorder
+}
+
+func (c *context) Request() *http.Request     { return c.req }
+func (c *context) SetRequest(r *http.Request) { c.req = r }
+func (c *context) JSON(code int, i interface{}) error {
+	c.rec.WriteHeader(code)
+	return json.NewEncoder(c.rec).Encode(i)
+}
+
+// handlerFunc mirrors echo.HandlerFunc
+type handlerFunc func(c *context) error
+
+func newContext(body string) *context {
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return &context{req: req, rec: httptest.NewRecorder()}
+}
+
+func TestMiddleware(t *testing.T) {
+	var normalized string
+	handler := Middleware[*context, handlerFunc]("email")(func(c *context) error {
+		normalized = Results(c)["email"].Normalized
+		return nil
+	})
+
+	if err := handler(newContext(`{"email":"Jane@Example.com"}`)); err != nil || normalized != "jane@example.com" {
+		t.Fatalf("expected the request to pass with results, got %v %q", err, normalized)
+	}
+
+	c := newContext(`{"email":"jane@@example.com"}`)
+	if err := handler(c); err != nil || c.rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a 422 response, got %v %d", err, c.rec.Code)
+	}
+}
+
+func TestMiddlewareErrorHandler(t *testing.T) {
+	handler := MiddlewareWithConfig[*context, handlerFunc](Config[*context]{
+		Fields:       []string{"email"},
+		Skipper:      func(c *context) bool { return c.req.URL.Query().Get("skip") != "" },
+		ErrorHandler: func(c *context, err *httpvalidate.Error) error { return err },
+	})(func(c *context) error { return nil })
+
+	var herr *httpvalidate.Error
+	if err := handler(newContext(`{`)); !errors.As(err, &herr) || herr.Status != http.StatusBadRequest {
+		t.Fatalf("expected the error to be returned to Echo, got %v", err)
+	}
+	if err := handler(newContext(`{"email":"bad"}`)); err == nil || err.Error() != "invalid email in email" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	c := newContext(`{"email":"bad"}`)
+	c.req.URL.RawQuery = "skip=1"
+	if err := handler(c); err != nil {
+		t.Fatalf("expected the skipper to bypass validation, got %v", err)
+	}
+}
@@ -1,85 +1,549 @@
 package emailvalidator
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net"
 	"regexp"
 	"strings"
+	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
-// EmailValidator provides methods to validate email addresses
+// Default length limits from RFC 5321
+const (
+	DefaultMaxLength          = 254
+	DefaultMaxLocalPartLength = 64
+	DefaultMaxDomainLength    = 253
+)
+
+// EmailValidator provides methods to validate email addresses.
+// A validator is immutable once constructed and safe for concurrent use;
+// use Clone or With to derive a differently configured variant.
 type EmailValidator struct {
 	strictMode bool
+
+	maxLength       int
+	maxLocalLength  int
+	maxDomainLength int
+	minTLDLength    int
+
+	allowTLDs        []string
+	tldPolicies      map[TLDCategory]Policy
+	blockedDomains   *DomainSet
+	allowIPAddresses bool
+	smtputf8         bool
+	allowUnderscores bool
+
+	disposableCheck    bool
+	disposableSeverity Severity
+
+	relayPolicy Policy
+	emojiPolicy Policy
+
+	typoSuggestions   bool
+	suggestionDomains *domainTargets
+	normalization     Normalization
+	dotPolicy         DotPolicy
+
+	localPartAnalysis bool
+	nameExtraction    bool
+
+	personalNameDetector *PersonalNameDetector
+
+	scoreWeights ScoreWeights
+
+	dnsChecker      *DNSChecker
+	parkingDetector *ParkingDetector
+
+	gravatarChecker *GravatarChecker
+	breachChecker   *BreachChecker
+
+	reputationChecker    *DomainReputationChecker
+	organizationEnricher OrganizationEnricher
+	hostingLocator       *MailHostingLocator
+
+	acceptAllDomains *DomainSet
+
+	disposableHeuristics *DisposableHeuristics
+
+	tldRisk map[string]float64
+
+	streamWorkers int
+
+	metrics Metrics
+	tracer  Tracer
+	auditor Auditor
 }
 
 // New creates a new EmailValidator instance
-func New() *EmailValidator {
-	return &EmailValidator{
-		strictMode: false,
-	}
+func New(opts ...Option) *EmailValidator {
+	return newValidator(false, opts)
 }
 
 // NewStrict creates a new EmailValidator with strict validation
-func NewStrict() *EmailValidator {
-	return &EmailValidator{
-		strictMode: true,
+func NewStrict(opts ...Option) *EmailValidator {
+	return newValidator(true, opts)
+}
+
+// newValidator builds a validator with default limits and applies options
+func newValidator(strict bool, opts []Option) *EmailValidator {
+	v := &EmailValidator{
+		strictMode:        strict,
+		maxLength:         DefaultMaxLength,
+		maxLocalLength:    DefaultMaxLocalPartLength,
+		maxDomainLength:   DefaultMaxDomainLength,
+		minTLDLength:      DefaultMinTLDLength,
+		typoSuggestions:   true,
+		suggestionDomains: defaultSuggestionTargets,
+		dotPolicy:         DotsReject,
+		scoreWeights:      DefaultScoreWeights(),
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // ValidationResult contains detailed validation results
 type ValidationResult struct {
 	IsValid      bool     `json:"is_valid"`
-	Errors       []string `json:"errors,omitempty"`
-	Warnings     []string `json:"warnings,omitempty"`
+	Errors       []ValidationError `json:"errors,omitempty"`
+	Warnings     []Warning `json:"warnings,omitempty"`
 	Normalized   string   `json:"normalized,omitempty"`
 	Domain       string   `json:"domain,omitempty"`
 	Username     string   `json:"username,omitempty"`
+	Suggestion   string   `json:"suggestion,omitempty"`
+	Score        float64  `json:"score"`
+	Verdict      Verdict  `json:"verdict"`
+	// HasGravatar reports whether the address has a Gravatar avatar. It is
+	// set for valid addresses when the Gravatar check is enabled and the
+	// lookup succeeded.
+	HasGravatar *bool `json:"has_gravatar,omitempty"`
+	// Breached reports whether the address appears in known data breaches,
+	// named in Breaches. It is set for valid addresses when the breach
+	// check is enabled and the lookup succeeded.
+	Breached *bool    `json:"breached,omitempty"`
+	Breaches []string `json:"breaches,omitempty"`
+	// DomainReputation rates the domain of valid addresses when the domain
+	// reputation check is enabled
+	DomainReputation *DomainReputation `json:"domain_reputation,omitempty"`
+	// Organization describes who runs the domain of valid addresses when
+	// organization enrichment is enabled
+	Organization *Organization `json:"organization,omitempty"`
+	// MailHosting locates the mail hosts of the domain of valid addresses
+	// when mail hosting lookups are enabled
+	MailHosting *MailHosting `json:"mail_hosting,omitempty"`
+	// AcceptAll reports that the domain of a valid address accepts mail for
+	// any local part, so that its mailbox cannot be verified and delivery is
+	// less certain
+	AcceptAll bool `json:"accept_all,omitempty"`
+	// PrivacyRelay names the privacy relay service, such as "apple" for
+	// Hide My Email, forwarding the address to a real mailbox
+	PrivacyRelay string `json:"privacy_relay,omitempty"`
+	// AlumniForwarder reports that the domain is a lifetime forwarding
+	// domain of a university's alumni, such as alum.mit.edu
+	AlumniForwarder bool `json:"alumni_forwarder,omitempty"`
+	// LikelyDisposable is the estimated probability, from 0 to 1, that the
+	// domain of a valid address belongs to an unlisted disposable service,
+	// when disposable heuristics are enabled. It lowers the score in
+	// proportion.
+	LikelyDisposable float64 `json:"likely_disposable,omitempty"`
+	// TLDRisk is the abuse risk of the top-level domain, from 0 to 1, when
+	// TLD risk weighting is enabled. It lowers the score in proportion.
+	TLDRisk float64 `json:"tld_risk,omitempty"`
+	// LocalPart describes the make-up of the local part when local part
+	// analysis is enabled
+	LocalPart *LocalPartAnalysis `json:"local_part,omitempty"`
+	// Name is the probable name of the owner, extracted from first.last
+	// style local parts when name extraction is enabled
+	Name *PersonName `json:"name,omitempty"`
+	// PersonalName reports whether the local part looks like a person's
+	// name rather than a handle or generated string, when personal name
+	// detection is enabled
+	PersonalName *bool `json:"personal_name,omitempty"`
+	
+	Syntax      CheckResult `json:"syntax"`
+	DNS         CheckResult `json:"dns"`
+	Reputation  CheckResult `json:"reputation"`
+	Suggestions CheckResult `json:"suggestions"`
+}
+
+// Err returns the validation errors joined into a single error, or nil when
+// the address is valid. The result supports errors.Is with the sentinel
+// errors, e.g. errors.Is(result.Err(), ErrDisposable).
+func (r ValidationResult) Err() error {
+	errs := make([]error, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+// ErrorMessages returns the human-readable messages of all errors
+func (r ValidationResult) ErrorMessages() []string {
+	messages := make([]string, len(r.Errors))
+	for i, err := range r.Errors {
+		messages[i] = err.Message
+	}
+	return messages
+}
+
+// addWarning records w
+func (r *ValidationResult) addWarning(w Warning) {
+	r.Warnings = append(r.Warnings, w)
+}
+
+// WarningsAtLeast returns the warnings whose severity is at least min
+func (r ValidationResult) WarningsAtLeast(min Severity) []Warning {
+	var warnings []Warning
+	for _, w := range r.Warnings {
+		if w.Severity >= min {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// addError records err, converting plain errors into a ValidationError
+func (r *ValidationResult) addError(err error) {
+	var verr ValidationError
+	if !errors.As(err, &verr) {
+		verr = newError(CodeInvalidFormat, FieldEmail, err, err.Error())
+	}
+	r.Errors = append(r.Errors, verr)
+}
+
+// Clone returns a deep copy of the validator
+func (v *EmailValidator) Clone() *EmailValidator {
+	c := *v
+	if v.allowTLDs != nil {
+		c.allowTLDs = append([]string(nil), v.allowTLDs...)
+	}
+	c.blockedDomains = v.blockedDomains.Clone()
+	return &c
+}
+
+// With returns a copy of the validator with the given options applied,
+// leaving the original untouched
+func (v *EmailValidator) With(opts ...Option) *EmailValidator {
+	c := v.Clone()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Batch returns a copy of the validator for one batch run. Its DNS checks
+// are memoized per domain in memo (a fresh one when nil), so each unique
+// domain is looked up only once.
+func (v *EmailValidator) Batch(memo *DomainMemo) Validator {
+	c := v.Clone()
+	if c.dnsChecker != nil {
+		c.dnsChecker = c.dnsChecker.Memoized(memo)
+	}
+	return c
+}
+
+// Prefetch looks up the domain of email with the configured DNS checker, if
+// any, so that a later Validate on a validator sharing the same memo finds
+// the answer. Like Validate, it makes no lookup for addresses failing the
+// syntax stage, such as malformed ones or those at blocked domains.
+func (v *EmailValidator) Prefetch(ctx context.Context, email string) {
+	if v.dnsChecker == nil {
+		return
+	}
+	var result ValidationResult
+	p, ok := v.checkSyntax(email, &result)
+	if !ok || result.Syntax.Status == StatusFailed {
+		return
+	}
+	v.dnsChecker.IsDomainValidContext(ctx, p.Domain)
 }
 
 // Validate performs comprehensive email validation
 func (v *EmailValidator) Validate(email string) ValidationResult {
-	result := ValidationResult{}
+	return v.ValidateContext(context.Background(), email)
+}
+
+// ValidateContext is like Validate but stops DNS lookups and enrichments
+// when ctx is done
+func (v *EmailValidator) ValidateContext(ctx context.Context, email string) ValidationResult {
+	if v.metrics == nil && v.tracer == nil && v.auditor == nil {
+		return v.validate(ctx, email)
+	}
+
+	var span Span
+	if v.tracer != nil {
+		ctx, span = v.tracer.Start(ctx, SpanValidate)
+	}
+	start := time.Now()
+	result := v.validate(ctx, email)
+	if v.metrics != nil {
+		v.metrics.ObserveValidation(result, time.Since(start))
+	}
+	if v.auditor != nil {
+		v.auditor.Audit(newAuditRecord(email, result, start, time.Now()))
+	}
+	if span != nil {
+		if result.Domain != "" {
+			span.SetAttribute(AttrDomain, result.Domain)
+		}
+		span.SetAttribute(AttrVerdict, string(result.Verdict))
+		span.SetAttribute(AttrValid, result.IsValid)
+		span.SetAttribute(AttrScore, result.Score)
+		span.End()
+	}
+	return result
+}
+
+// validate runs all validation stages on email
+func (v *EmailValidator) validate(ctx context.Context, email string) ValidationResult {
+	result := ValidationResult{
+		DNS: skipped(),
+	}
+	
+	p, ok := v.checkSyntax(email, &result)
+	if !ok {
+		result.Reputation = skipped()
+		result.Suggestions = skipped()
+		result.Score, result.Verdict = v.scoreWeights.score(signals{syntax: signalFail})
+		return result
+	}
+	result.Username = p.Local
+	result.Domain = p.Domain
+	
+	if v.dnsChecker != nil && result.Syntax.Status != StatusFailed {
+		v.checkDNS(ctx, p.Domain, &result)
+	}
+	
+	// Relays and alumni forwarders reach real mailboxes, whatever
+	// disposable lists say
+	result.PrivacyRelay = relayService(p.LowerDomain)
+	result.AlumniForwarder = isAlumniForwarder(p.LowerDomain)
+	disposable := result.PrivacyRelay == "" && !result.AlumniForwarder && isDisposableDomain(p.LowerDomain)
+	if v.tldRisk != nil {
+		result.TLDRisk = tldRisk(v.tldRisk, p.LowerDomain)
+	}
+	if v.localPartAnalysis {
+		analysis := analyzeLocalPart(p.LowerLocal)
+		result.LocalPart = &analysis
+	}
+	if v.personalNameDetector != nil {
+		v.checkPersonalName(p, &result)
+	}
+	if v.nameExtraction {
+		if name, ok := extractName(p.LowerLocal); ok {
+			result.Name = &name
+		}
+	}
+	v.checkReputation(p, disposable, &result)
+	v.checkSuggestions(p, &result)
+	
+	result.Normalized = v.normalization.Normalize(email)
+	if v.dotPolicy == DotsNormalize && v.relaxedDots() && len(result.Errors) == 0 {
+		result.Normalized = dropStrayDots(result.Normalized)
+	}
+	
+	result.IsValid = len(result.Errors) == 0
+	if v.gravatarChecker != nil && result.IsValid {
+		v.checkGravatar(ctx, email, &result)
+	}
+	if v.breachChecker != nil && result.IsValid {
+		v.checkBreaches(ctx, email, &result)
+	}
+	if v.reputationChecker != nil && result.IsValid {
+		v.checkDomainReputation(ctx, p.LowerDomain, &result)
+	}
+	if v.organizationEnricher != nil && result.IsValid {
+		v.checkOrganization(ctx, p.LowerDomain, &result)
+	}
+	if v.hostingLocator != nil && result.IsValid {
+		v.checkMailHosting(ctx, p.LowerDomain, &result)
+	}
+	if v.disposableHeuristics != nil && result.IsValid && !disposable && result.PrivacyRelay == "" && !result.AlumniForwarder {
+		v.checkDisposableHeuristics(ctx, p.LowerDomain, &result)
+	}
+	if v.acceptAllDomains != nil && result.IsValid {
+		v.checkAcceptAll(p.LowerDomain, &result)
+	}
+	
+	result.Score, result.Verdict = v.scoreWeights.score(signals{
+		syntax:           result.Syntax.signalState(),
+		dns:              result.DNS.signalState(),
+		disposable:       stateOf(disposable),
+		likelyDisposable: result.LikelyDisposable,
+		tldRisk:          result.TLDRisk,
+		role:             stateOf(roleAccounts[p.LowerLocal]),
+		pattern:          stateOf(isSuspiciousPattern(p.LowerLocal)),
+		incomplete:       result.DNS.Status == StatusError,
+	})
+	return result
+}
+
+// Canonical returns the canonical form of email used for normalization and
+// deduplication: trimmed and lower-cased
+func Canonical(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsValidSyntax reports whether email passes the syntax stage of Validate:
+// the format, length limits, local part and domain rules, blocked domains
+// and allowed TLDs. It makes no lookups, records no metrics or traces and
+// does not allocate for valid addresses, so it suits hot paths such as
+// form input checks; use Validate for the reasons and the score.
+func (v *EmailValidator) IsValidSyntax(email string) bool {
+	if !v.isValidFormat(email) || len(email) > v.maxLength {
+		return false
+	}
+	p, _ := ParseAddress(email)
+	return v.validateUsername(p.Local) == nil && v.validateDomain(p, len(p.Local)+1) == nil
+}
+
+// checkSyntax validates the address structure, returning its parts and
+// whether the basic format was recognised
+func (v *EmailValidator) checkSyntax(email string, result *ValidationResult) (ParsedEmail, bool) {
+	s := result.begin(&result.Syntax)
+	defer s.done()
+	
+	if checkInvisible(s, email) {
+		return ParsedEmail{}, false
+	}
 	
 	// Basic format check
 	if !v.isValidFormat(email) {
-		result.Errors = append(result.Errors, "Invalid email format")
-		return result
+		offset, substring := formatErrorPosition(email, v.looseHostnames())
+		s.addError(newError(CodeInvalidFormat, FieldEmail, ErrInvalidFormat, "Invalid email format").at(offset, substring))
+		return ParsedEmail{}, false
+	}
+	
+	if len(email) > v.maxLength {
+		s.addError(newError(CodeEmailTooLong, FieldEmail, ErrTooLong, fmt.Sprintf("email too long (max %d characters)", v.maxLength)).withParam("max", v.maxLength).at(v.maxLength, email[v.maxLength:]))
 	}
 	
-	// Extract parts
-	username, domain := v.splitEmail(email)
-	result.Username = username
-	result.Domain = domain
+	// Extract parts; the format check guarantees a single "@"
+	p, _ := ParseAddress(email)
 	
 	// Validate username
-	if err := v.validateUsername(username); err != nil {
-		result.Errors = append(result.Errors, err.Error())
+	if err := v.validateUsername(p.Local); err != nil {
+		s.addError(err)
+	} else {
+		v.checkDots(s, p)
 	}
 	
 	// Validate domain
-	if err := v.validateDomain(domain); err != nil {
-		result.Errors = append(result.Errors, err.Error())
+	if err := v.validateDomain(p, len(p.Local)+1); err != nil {
+		s.addError(err)
+	} else {
+		v.checkHostname(s, p)
 	}
 	
-	// Check for common typos
-	if warning := v.checkForTypos(email); warning != "" {
-		result.Warnings = append(result.Warnings, warning)
+	return p, true
+}
+
+// checkDNS verifies that the domain can receive mail
+func (v *EmailValidator) checkDNS(ctx context.Context, domain string, result *ValidationResult) {
+	s := result.begin(&result.DNS)
+	
+	var span Span
+	if v.tracer != nil {
+		ctx, span = v.tracer.Start(ctx, SpanDNS)
 	}
 	
-	// Normalize email (lowercase)
-	result.Normalized = strings.ToLower(strings.TrimSpace(email))
+	exists, cached, err := v.dnsChecker.check(ctx, domain)
+	switch {
+	case errors.Is(err, ErrDomainNotFound) || (err == nil && !exists):
+		s.addError(newError(CodeDomainNotFound, FieldDomain, ErrDomainNotFound, "domain does not exist or cannot receive email").withParam("domain", domain))
+	case err != nil:
+		s.addWarning(newWarning(WarnDNSUnavailable, SeverityWarning, "DNS lookup could not be completed").withParam("domain", domain))
+		s.unavailable()
+	case v.parkingDetector != nil:
+		// Detection failures are not reported: the domain exists and the
+		// address may well be deliverable
+		if parked, _ := v.parkingDetector.IsParked(ctx, domain); parked {
+			s.addError(newError(CodeDomainParked, FieldDomain, ErrParkedDomain, "domain is parked and does not receive email").withParam("domain", domain))
+		}
+	}
+	s.done()
 	
-	result.IsValid = len(result.Errors) == 0
-	return result
+	if span != nil {
+		span.SetAttribute(AttrDomain, domain)
+		span.SetAttribute(AttrCacheHit, cached)
+		span.SetAttribute(AttrStatus, string(result.DNS.Status))
+		if err != nil && !errors.Is(err, ErrDomainNotFound) {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
+
+// checkReputation looks for disposable providers and role accounts
+func (v *EmailValidator) checkReputation(p ParsedEmail, disposable bool, result *ValidationResult) {
+	s := result.begin(&result.Reputation)
+	defer s.done()
+	
+	// Check for disposable providers
+	if v.disposableCheck && disposable {
+		if v.disposableSeverity == SeverityError {
+			s.addError(newError(CodeDisposable, FieldDomain, ErrDisposable, "disposable email addresses are not allowed").withParam("domain", p.Domain).at(len(p.Local)+1, p.Domain))
+		} else {
+			s.addWarning(newWarning(WarnDisposable, v.disposableSeverity, "Disposable email address detected").withParam("domain", p.Domain))
+		}
+	}
+	
+	if result.PrivacyRelay != "" {
+		v.checkPrivacyRelay(s, p)
+	}
+	if v.tldPolicies != nil {
+		v.checkTLDPolicy(s, p)
+	}
+	if v.emojiPolicy != PolicyAllow && isEmojiDomain(p.LowerDomain) {
+		v.checkEmojiDomain(s, p)
+	}
+	v.checkMixedScript(s, p)
+	
+	// Flag role-based accounts such as admin@ or support@
+	if roleAccounts[p.LowerLocal] {
+		s.addWarning(newWarning(WarnRoleAccount, SeverityInfo, "Role-based account detected").withParam("local_part", redactPart(p.Local)))
+	}
 }
 
+// checkSuggestions suggests a correction for common domain typos
+func (v *EmailValidator) checkSuggestions(p ParsedEmail, result *ValidationResult) {
+	if !v.typoSuggestions {
+		result.Suggestions = skipped()
+		return
+	}
+	
+	s := result.begin(&result.Suggestions)
+	defer s.done()
+	
+	result.Suggestion = v.suggestCorrection(p.Local, p.LowerDomain)
+	if result.Suggestion != "" {
+		s.status = StatusWarning
+	}
+}
+
+// formatPattern is an RFC 5322 compliant regex (simplified version)
+var formatPattern = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// utf8FormatPattern is formatPattern also accepting the non-ASCII letters,
+// marks and digits of internationalized local parts (RFC 6531)
+var utf8FormatPattern = regexp.MustCompile(`^[\p{L}\p{M}\p{N}a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
 // isValidFormat checks basic email format using regex
 func (v *EmailValidator) isValidFormat(email string) bool {
-	// RFC 5322 compliant regex (simplified version)
-	pattern := `^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`
-	matched, _ := regexp.MatchString(pattern, email)
-	return matched
+	switch {
+	case v.smtputf8 && v.looseHostnames():
+		return looseUTF8FormatPattern.MatchString(email)
+	case v.smtputf8:
+		return utf8FormatPattern.MatchString(email)
+	case v.looseHostnames():
+		return looseFormatPattern.MatchString(email)
+	}
+	return formatPattern.MatchString(email)
 }
 
 // splitEmail splits email into username and domain parts
@@ -94,28 +558,38 @@ func (v *EmailValidator) splitEmail(email string) (string, string) {
 // validateUsername checks username part constraints
 func (v *EmailValidator) validateUsername(username string) error {
 	if len(username) == 0 {
-		return errors.New("username cannot be empty")
+		return newError(CodeLocalPartEmpty, FieldLocal, ErrInvalidLocalPart, "username cannot be empty").at(0, "")
 	}
 	
-	if len(username) > 64 {
-		return errors.New("username too long (max 64 characters)")
+	if len(username) > v.maxLocalLength {
+		return newError(CodeLocalPartTooLong, FieldLocal, ErrTooLong, fmt.Sprintf("username too long (max %d characters)", v.maxLocalLength)).withParam("max", v.maxLocalLength).at(v.maxLocalLength, username[v.maxLocalLength:])
 	}
 	
-	// Check for consecutive dots
-	if strings.Contains(username, "..") {
-		return errors.New("username cannot contain consecutive dots")
-	}
+	// The dot policy may accept stray dots, but not a local part of dots only
+	if v.relaxedDots() {
+		if strings.Trim(username, ".") == "" {
+			return newError(CodeLocalPartDotBoundary, FieldLocal, ErrInvalidLocalPart, "username cannot consist of dots only").at(0, username)
+		}
+	} else {
+		// Check for consecutive dots
+		if i := strings.Index(username, ".."); i >= 0 {
+			return newError(CodeLocalPartConsecutiveDots, FieldLocal, ErrInvalidLocalPart, "username cannot contain consecutive dots").at(i, "..")
+		}
 	
-	// Check if starts or ends with dot
-	if strings.HasPrefix(username, ".") || strings.HasSuffix(username, ".") {
-		return errors.New("username cannot start or end with a dot")
+		// Check if starts or ends with dot
+		if strings.HasPrefix(username, ".") {
+			return newError(CodeLocalPartDotBoundary, FieldLocal, ErrInvalidLocalPart, "username cannot start or end with a dot").at(0, ".")
+		}
+		if strings.HasSuffix(username, ".") {
+			return newError(CodeLocalPartDotBoundary, FieldLocal, ErrInvalidLocalPart, "username cannot start or end with a dot").at(len(username)-1, ".")
+		}
 	}
 	
 	// In strict mode, check for special characters
 	if v.strictMode {
-		for _, char := range username {
+		for i, char := range username {
 			if !v.isValidUsernameChar(char) {
-				return errors.New("username contains invalid characters")
+				return newError(CodeLocalPartInvalidChars, FieldLocal, ErrInvalidLocalPart, "username contains invalid characters").at(i, runeAt(username, i))
 			}
 		}
 	}
@@ -123,43 +597,123 @@ func (v *EmailValidator) validateUsername(username string) error {
 	return nil
 }
 
-// validateDomain checks domain part constraints
-func (v *EmailValidator) validateDomain(domain string) error {
+// validateDomain checks domain part constraints. base is the byte offset of
+// the domain within the address and is used for error positions.
+func (v *EmailValidator) validateDomain(p ParsedEmail, base int) error {
+	domain := p.Domain
+	
 	if len(domain) == 0 {
-		return errors.New("domain cannot be empty")
+		return newError(CodeDomainEmpty, FieldDomain, ErrInvalidDomain, "domain cannot be empty").at(base, "")
+	}
+	
+	if len(domain) > v.maxDomainLength {
+		return newError(CodeDomainTooLong, FieldDomain, ErrTooLong, fmt.Sprintf("domain too long (max %d characters)", v.maxDomainLength)).withParam("max", v.maxDomainLength).at(base+v.maxDomainLength, domain[v.maxDomainLength:])
 	}
 	
-	if len(domain) > 253 {
-		return errors.New("domain too long (max 253 characters)")
+	if v.blockedDomains.Contains(p.LowerDomain) {
+		return newError(CodeDomainBlocked, FieldDomain, ErrBlockedDomain, "domain is blocked").withParam("domain", domain).at(base, domain)
 	}
 	
 	// Check for valid domain structure
-	domainParts := strings.Split(domain, ".")
-	if len(domainParts) < 2 {
-		return errors.New("domain must have at least two parts")
+	if strings.IndexByte(domain, '.') < 0 {
+		return newError(CodeDomainTooFewLabels, FieldDomain, ErrInvalidDomain, "domain must have at least two parts").at(base, domain)
+	}
+	
+	tld := p.TLD
+	if len(v.allowTLDs) > 0 && !v.isAllowedTLD(tld) {
+		return newError(CodeTLDNotAllowed, FieldTLD, ErrTLDNotAllowed, "top-level domain is not allowed").withParam("tld", tld).at(base+len(domain)-len(tld), tld)
 	}
 	
 	// Check each domain part
-	for _, part := range domainParts {
+	offset := base
+	for rest, more := domain, true; more; {
+		var part string
+		part, rest, more = strings.Cut(rest, ".")
 		if len(part) == 0 {
-			return errors.New("domain part cannot be empty")
+			return newError(CodeDomainLabelEmpty, FieldDomain, ErrInvalidDomain, "domain part cannot be empty").at(offset, "")
 		}
 		if len(part) > 63 {
-			return errors.New("domain part too long (max 63 characters)")
+			return newError(CodeDomainLabelTooLong, FieldDomain, ErrInvalidDomain, "domain part too long (max 63 characters)").withParam("max", 63).at(offset, part)
+		}
+		if strings.HasPrefix(part, "-") {
+			return newError(CodeDomainLabelHyphen, FieldDomain, ErrInvalidDomain, "domain part cannot start or end with hyphen").at(offset, "-")
 		}
-		if strings.HasPrefix(part, "-") || strings.HasSuffix(part, "-") {
-			return errors.New("domain part cannot start or end with hyphen")
+		if strings.HasSuffix(part, "-") {
+			return newError(CodeDomainLabelHyphen, FieldDomain, ErrInvalidDomain, "domain part cannot start or end with hyphen").at(offset+len(part)-1, "-")
 		}
 		
 		// Check for valid characters in domain part
-		for _, char := range part {
+		for i, char := range part {
 			if !v.isValidDomainChar(char) {
-				return errors.New("domain contains invalid characters")
+				return newError(CodeDomainInvalidChars, FieldDomain, ErrInvalidDomain, "domain contains invalid characters").at(offset+i, runeAt(part, i))
 			}
 		}
+		offset += len(part) + 1
 	}
 	
-	return nil
+	return v.validateTLD(p, base)
+}
+
+// formatErrorPosition locates the first character that breaks the basic
+// address format, returning -1 when no single position is at fault;
+// underscores reports whether domains may contain underscores
+func formatErrorPosition(email string, underscores bool) (int, string) {
+	at := strings.Index(email, "@")
+	if at < 0 {
+		return -1, ""
+	}
+	if next := strings.Index(email[at+1:], "@"); next >= 0 {
+		return at + 1 + next, "@"
+	}
+	for i, char := range email {
+		switch {
+		case i == at:
+			continue
+		case i < at && !strings.ContainsRune(localPartSymbols, char) && !isASCIIAlphanumeric(char):
+			return i, runeAt(email, i)
+		case i > at && char != '.' && char != '-' && !(char == '_' && underscores) && !isASCIIAlphanumeric(char):
+			return i, runeAt(email, i)
+		}
+	}
+	offset := at + 1
+	for _, label := range strings.Split(email[at+1:], ".") {
+		switch {
+		case label == "":
+			return offset, ""
+		case strings.HasPrefix(label, "-"):
+			return offset, "-"
+		case strings.HasSuffix(label, "-"):
+			return offset + len(label) - 1, "-"
+		}
+		offset += len(label) + 1
+	}
+	return -1, ""
+}
+
+// runeAt returns the character at byte offset i of s as it appears in s,
+// so that an invalid UTF-8 byte is reported as itself
+func runeAt(s string, i int) string {
+	_, size := utf8.DecodeRuneInString(s[i:])
+	return s[i : i+size]
+}
+
+// localPartSymbols are the non-alphanumeric characters accepted by the basic
+// format check in the local part
+const localPartSymbols = ".!#$%&'*+/=?^_`{|}~-"
+
+// isASCIIAlphanumeric reports whether char is an ASCII letter or digit
+func isASCIIAlphanumeric(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
+
+// isAllowedTLD reports whether tld is in the configured allow list
+func (v *EmailValidator) isAllowedTLD(tld string) bool {
+	for _, allowed := range v.allowTLDs {
+		if strings.EqualFold(allowed, tld) {
+			return true
+		}
+	}
+	return false
 }
 
 // isValidUsernameChar checks if character is valid in email username
@@ -173,27 +727,32 @@ func (v *EmailValidator) isValidUsernameChar(char rune) bool {
 
 // isValidDomainChar checks if character is valid in domain part
 func (v *EmailValidator) isValidDomainChar(char rune) bool {
-	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '-'
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '-' || char == '_' && v.looseHostnames()
 }
 
-// checkForTypos looks for common email typos
-func (v *EmailValidator) checkForTypos(email string) string {
-	lowerEmail := strings.ToLower(email)
-	
-	// Check for common domain typos
-	commonTypos := map[string]string{
-		"gmial.com":  "gmail.com",
-		"gmal.com":   "gmail.com",
-		"gmai.com":   "gmail.com",
-		"yahooo.com": "yahoo.com",
-		"yaho.com":   "yahoo.com",
-		"hotmal.com": "hotmail.com",
-		"hotmai.com": "hotmail.com",
+// commonTypos maps common misspellings of popular provider domains to
+// the intended domain
+var commonTypos = map[string]string{
+	"gmial.com":  "gmail.com",
+	"gmal.com":   "gmail.com",
+	"gmai.com":   "gmail.com",
+	"yahooo.com": "yahoo.com",
+	"yaho.com":   "yahoo.com",
+	"hotmal.com": "hotmail.com",
+	"hotmai.com": "hotmail.com",
+}
+
+// suggestCorrection returns the corrected address when the lower-cased
+// domain is a common typo of a suggestion domain, or within one edit of
+// one when the domains were set WithSuggestionDomains, or an empty string
+// otherwise
+func (v *EmailValidator) suggestCorrection(username, lowerDomain string) string {
+	if correct, ok := commonTypos[lowerDomain]; ok && v.suggestionDomains.set[correct] {
+		return username + "@" + correct
 	}
-	
-	for typo, correct := range commonTypos {
-		if strings.Contains(lowerEmail, "@"+typo) {
-			return "Possible typo detected: " + typo + " should be " + correct
+	if v.suggestionDomains != defaultSuggestionTargets {
+		if correct := v.suggestionDomains.closest(lowerDomain); correct != "" {
+			return username + "@" + correct
 		}
 	}
 	
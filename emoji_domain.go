@@ -0,0 +1,29 @@
+package emailvalidator
+
+import "unicode"
+
+// isEmojiDomain reports whether a label of the lower-cased domain decodes
+// to emoji or other symbols. IDNA2008 disallows them, but registries still
+// following IDNA2003, such as .ws and .to, register emoji domains.
+func isEmojiDomain(lowerDomain string) bool {
+	unicodeDomain, err := domainToUnicode(lowerDomain)
+	if err != nil {
+		return false
+	}
+	for _, r := range unicodeDomain {
+		if unicode.Is(unicode.So, r) || r >= 0x1F000 && r <= 0x1FAFF {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEmojiDomain applies the emoji domain policy
+func (v *EmailValidator) checkEmojiDomain(s *stage, p ParsedEmail) {
+	switch v.emojiPolicy {
+	case PolicyWarn:
+		s.addWarning(newWarning(WarnEmojiDomain, SeverityWarning, "Emoji domain detected").withParam("domain", p.Domain))
+	case PolicyBlock:
+		s.addError(newError(CodeEmojiDomain, FieldDomain, ErrEmojiDomain, "emoji domains are not allowed").withParam("domain", p.Domain).at(len(p.Local)+1, p.Domain))
+	}
+}
@@ -0,0 +1,38 @@
+package emailvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmojiDomain(t *testing.T) {
+	tests := map[string]bool{
+		"xn--i-7iq.ws":     true,  // i❤.ws
+		"mail.xn--e28h.to": true,  // mail.😀.to
+		"xn--bcher-kva.de": false, // bücher.de
+		"example.com":      false,
+		"xn--zz-!.com":     false, // not Punycode
+	}
+	for domain, want := range tests {
+		if got := isEmojiDomain(domain); got != want {
+			t.Errorf("isEmojiDomain(%q) = %v, want %v", domain, got, want)
+		}
+	}
+}
+
+func TestEmojiDomainPolicy(t *testing.T) {
+	const email = "jane@xn--i-7iq.ws"
+	if r := New().Validate(email); !r.IsValid || len(r.Reasons()) != 0 {
+		t.Errorf("default: valid %v, reasons %v", r.IsValid, r.Reasons())
+	}
+	if r := New(WithEmojiDomainPolicy(PolicyWarn)).Validate(email); !r.IsValid || r.Reason() != ReasonEmojiDomain {
+		t.Errorf("warn: valid %v, reasons %v", r.IsValid, r.Reasons())
+	}
+	r := New(WithEmojiDomainPolicy(PolicyBlock)).Validate(email)
+	if r.IsValid || !errors.Is(r.Err(), ErrEmojiDomain) {
+		t.Errorf("block: valid %v, errors %v", r.IsValid, r.Errors)
+	}
+	if r := New(WithEmojiDomainPolicy(PolicyBlock)).Validate("jane@xn--bcher-kva.de"); !r.IsValid {
+		t.Errorf("block rejected an IDN without emoji: %v", r.Errors)
+	}
+}
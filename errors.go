@@ -0,0 +1,107 @@
+package emailvalidator
+
+import "errors"
+
+// Sentinel errors returned, wrapped, by validation rules and checks.
+// Use errors.Is to branch on them instead of matching messages.
+var (
+	ErrInvalidFormat    = errors.New("invalid email format")
+	ErrTooLong          = errors.New("email exceeds length limit")
+	ErrInvalidLocalPart = errors.New("invalid local part")
+	ErrInvalidDomain    = errors.New("invalid domain")
+	ErrBlockedDomain    = errors.New("domain is blocked")
+	ErrTLDNotAllowed    = errors.New("top-level domain is not allowed")
+	ErrDisposable       = errors.New("disposable email address")
+	ErrDomainNotFound   = errors.New("domain not found")
+	ErrDNSLookup        = errors.New("DNS lookup failed")
+	ErrParkedDomain     = errors.New("domain is parked")
+	ErrPrivacyRelay     = errors.New("privacy relay address")
+	ErrEmojiDomain      = errors.New("emoji domain")
+)
+
+// ErrorCode is a stable, machine-readable identifier for a validation error
+type ErrorCode string
+
+// Error codes reported in ValidationError.Code
+const (
+	CodeInvalidFormat            ErrorCode = "invalid_format"
+	CodeInvisibleChars           ErrorCode = "invisible_chars"
+	CodeEmailTooLong             ErrorCode = "email_too_long"
+	CodeLocalPartEmpty           ErrorCode = "local_part_empty"
+	CodeLocalPartTooLong         ErrorCode = "local_part_too_long"
+	CodeLocalPartConsecutiveDots ErrorCode = "local_part_consecutive_dots"
+	CodeLocalPartDotBoundary     ErrorCode = "local_part_dot_boundary"
+	CodeLocalPartInvalidChars    ErrorCode = "local_part_invalid_chars"
+	CodeDomainEmpty              ErrorCode = "domain_empty"
+	CodeDomainTooLong            ErrorCode = "domain_too_long"
+	CodeDomainBlocked            ErrorCode = "domain_blocked"
+	CodeDomainTooFewLabels       ErrorCode = "domain_too_few_labels"
+	CodeDomainLabelEmpty         ErrorCode = "domain_label_empty"
+	CodeDomainLabelTooLong       ErrorCode = "domain_label_too_long"
+	CodeDomainLabelHyphen        ErrorCode = "domain_label_hyphen"
+	CodeDomainInvalidChars       ErrorCode = "domain_invalid_chars"
+	CodeTLDNotAllowed            ErrorCode = "tld_not_allowed"
+	CodeTLDNumeric               ErrorCode = "tld_numeric"
+	CodeTLDTooShort              ErrorCode = "tld_too_short"
+	CodeDisposable               ErrorCode = "disposable"
+	CodeDomainNotFound           ErrorCode = "domain_not_found"
+	CodeDomainParked             ErrorCode = "domain_parked"
+	CodePrivacyRelay             ErrorCode = "privacy_relay"
+	CodeEmojiDomain              ErrorCode = "emoji_domain"
+)
+
+// Fields of the address an error refers to
+const (
+	FieldEmail  = "email"
+	FieldLocal  = "local"
+	FieldDomain = "domain"
+	FieldTLD    = "tld"
+)
+
+// ValidationError represents a validation error. Code, Field and Params are
+// meant for programs; Message is a human-readable English description.
+// Offset is the byte offset of Substring, the offending part of the address,
+// or -1 when the error does not refer to a specific position. In privacy
+// mode Substring is masked unless it is only punctuation.
+type ValidationError struct {
+	Code      ErrorCode              `json:"code"`
+	Field     string                 `json:"field,omitempty"`
+	Message   string                 `json:"message"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Offset    int                    `json:"offset"`
+	Substring string                 `json:"substring,omitempty"`
+	Rule      string                 `json:"rule,omitempty"`
+	Err       error                  `json:"-"`
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the sentinel error this error wraps
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// newError builds a ValidationError with code for field that wraps err
+func newError(code ErrorCode, field string, err error, message string) ValidationError {
+	return ValidationError{Code: code, Field: field, Message: message, Offset: -1, Err: err}
+}
+
+// at returns a copy of e pointing at substring found at offset
+func (e ValidationError) at(offset int, substring string) ValidationError {
+	e.Offset = offset
+	e.Substring = redactPart(substring)
+	return e
+}
+
+// withParam returns a copy of e with an additional parameter
+func (e ValidationError) withParam(key string, value interface{}) ValidationError {
+	params := make(map[string]interface{}, len(e.Params)+1)
+	for k, v := range e.Params {
+		params[k] = v
+	}
+	params[key] = value
+	e.Params = params
+	return e
+}
@@ -0,0 +1,121 @@
+package emailvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResultErrSupportsErrorsIs(t *testing.T) {
+	v := New(
+		WithBlockedDomains([]string{"spam.com"}),
+		WithDisposableCheck(SeverityError),
+	)
+
+	testCases := []struct {
+		email string
+		want  error
+	}{
+		{"invalid-email", ErrInvalidFormat},
+		{"user@spam.com", ErrBlockedDomain},
+		{"user@mailinator.com", ErrDisposable},
+		{"user..name@example.com", ErrInvalidLocalPart},
+	}
+
+	for _, tc := range testCases {
+		err := v.Validate(tc.email).Err()
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected errors.Is(%v, %v)", tc.email, err, tc.want)
+		}
+		var verr ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%s: expected a ValidationError in %v", tc.email, err)
+		}
+	}
+
+	if err := v.Validate("user@example.com").Err(); err != nil {
+		t.Errorf("expected nil error for a valid address, got %v", err)
+	}
+}
+
+func TestRulesWrapSentinels(t *testing.T) {
+	if err := NewFormatRule().Validate("nope"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("format rule: got %v", err)
+	}
+	rule := NewDisposableDomainRule(map[string]bool{"mailinator.com": true})
+	if err := rule.Validate("user@mailinator.com"); !errors.Is(err, ErrDisposable) {
+		t.Errorf("disposable rule: got %v", err)
+	}
+	if _, err := NewDNSChecker().ValidateEmailDomain("nope"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("dns checker: got %v", err)
+	}
+}
+
+func TestResultErrorCodes(t *testing.T) {
+	v := New(WithMaxLocalPartLength(4), WithBlockedDomains([]string{"spam.com"}))
+
+	testCases := []struct {
+		email string
+		code  ErrorCode
+		field string
+	}{
+		{"invalid-email", CodeInvalidFormat, FieldEmail},
+		{"toolong@example.com", CodeLocalPartTooLong, FieldLocal},
+		{"user@spam.com", CodeDomainBlocked, FieldDomain},
+		{"a.@example.com", CodeLocalPartDotBoundary, FieldLocal},
+	}
+
+	for _, tc := range testCases {
+		result := v.Validate(tc.email)
+		if len(result.Errors) == 0 {
+			t.Errorf("%s: expected an error", tc.email)
+			continue
+		}
+		got := result.Errors[0]
+		if got.Code != tc.code || got.Field != tc.field || got.Message == "" {
+			t.Errorf("%s: got %+v, want code %s field %s", tc.email, got, tc.code, tc.field)
+		}
+	}
+
+	if max := v.Validate("toolong@example.com").Errors[0].Params["max"]; max != 4 {
+		t.Errorf("expected max param 4, got %v", max)
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	v := New(WithAllowedTLDs([]string{"com"}))
+
+	testCases := []struct {
+		email     string
+		field     string
+		offset    int
+		substring string
+	}{
+		{"user..name@example.com", FieldLocal, 4, ".."},
+		{"user@exa_mple.com", FieldEmail, 8, "_"},
+		{"user@exa mple.com", FieldEmail, 8, " "},
+		{"user@a@example.com", FieldEmail, 6, "@"},
+		{"user@example.-bad.com", FieldEmail, 13, "-"},
+		{"user@example.org", FieldTLD, 13, "org"},
+		{"user.@example.com", FieldLocal, 4, "."},
+	}
+
+	for _, tc := range testCases {
+		result := v.Validate(tc.email)
+		if len(result.Errors) == 0 {
+			t.Errorf("%s: expected an error", tc.email)
+			continue
+		}
+		got := result.Errors[0]
+		if got.Field != tc.field || got.Offset != tc.offset || got.Substring != tc.substring {
+			t.Errorf("%s: got field %q offset %d substring %q, want %q %d %q",
+				tc.email, got.Field, got.Offset, got.Substring, tc.field, tc.offset, tc.substring)
+		}
+		if got.Offset >= 0 && tc.email[got.Offset:got.Offset+len(got.Substring)] != got.Substring {
+			t.Errorf("%s: offset %d does not point at %q", tc.email, got.Offset, got.Substring)
+		}
+	}
+
+	if got := v.Validate("no-at-sign").Errors[0]; got.Offset != -1 {
+		t.Errorf("expected offset -1 without a position, got %d", got.Offset)
+	}
+}
@@ -65,4 +65,29 @@ func TestEmailValidation(t *testing.T) {
 			t.Errorf("Email %s: expected %t, got %t", tc.email, tc.expected, result.IsValid)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func ExampleEmailValidator_IsValidSyntax() {
+	validator := New(WithBlockedDomains([]string{"spam.com"}))
+	
+	for _, email := range []string{"user@example.com", "user..name@example.com", "user@spam.com"} {
+		fmt.Printf("%s: %t\n", email, validator.IsValidSyntax(email))
+	}
+	
+	// Output:
+	// user@example.com: true
+	// user..name@example.com: false
+	// user@spam.com: false
+}
+
+func ExampleValidatorFunc() {
+	// A fake validator for tests that accepts every address
+	var validator Validator = ValidatorFunc(func(email string) ValidationResult {
+		return ValidationResult{IsValid: true, Verdict: VerdictValid, Score: 100}
+	})
+
+	fmt.Println(validator.Validate("anything").IsValid)
+
+	// Output:
+	// true
+}
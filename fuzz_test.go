@@ -0,0 +1,106 @@
+package emailvalidator
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// Run a target with, for example,
+//
+//	go test -run '^$' -fuzz FuzzValidate
+//
+// Without -fuzz, the seeds run as ordinary tests.
+
+// addSeeds seeds f with the addresses of the conformance corpus
+func addSeeds(f *testing.F) {
+	for _, c := range corpus {
+		f.Add(c.address)
+	}
+	f.Add("jane@")
+	f.Add("@")
+	f.Add("a@b@c")
+	f.Add("\xff@example.com")
+}
+
+func FuzzValidate(f *testing.F) {
+	addSeeds(f)
+	lenientValidator := New(WithSMTPUTF8(true), WithLocalPartAnalysis(true), WithNameExtraction(true))
+	strictValidator := NewStrict(WithSMTPUTF8(true))
+	patterns := NewCommonPatterns()
+
+	f.Fuzz(func(t *testing.T, email string) {
+		r := lenientValidator.Validate(email)
+		if r.IsValid != (len(r.Errors) == 0) {
+			t.Fatalf("IsValid = %v with errors %v", r.IsValid, r.Errors)
+		}
+		if r.IsValid {
+			if _, ok := ParseAddress(email); !ok {
+				t.Fatalf("valid address %q does not parse", email)
+			}
+		}
+		if got := lenientValidator.IsValidSyntax(email); got != (r.Syntax.Status != StatusFailed) {
+			t.Fatalf("IsValidSyntax(%q) = %v, syntax stage %s", email, got, r.Syntax.Status)
+		}
+		if strictValidator.Validate(email).IsValid && !r.IsValid {
+			t.Fatalf("%q accepted by the strict validator only", email)
+		}
+		for _, err := range r.Errors {
+			if err.Offset > len(email) || err.Offset >= 0 && !strings.HasPrefix(email[err.Offset:], err.Substring) {
+				t.Fatalf("error %s at %d, %q does not point into %q", err.Code, err.Offset, err.Substring, email)
+			}
+		}
+
+		patterns.IsDisposable(email)
+		patterns.IsRoleAccount(email)
+		patterns.ClassifyPatterns(email)
+		patterns.AnalyzeLocalPart(email)
+	})
+}
+
+func FuzzParseAddress(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, email string) {
+		p, ok := ParseAddress(email)
+		if p.Address != email {
+			t.Fatalf("Address = %q", p.Address)
+		}
+		if ok != (strings.Count(email, "@") == 1) {
+			t.Fatalf("ok = %v for %q", ok, email)
+		}
+		if !ok {
+			return
+		}
+		if p.Local+"@"+p.Domain != email {
+			t.Fatalf("parts %q and %q do not rebuild %q", p.Local, p.Domain, email)
+		}
+		if !strings.HasSuffix(p.Domain, p.TLD) || strings.Contains(p.TLD, ".") {
+			t.Fatalf("TLD %q of %q", p.TLD, p.Domain)
+		}
+		if utf8.ValidString(email) && (!strings.EqualFold(p.Local, p.LowerLocal) || !strings.EqualFold(p.Domain, p.LowerDomain)) {
+			t.Fatalf("lower-cased parts %q and %q of %q", p.LowerLocal, p.LowerDomain, email)
+		}
+	})
+}
+
+func FuzzExtract(f *testing.F) {
+	addSeeds(f)
+	v := New()
+	patterns := NewCommonPatterns()
+
+	f.Fuzz(func(t *testing.T, email string) {
+		local, domain := v.ExtractUsername(email), v.ExtractDomain(email)
+		if p, ok := ParseAddress(email); ok {
+			if local != p.Local || domain != p.Domain {
+				t.Fatalf("extracted %q and %q, parsed %q and %q", local, domain, p.Local, p.Domain)
+			}
+		} else if local != "" || domain != "" {
+			t.Fatalf("extracted %q and %q from %q", local, domain, email)
+		}
+
+		name, ok := patterns.ExtractName(email)
+		if ok && (name.First == "" || name.Last == "" || name.Confidence <= 0 || name.Confidence > 1) {
+			t.Fatalf("ExtractName(%q) = %+v", email, name)
+		}
+	})
+}
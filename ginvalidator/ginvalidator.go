@@ -0,0 +1,263 @@
+// Package ginvalidator validates the email fields of structs bound by Gin.
+// Install it as Gin's binding validator, keeping Gin's default validator
+// for the other binding tags:
+//
+//	binding.Validator = ginvalidator.New(
+//		ginvalidator.WithFallback(binding.Validator),
+//		ginvalidator.WithRule("nodisposable"),
+//	)
+//
+// String fields tagged `email:""` are validated; the tag may add checks
+// for that field, as in `email:"dns norole"`. A field with a likely domain
+// typo reports the suggestion in its error. Routes needing other checks
+// derive a validator with With and bind through it:
+//
+//	signup := validator.With(ginvalidator.WithRule("dns notypo"))
+//	if err := signup.Bind(c, &req); err != nil { ... }
+package ginvalidator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/internal/tagrule"
+)
+
+// TagName is the struct tag marking the fields to validate
+const TagName = "email"
+
+// StructValidator is Gin's binding.StructValidator
+type StructValidator interface {
+	ValidateStruct(obj any) error
+	Engine() any
+}
+
+// Binder is implemented by *gin.Context
+type Binder interface {
+	ShouldBind(obj any) error
+}
+
+// Option configures a Validator
+type Option func(*Validator)
+
+// WithValidator sets the validator the field checks are derived from.
+// The default is emailvalidator.New().
+func WithValidator(v *emailvalidator.EmailValidator) Option {
+	return func(gv *Validator) {
+		gv.base = v
+	}
+}
+
+// WithFallback runs next before the email checks, typically Gin's default
+// binding.Validator, so that the other binding tags keep working
+func WithFallback(next StructValidator) Option {
+	return func(gv *Validator) {
+		gv.fallback = next
+	}
+}
+
+// WithRule adds checks applied to every email field, written like the
+// field tag. Successive calls accumulate.
+func WithRule(params string) Option {
+	return func(gv *Validator) {
+		gv.params = strings.TrimSpace(gv.params + " " + params)
+	}
+}
+
+// Validator implements Gin's binding.StructValidator
+type Validator struct {
+	base     *emailvalidator.EmailValidator
+	fallback StructValidator
+	params   string
+	rules    *tagrule.Set
+}
+
+// New creates a Validator
+func New(opts ...Option) *Validator {
+	v := &Validator{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.base == nil {
+		v.base = emailvalidator.New()
+	}
+	v.rules = tagrule.NewSet(v.base)
+	return v
+}
+
+// With returns a copy of the validator with opts applied, for routes that
+// need different checks
+func (v *Validator) With(opts ...Option) *Validator {
+	c := &Validator{base: v.base, fallback: v.fallback, params: v.params}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.rules = v.rules
+	if c.base != v.base {
+		c.rules = tagrule.NewSet(c.base)
+	}
+	return c
+}
+
+// Engine returns the fallback's engine, or the Validator itself
+func (v *Validator) Engine() any {
+	if v.fallback != nil {
+		return v.fallback.Engine()
+	}
+	return v
+}
+
+// ValidateStruct runs the fallback and then validates the email fields of
+// obj, returning ValidationErrors when any is rejected
+func (v *Validator) ValidateStruct(obj any) error {
+	if v.fallback != nil {
+		if err := v.fallback.ValidateStruct(obj); err != nil {
+			return err
+		}
+	}
+	return v.validateEmails(obj)
+}
+
+// Bind binds the request with c and then validates the email fields of
+// obj with this validator's checks. The global binding validator still
+// runs during binding.
+func (v *Validator) Bind(c Binder, obj any) error {
+	if err := c.ShouldBind(obj); err != nil {
+		return err
+	}
+	return v.validateEmails(obj)
+}
+
+// validateEmails checks the tagged fields of obj
+func (v *Validator) validateEmails(obj any) error {
+	var errs ValidationErrors
+	if err := v.walk(reflect.ValueOf(obj), "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// walk visits the fields of value, recording rejected emails in errs
+func (v *Validator) walk(value reflect.Value, path string, errs *ValidationErrors) error {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.walk(value.Index(i), fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := join(path, fieldName(field))
+		params, tagged := field.Tag.Lookup(TagName)
+		if !tagged {
+			if err := v.walk(value.Field(i), name, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fv := value.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("ginvalidator: %s tag on %s of unsupported type %s", TagName, name, field.Type)
+		}
+		if fv.String() == "" {
+			// Presence is the job of the required binding tag
+			continue
+		}
+
+		rule, err := v.rules.Rule(strings.TrimSpace(v.params + " " + params))
+		if err != nil {
+			return fmt.Errorf("ginvalidator: %s: %w", name, err)
+		}
+		result, ok, reason := rule.Check(fv.String())
+		if !ok {
+			*errs = append(*errs, FieldError{
+				Field:      name,
+				Value:      fv.String(),
+				Errors:     result.Errors,
+				Reason:     reason,
+				Suggestion: result.Suggestion,
+			})
+		}
+	}
+	return nil
+}
+
+// fieldName returns the JSON name of field, as clients know it
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// join appends name to the dotted path
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// FieldError describes why one email field was rejected
+type FieldError struct {
+	Field  string                           `json:"field"`
+	Value  string                           `json:"value"`
+	Errors []emailvalidator.ValidationError `json:"errors,omitempty"`
+	// Reason explains rejections by the norole and notypo checks
+	Reason     emailvalidator.Reason `json:"reason,omitempty"`
+	Suggestion string                `json:"suggestion,omitempty"`
+}
+
+// Error implements error
+func (e FieldError) Error() string {
+	msg := string(e.Reason)
+	if len(e.Errors) > 0 {
+		msg = e.Errors[0].Message
+	}
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s: %s (did you mean %s?)", e.Field, msg, emailvalidator.Redact(e.Suggestion))
+	}
+	return fmt.Sprintf("%s: %s", e.Field, msg)
+}
+
+// ValidationErrors lists the rejected email fields of one struct
+type ValidationErrors []FieldError
+
+// Error implements error
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
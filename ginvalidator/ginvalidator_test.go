@@ -0,0 +1,107 @@
+package ginvalidator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+type contact struct {
+	Email string `json:"email" email:"norole"`
+}
+
+type signup struct {
+	Email    string    `json:"email" email:""`
+	Backup   *string   `json:"backup_email" email:"nodisposable"`
+	Contacts []contact `json:"contacts"`
+	Name     string    `json:"name"`
+}
+
+// fallback records calls and fails when asked to
+type fallback struct {
+	calls int
+	err   error
+}
+
+func (f *fallback) ValidateStruct(any) error { f.calls++; return f.err }
+func (f *fallback) Engine() any              { return "engine" }
+
+func TestValidateStruct(t *testing.T) {
+	next := &fallback{}
+	v := New(WithFallback(next))
+
+	backup := "jane@mailinator.com"
+	err := v.ValidateStruct(&signup{
+		Email:    "jane@gmial.com",
+		Backup:   &backup,
+		Contacts: []contact{{Email: "bob@example.com"}, {Email: "admin@example.com"}},
+	})
+	if next.calls != 1 || v.Engine() != "engine" {
+		t.Error("expected the fallback to run and provide the engine")
+	}
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected 2 field errors, got %v", err)
+	}
+	if errs[0].Field != "backup_email" || errs[1].Field != "contacts[1].email" || errs[1].Reason != emailvalidator.ReasonRoleAccount {
+		t.Errorf("unexpected field errors %+v", errs)
+	}
+
+	// A typo only fails with notypo, and its error carries the suggestion
+	err = v.With(WithRule("notypo")).ValidateStruct(&signup{Email: "jane@gmial.com"})
+	if err == nil || !strings.Contains(err.Error(), "did you mean jane@gmail.com?") {
+		t.Errorf("expected a typo suggestion in %v", err)
+	}
+}
+
+func TestSuggestionPrivacyMode(t *testing.T) {
+	emailvalidator.SetPrivacyMode(true)
+	defer emailvalidator.SetPrivacyMode(false)
+
+	err := New(WithRule("notypo")).ValidateStruct(&signup{Email: "jane@gmial.com"})
+	if err == nil || strings.Contains(err.Error(), "jane") || !strings.Contains(err.Error(), "j***e@g***l.com") {
+		t.Errorf("expected a masked suggestion in %v", err)
+	}
+}
+
+func TestValidateStructFallbackError(t *testing.T) {
+	next := &fallback{err: errors.New("name is required")}
+	if err := New(WithFallback(next)).ValidateStruct(&signup{Email: "bad"}); err != next.err {
+		t.Errorf("expected the fallback error first, got %v", err)
+	}
+}
+
+// binder stands in for *gin.Context
+type binder struct{ email string }
+
+func (b binder) ShouldBind(obj any) error {
+	obj.(*signup).Email = b.email
+	return nil
+}
+
+func TestBindRoute(t *testing.T) {
+	route := New().With(WithRule("nodisposable"))
+
+	var req signup
+	if err := route.Bind(binder{"jane@example.com"}, &req); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := route.Bind(binder{"jane@mailinator.com"}, &req); err == nil {
+		t.Error("expected the route rule to reject a disposable address")
+	}
+	if err := New().ValidateStruct(&req); err != nil {
+		t.Errorf("route options leaked into the base validator: %v", err)
+	}
+}
+
+func TestValidateStructUnknownParam(t *testing.T) {
+	type bad struct {
+		Email string `email:"nosuch"`
+	}
+	if err := New().ValidateStruct(bad{Email: "jane@example.com"}); err == nil || errors.As(err, new(ValidationErrors)) {
+		t.Errorf("expected a configuration error, got %v", err)
+	}
+}
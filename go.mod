@@ -1,7 +1,3 @@
-module yourmodule/emailvalidator
+module github.com/LBFmuraiybatu/email_validator
 
 go 1.21
-
-require (
-    // No external dependencies for basic functionality
-)
\ No newline at end of file
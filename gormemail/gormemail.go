@@ -0,0 +1,104 @@
+// Package gormemail provides a GORM model field that keeps an address as
+// entered alongside its canonical form:
+//
+//	type User struct {
+//		ID    uint
+//		Email gormemail.Email `gorm:"embedded;embeddedPrefix:email_"`
+//	}
+//
+//	func (u *User) BeforeSave(tx *gorm.DB) error {
+//		return u.Email.Prepare()
+//	}
+//
+// The BeforeSave hook validates the address on create and update and fills
+// in Canonical. Without it, Address still refuses to write an invalid value,
+// but Canonical is not kept up to date.
+package gormemail
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// Validator checks the addresses written by this package. Replace it during
+// initialization to apply other options.
+var Validator emailvalidator.Validator = emailvalidator.New()
+
+// Address is an email column validated before it is written
+type Address string
+
+// Value implements driver.Valuer, rejecting invalid addresses. Empty
+// addresses are written as is; use a not null constraint to forbid them.
+func (a Address) Value() (driver.Value, error) {
+	if err := check(string(a)); err != nil {
+		return nil, err
+	}
+	return string(a), nil
+}
+
+// Scan implements sql.Scanner
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = ""
+	case string:
+		*a = Address(v)
+	case []byte:
+		*a = Address(v)
+	default:
+		return fmt.Errorf("gormemail: cannot scan %T into Address", src)
+	}
+	return nil
+}
+
+// GormDataType reports the generic column type to GORM
+func (Address) GormDataType() string {
+	return "string"
+}
+
+// Email stores an address as entered and its canonical form, which is the
+// one to index and query by
+type Email struct {
+	Address   Address `gorm:"size:254"`
+	Canonical string  `gorm:"size:254;index"`
+}
+
+// New validates address, trimmed of surrounding space, and returns it as
+// an Email
+func New(address string) (Email, error) {
+	e := Email{Address: Address(strings.TrimSpace(address))}
+	return e, e.Prepare()
+}
+
+// Prepare validates Address and recomputes Canonical. Call it from the
+// model's BeforeSave hook.
+func (e *Email) Prepare() error {
+	if err := check(string(e.Address)); err != nil {
+		return err
+	}
+	e.Canonical = emailvalidator.Canonical(string(e.Address))
+	return nil
+}
+
+// String returns the address as entered
+func (e Email) String() string {
+	return string(e.Address)
+}
+
+// check validates a non-empty address with Validator
+func check(address string) error {
+	if address == "" {
+		return nil
+	}
+	result := Validator.Validate(address)
+	if result.IsValid {
+		return nil
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("gormemail: %q: %w", emailvalidator.Redact(address), result.Errors[0])
+	}
+	return fmt.Errorf("gormemail: %q is not a valid email address", emailvalidator.Redact(address))
+}
@@ -0,0 +1,57 @@
+package gormemail
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestEmailPrepare(t *testing.T) {
+	e, err := New("  Jane.Doe@Example.COM ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Address != "Jane.Doe@Example.COM" || e.Canonical != "jane.doe@example.com" {
+		t.Errorf("unexpected email %+v", e)
+	}
+
+	e.Address = "jane@@example.com"
+	if err := e.Prepare(); !errors.Is(err, emailvalidator.ErrInvalidFormat) {
+		t.Errorf("expected ErrInvalidFormat, got %v", err)
+	}
+	if e.Canonical != "jane.doe@example.com" {
+		t.Error("a failed Prepare should leave Canonical unchanged")
+	}
+}
+
+func TestAddressValuer(t *testing.T) {
+	if v, err := Address("jane@example.com").Value(); err != nil || v != "jane@example.com" {
+		t.Errorf("unexpected value %v, %v", v, err)
+	}
+	if _, err := Address("not-an-email").Value(); err == nil {
+		t.Error("expected an invalid address to be refused")
+	}
+	if v, err := Address("").Value(); err != nil || v != "" {
+		t.Errorf("expected empty addresses to pass through, got %v, %v", v, err)
+	}
+
+	var a Address
+	if err := a.Scan([]byte("jane@example.com")); err != nil || a != "jane@example.com" {
+		t.Errorf("unexpected scan %q, %v", a, err)
+	}
+	if err := a.Scan(42); err == nil {
+		t.Error("expected an error scanning an integer")
+	}
+}
+
+func TestPrepareErrorPrivacyMode(t *testing.T) {
+	emailvalidator.SetPrivacyMode(true)
+	defer emailvalidator.SetPrivacyMode(false)
+
+	e := Email{Address: "jane@@example.com"}
+	if err := e.Prepare(); err == nil || strings.Contains(err.Error(), "jane") {
+		t.Errorf("error %v", err)
+	}
+}
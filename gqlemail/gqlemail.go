@@ -0,0 +1,112 @@
+// Package gqlemail provides an Email GraphQL scalar that validates
+// addresses during input coercion. With gqlgen, declare the scalar in the
+// schema and bind it in gqlgen.yml:
+//
+//	scalar Email
+//
+//	models:
+//	  Email:
+//	    model: github.com/LBFmuraiybatu/email_validator/gqlemail.Email
+//
+// Invalid input fails with an *Error, whose extensions carry the
+// validator's error codes and any typo suggestion:
+//
+//	{
+//	  "message": "jane@gmial: domain must have at least two parts",
+//	  "extensions": {
+//	    "code": "INVALID_EMAIL",
+//	    "errors": [{"code": "domain_too_few_labels", "field": "domain", ...}]
+//	  }
+//	}
+package gqlemail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// Code is the extensions code of errors for invalid addresses
+const Code = "INVALID_EMAIL"
+
+// Validator checks the addresses coerced by this package. Replace it during
+// initialization to apply other options.
+var Validator emailvalidator.Validator = emailvalidator.New()
+
+// Email is a validated address
+type Email string
+
+// Parse validates address, trimmed of surrounding space, for resolvers
+// taking it as a plain String argument
+func Parse(address string) (Email, error) {
+	address = strings.TrimSpace(address)
+	result := Validator.Validate(address)
+	if !result.IsValid {
+		return "", &Error{Address: address, Errors: result.Errors, Suggestion: result.Suggestion}
+	}
+	return Email(address), nil
+}
+
+// UnmarshalGQL implements gqlgen's graphql.Unmarshaler
+func (e *Email) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Email must be a string, got %T", v)
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
+
+// MarshalGQL implements gqlgen's graphql.Marshaler
+func (e Email) MarshalGQL(w io.Writer) {
+	data, _ := json.Marshal(string(e))
+	w.Write(data)
+}
+
+// String returns the address
+func (e Email) String() string {
+	return string(e)
+}
+
+// Error reports an invalid address. It implements gqlgen's
+// graphql.ExtendedError, so the default error presenter copies its
+// extensions into the GraphQL error.
+type Error struct {
+	Address    string
+	Errors     []emailvalidator.ValidationError
+	Suggestion string
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("%s: %s", emailvalidator.Redact(e.Address), e.Errors[0].Message)
+	}
+	return fmt.Sprintf("%s is not a valid email address", emailvalidator.Redact(e.Address))
+}
+
+// Unwrap returns the validation errors, so that errors.Is matches the
+// validator's sentinel errors
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Extensions implements graphql.ExtendedError
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": Code, "errors": e.Errors}
+	if e.Suggestion != "" {
+		ext["suggestion"] = emailvalidator.Redact(e.Suggestion)
+	}
+	return ext
+}
@@ -0,0 +1,59 @@
+package gqlemail
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestUnmarshalGQL(t *testing.T) {
+	var e Email
+	if err := e.UnmarshalGQL(" jane@example.com "); err != nil || e != "jane@example.com" {
+		t.Fatalf("got %q, %v", e, err)
+	}
+
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	if buf.String() != `"jane@example.com"` {
+		t.Errorf("marshalled %s", buf.String())
+	}
+
+	if err := e.UnmarshalGQL(42); err == nil {
+		t.Error("accepted a number")
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	var e Email
+	err := e.UnmarshalGQL("jane@gmial")
+
+	var gqlErr *Error
+	if !errors.As(err, &gqlErr) || e != "" {
+		t.Fatalf("got %q, %v", e, err)
+	}
+	if !errors.Is(err, emailvalidator.ErrInvalidDomain) {
+		t.Errorf("%v does not match ErrInvalidDomain", err)
+	}
+	ext := gqlErr.Extensions()
+	errs, _ := ext["errors"].([]emailvalidator.ValidationError)
+	if ext["code"] != Code || len(errs) == 0 || errs[0].Code != emailvalidator.CodeDomainTooFewLabels {
+		t.Errorf("extensions %v", ext)
+	}
+	if err.Error() != "jane@gmial: domain must have at least two parts" {
+		t.Errorf("message %q", err.Error())
+	}
+}
+
+func TestInvalidPrivacyMode(t *testing.T) {
+	emailvalidator.SetPrivacyMode(true)
+	defer emailvalidator.SetPrivacyMode(false)
+
+	var e Email
+	err := e.UnmarshalGQL("jane@gmial")
+	if err == nil || strings.Contains(err.Error(), "jane") {
+		t.Errorf("message %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package emailvalidator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultGravatarURL is the base URL of the Gravatar avatar endpoint
+const DefaultGravatarURL = "https://gravatar.com/avatar/"
+
+// GravatarChecker looks up whether an address has a Gravatar avatar, a
+// cheap signal that the mailbox belongs to a real person. A checker is
+// safe for concurrent use; its With methods return modified copies.
+type GravatarChecker struct {
+	client  *http.Client
+	baseURL string
+	timeout time.Duration
+}
+
+// NewGravatarChecker creates a GravatarChecker querying gravatar.com
+func NewGravatarChecker() *GravatarChecker {
+	return &GravatarChecker{
+		client:  http.DefaultClient,
+		baseURL: DefaultGravatarURL,
+		timeout: 3 * time.Second,
+	}
+}
+
+// WithClient returns a copy of the checker sending requests with client
+func (g *GravatarChecker) WithClient(client *http.Client) *GravatarChecker {
+	c := *g
+	c.client = client
+	return &c
+}
+
+// WithBaseURL returns a copy of the checker querying baseURL, to which the
+// hash of the address is appended, instead of DefaultGravatarURL
+func (g *GravatarChecker) WithBaseURL(baseURL string) *GravatarChecker {
+	c := *g
+	c.baseURL = baseURL
+	return &c
+}
+
+// WithTimeout returns a copy of the checker with the given request timeout
+func (g *GravatarChecker) WithTimeout(timeout time.Duration) *GravatarChecker {
+	c := *g
+	c.timeout = timeout
+	return &c
+}
+
+// HasGravatar reports whether email has an avatar. Only the SHA-256 hash
+// of the canonical address is sent.
+func (g *GravatarChecker) HasGravatar(ctx context.Context, email string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	sum := sha256.Sum256([]byte(Canonical(email)))
+	url := strings.TrimSuffix(g.baseURL, "/") + "/" + hex.EncodeToString(sum[:]) + "?d=404"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("gravatar: %v", err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("gravatar: unexpected status %s", resp.Status)
+}
+
+// checkGravatar records whether the address has an avatar, leaving
+// HasGravatar nil when the lookup fails
+func (v *EmailValidator) checkGravatar(ctx context.Context, email string, result *ValidationResult) {
+	has, err := v.gravatarChecker.HasGravatar(ctx, email)
+	if err == nil {
+		result.HasGravatar = &has
+	}
+}
@@ -0,0 +1,53 @@
+package emailvalidator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGravatarCheck(t *testing.T) {
+	sum := sha256.Sum256([]byte("jane@example.com"))
+	known := "/" + hex.EncodeToString(sum[:])
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch {
+		case r.Method != http.MethodHead || r.URL.Query().Get("d") != "404":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == known:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	v := New(WithGravatarCheck(NewGravatarChecker().WithBaseURL(srv.URL)))
+
+	// The address is canonicalized before hashing
+	if r := v.Validate("Jane@Example.com"); r.HasGravatar == nil || !*r.HasGravatar {
+		t.Fatalf("known address: %v", r.HasGravatar)
+	}
+	if r := v.Validate("nobody@example.com"); r.HasGravatar == nil || *r.HasGravatar {
+		t.Fatalf("unknown address: %v", r.HasGravatar)
+	}
+
+	// Invalid addresses are not looked up
+	before := requests.Load()
+	if r := v.Validate("not-an-address"); r.HasGravatar != nil || requests.Load() != before {
+		t.Fatal("invalid address looked up")
+	}
+
+	// Failed lookups leave the outcome unknown
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	v = v.With(WithGravatarCheck(NewGravatarChecker().WithBaseURL(down.URL)))
+	if r := v.Validate("jane@example.com"); !r.IsValid || r.HasGravatar != nil {
+		t.Fatalf("failed lookup: %v %v", r.IsValid, r.HasGravatar)
+	}
+}
@@ -0,0 +1,19 @@
+// Package grpcvalidate serves the EmailValidator gRPC service defined in
+// proto/emailvalidator/v1/validator.proto.
+//
+// The server depends on google.golang.org/grpc and on code generated from
+// the schema, so it is built only with the grpc build tag:
+//
+//	go generate ./grpcvalidate
+//	go build -tags grpc ./...
+//
+// The generated code is not committed; the grpc workflow in .github
+// generates it and runs the tests with the tag.
+//
+// Register it on a gRPC server:
+//
+//	s := grpc.NewServer()
+//	validatorv1.RegisterEmailValidatorServer(s, grpcvalidate.NewServer(emailvalidator.New()))
+package grpcvalidate
+
+//go:generate protoc -I ../proto --go_out=../proto --go_opt=paths=source_relative --go-grpc_out=../proto --go-grpc_opt=paths=source_relative emailvalidator/v1/validator.proto
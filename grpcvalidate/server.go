@@ -0,0 +1,248 @@
+//go:build grpc
+
+package grpcvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	validatorv1 "github.com/LBFmuraiybatu/email_validator/proto/emailvalidator/v1"
+)
+
+// MaxBatchSize bounds the number of addresses in one ValidateBatch call
+const MaxBatchSize = 1000
+
+// Server implements validatorv1.EmailValidatorServer
+type Server struct {
+	validatorv1.UnimplementedEmailValidatorServer
+	validator emailvalidator.Validator
+}
+
+// NewServer creates a Server validating with v
+func NewServer(v emailvalidator.Validator) *Server {
+	return &Server{validator: v}
+}
+
+// Validate validates one address
+func (s *Server) Validate(ctx context.Context, req *validatorv1.ValidateRequest) (*validatorv1.ValidateResponse, error) {
+	return toResponse(s.validate(ctx, req.GetEmail())), nil
+}
+
+// ValidateBatch validates the addresses of req in order
+func (s *Server) ValidateBatch(ctx context.Context, req *validatorv1.ValidateBatchRequest) (*validatorv1.ValidateBatchResponse, error) {
+	emails := req.GetEmails()
+	if len(emails) > MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d addresses exceeds the limit of %d", len(emails), MaxBatchSize)
+	}
+
+	resp := &validatorv1.ValidateBatchResponse{Results: make([]*validatorv1.ValidateResponse, len(emails))}
+	for i, email := range emails {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		resp.Results[i] = toResponse(s.validate(ctx, email))
+	}
+	return resp, nil
+}
+
+// validate validates email, cancelling lookups with ctx when supported
+func (s *Server) validate(ctx context.Context, email string) emailvalidator.ValidationResult {
+	if cv, ok := s.validator.(emailvalidator.ContextValidator); ok {
+		return cv.ValidateContext(ctx, email)
+	}
+	return s.validator.Validate(email)
+}
+
+var verdicts = map[emailvalidator.Verdict]validatorv1.Verdict{
+	emailvalidator.VerdictValid:   validatorv1.Verdict_VERDICT_VALID,
+	emailvalidator.VerdictRisky:   validatorv1.Verdict_VERDICT_RISKY,
+	emailvalidator.VerdictInvalid: validatorv1.Verdict_VERDICT_INVALID,
+	emailvalidator.VerdictUnknown: validatorv1.Verdict_VERDICT_UNKNOWN,
+}
+
+// toResponse converts a result to its protobuf form
+func toResponse(r emailvalidator.ValidationResult) *validatorv1.ValidateResponse {
+	return &validatorv1.ValidateResponse{
+		SchemaVersion: emailvalidator.ResultSchemaVersion,
+		IsValid:       r.IsValid,
+		Errors:        toErrors(r.Errors),
+		Warnings:      toWarnings(r.Warnings),
+		Normalized:    r.Normalized,
+		Domain:        r.Domain,
+		Username:      r.Username,
+		Suggestion:    r.Suggestion,
+		Score:         r.Score,
+		Verdict:       verdicts[r.Verdict],
+		Syntax:        toCheck(r.Syntax),
+		Dns:           toCheck(r.DNS),
+		Reputation:    toCheck(r.Reputation),
+		Suggestions:   toCheck(r.Suggestions),
+
+		HasGravatar:      r.HasGravatar,
+		Breached:         r.Breached,
+		Breaches:         r.Breaches,
+		Reasons:          toReasons(r.Reasons()),
+		DomainReputation: toDomainReputation(r.DomainReputation),
+		Organization:     toOrganization(r.Organization),
+		MailHosting:      toMailHosting(r.MailHosting),
+		AcceptAll:        r.AcceptAll,
+		PrivacyRelay:     r.PrivacyRelay,
+		AlumniForwarder:  r.AlumniForwarder,
+		LikelyDisposable: r.LikelyDisposable,
+		TldRisk:          r.TLDRisk,
+		LocalPart:        toLocalPart(r.LocalPart),
+		Name:             toName(r.Name),
+		PersonalName:     r.PersonalName,
+	}
+}
+
+func toReasons(reasons []emailvalidator.Reason) []string {
+	if len(reasons) == 0 {
+		return nil
+	}
+	out := make([]string, len(reasons))
+	for i, reason := range reasons {
+		out[i] = string(reason)
+	}
+	return out
+}
+
+func toDomainReputation(d *emailvalidator.DomainReputation) *validatorv1.DomainReputation {
+	if d == nil {
+		return nil
+	}
+	out := &validatorv1.DomainReputation{
+		Score:      int32(d.Score),
+		Listings:   d.Listings,
+		Mx:         d.MX,
+		Spf:        d.SPF,
+		Dmarc:      d.DMARC,
+		Wildcard:   d.Wildcard,
+		Incomplete: d.Incomplete,
+	}
+	if d.Registered != nil {
+		out.Registered = d.Registered.Format(time.RFC3339)
+	}
+	return out
+}
+
+func toOrganization(o *emailvalidator.Organization) *validatorv1.Organization {
+	if o == nil {
+		return nil
+	}
+	return &validatorv1.Organization{
+		Name:     o.Name,
+		Domain:   o.Domain,
+		Type:     string(o.Type),
+		Provider: o.Provider,
+	}
+}
+
+func toMailHosting(m *emailvalidator.MailHosting) *validatorv1.MailHosting {
+	if m == nil {
+		return nil
+	}
+	out := &validatorv1.MailHosting{
+		Hosts:     make([]*validatorv1.MailHost, len(m.Hosts)),
+		Countries: m.Countries,
+	}
+	for i, h := range m.Hosts {
+		out.Hosts[i] = &validatorv1.MailHost{
+			Host:    h.Host,
+			Ip:      h.IP,
+			Asn:     h.ASN,
+			AsOrg:   h.ASOrg,
+			Country: h.Country,
+		}
+	}
+	return out
+}
+
+func toLocalPart(l *emailvalidator.LocalPartAnalysis) *validatorv1.LocalPartAnalysis {
+	if l == nil {
+		return nil
+	}
+	out := &validatorv1.LocalPartAnalysis{
+		Length:     int32(l.Length),
+		DigitRatio: l.DigitRatio,
+		Entropy:    l.Entropy,
+	}
+	if len(l.Separators) > 0 {
+		out.Separators = make(map[string]int32, len(l.Separators))
+		for sep, n := range l.Separators {
+			out.Separators[sep] = int32(n)
+		}
+	}
+	return out
+}
+
+func toName(n *emailvalidator.PersonName) *validatorv1.PersonName {
+	if n == nil {
+		return nil
+	}
+	return &validatorv1.PersonName{
+		First:      n.First,
+		Last:       n.Last,
+		Confidence: n.Confidence,
+	}
+}
+
+func toCheck(c emailvalidator.CheckResult) *validatorv1.CheckResult {
+	return &validatorv1.CheckResult{
+		Status:     string(c.Status),
+		DurationNs: int64(c.Duration),
+		Errors:     toErrors(c.Errors),
+		Warnings:   toWarnings(c.Warnings),
+	}
+}
+
+func toErrors(errs []emailvalidator.ValidationError) []*validatorv1.ValidationError {
+	out := make([]*validatorv1.ValidationError, len(errs))
+	for i, e := range errs {
+		out[i] = &validatorv1.ValidationError{
+			Code:      string(e.Code),
+			Field:     e.Field,
+			Message:   e.Message,
+			Params:    toParams(e.Params),
+			Offset:    int32(e.Offset),
+			Substring: e.Substring,
+			Rule:      e.Rule,
+		}
+	}
+	return out
+}
+
+func toWarnings(warnings []emailvalidator.Warning) []*validatorv1.Warning {
+	out := make([]*validatorv1.Warning, len(warnings))
+	for i, w := range warnings {
+		out[i] = &validatorv1.Warning{
+			Code:     string(w.Code),
+			Severity: validatorv1.Severity(w.Severity),
+			Message:  w.Message,
+			Params:   toParams(w.Params),
+		}
+	}
+	return out
+}
+
+// toParams encodes each parameter value as JSON
+func toParams(params map[string]interface{}) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(params))
+	for key, value := range params {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf("%q", fmt.Sprint(value)))
+		}
+		out[key] = string(encoded)
+	}
+	return out
+}
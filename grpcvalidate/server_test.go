@@ -0,0 +1,98 @@
+//go:build grpc
+
+package grpcvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	validatorv1 "github.com/LBFmuraiybatu/email_validator/proto/emailvalidator/v1"
+)
+
+// fullResult sets every optional field of a result, so that its JSON
+// encoding has every key of the schema
+func fullResult() emailvalidator.ValidationResult {
+	yes := true
+	registered := time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)
+	return emailvalidator.ValidationResult{
+		IsValid:          true,
+		Warnings:         []emailvalidator.Warning{{Code: emailvalidator.WarnRoleAccount, Severity: emailvalidator.SeverityInfo}},
+		Errors:           []emailvalidator.ValidationError{{Code: emailvalidator.CodeDisposable}},
+		Normalized:       "jane.doe@example.com",
+		Domain:           "example.com",
+		Username:         "jane.doe",
+		Suggestion:       "jane.doe@example.org",
+		Score:            90,
+		Verdict:          emailvalidator.VerdictValid,
+		HasGravatar:      &yes,
+		Breached:         &yes,
+		Breaches:         []string{"Example"},
+		DomainReputation: &emailvalidator.DomainReputation{Score: 80, Listings: []string{"dbl"}, Registered: &registered, MX: true},
+		Organization:     &emailvalidator.Organization{Name: "Example", Domain: "example.com"},
+		MailHosting:      &emailvalidator.MailHosting{Hosts: []emailvalidator.MailHost{{Host: "mx.example.com", IP: "192.0.2.1"}}},
+		AcceptAll:        true,
+		PrivacyRelay:     "apple",
+		AlumniForwarder:  true,
+		LikelyDisposable: 0.5,
+		TLDRisk:          0.1,
+		LocalPart:        &emailvalidator.LocalPartAnalysis{Length: 8, Separators: map[string]int{".": 1}},
+		Name:             &emailvalidator.PersonName{First: "Jane", Last: "Doe", Confidence: 0.8},
+		PersonalName:     &yes,
+	}
+}
+
+func TestResponseMirrorsResultSchema(t *testing.T) {
+	data, err := json.Marshal(fullResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := (&validatorv1.ValidateResponse{}).ProtoReflect().Descriptor().Fields()
+	for key := range keys {
+		if fields.ByName(protoreflect.Name(key)) == nil {
+			t.Errorf("ValidateResponse has no field for the JSON key %q", key)
+		}
+	}
+}
+
+func TestToResponse(t *testing.T) {
+	resp := toResponse(fullResult())
+	if resp.GetSchemaVersion() != emailvalidator.ResultSchemaVersion {
+		t.Errorf("schema version %q", resp.GetSchemaVersion())
+	}
+	if !resp.GetHasGravatar() || resp.GetDomainReputation().GetRegistered() != "2001-02-03T00:00:00Z" || resp.GetName().GetFirst() != "Jane" {
+		t.Errorf("enrichments not converted: %v", resp)
+	}
+	if got := resp.GetWarnings()[0].GetSeverity(); got != validatorv1.Severity_SEVERITY_INFO {
+		t.Errorf("severity %v", got)
+	}
+	if len(resp.GetReasons()) == 0 {
+		t.Error("reasons missing")
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	s := NewServer(emailvalidator.New())
+	resp, err := s.ValidateBatch(context.Background(), &validatorv1.ValidateBatchRequest{Emails: []string{"jane@example.com", "nope"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetResults()) != 2 || !resp.GetResults()[0].GetIsValid() || resp.GetResults()[1].GetIsValid() {
+		t.Errorf("unexpected results: %v", resp.GetResults())
+	}
+
+	_, err = s.ValidateBatch(context.Background(), &validatorv1.ValidateBatchRequest{Emails: make([]string, MaxBatchSize+1)})
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit") {
+		t.Errorf("expected the batch limit error, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IPInfo is the network and location of an IP address
+type IPInfo struct {
+	ASN     uint32 `json:"asn,omitempty"`
+	ASOrg   string `json:"as_org,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// IPDatabase maps IP addresses to their network and location. IPRanges
+// implements it; a GeoIP2 or other database can be adapted in a few lines.
+// Implementations must be safe for concurrent use.
+type IPDatabase interface {
+	LookupIP(ip netip.Addr) (IPInfo, bool)
+}
+
+// IPRanges is an in-memory IPDatabase of address ranges
+type IPRanges struct {
+	ranges []ipRange
+}
+
+type ipRange struct {
+	start, end netip.Addr
+	info       IPInfo
+}
+
+// LoadIPRanges reads an IPRanges from the file at path in the ip2asn TSV
+// format of iptoasn.com
+func LoadIPRanges(path string) (*IPRanges, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	db, err := ReadIPRanges(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return db, nil
+}
+
+// ReadIPRanges reads an IPRanges in the ip2asn TSV format, with one range
+// per line: first address, last address, AS number, country code and AS
+// description. Unrouted ranges, with AS number 0, are skipped.
+func ReadIPRanges(r io.Reader) (*IPRanges, error) {
+	db := &IPRanges{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 5 {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: want 5 tab-separated fields, got %d", line, len(fields))
+		}
+		start, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		end, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		asn, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid AS number %q", line, fields[2])
+		}
+		if asn == 0 {
+			continue
+		}
+		country := fields[3]
+		if country == "None" {
+			country = ""
+		}
+		db.ranges = append(db.ranges, ipRange{start: start, end: end, info: IPInfo{ASN: uint32(asn), ASOrg: fields[4], Country: country}})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return db.ranges[i].start.Less(db.ranges[j].start)
+	})
+	return db, nil
+}
+
+// LookupIP implements IPDatabase
+func (db *IPRanges) LookupIP(ip netip.Addr) (IPInfo, bool) {
+	ip = ip.Unmap()
+	// The last range starting at or before ip
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return ip.Less(db.ranges[i].start)
+	}) - 1
+	if i < 0 || db.ranges[i].end.Less(ip) {
+		return IPInfo{}, false
+	}
+	return db.ranges[i].info, true
+}
+
+// MailHost is one address of an MX host and where it is located
+type MailHost struct {
+	Host string `json:"host"`
+	IP   string `json:"ip"`
+	IPInfo
+}
+
+// MailHosting reports where the mail of a domain is hosted
+type MailHosting struct {
+	Hosts []MailHost `json:"hosts"`
+	// Countries are the distinct countries of the hosts
+	Countries []string `json:"countries,omitempty"`
+}
+
+// MailHostingLocator locates the MX hosts of domains with an IPDatabase.
+// Findings are cached per domain. A locator is safe for concurrent use.
+type MailHostingLocator struct {
+	db       IPDatabase
+	resolver Resolver
+	timeout  time.Duration
+	cache    *ttlCache[string, MailHosting]
+}
+
+// NewMailHostingLocator creates a MailHostingLocator looking up addresses
+// in db with the default resolver
+func NewMailHostingLocator(db IPDatabase) *MailHostingLocator {
+	return &MailHostingLocator{
+		db:       db,
+		resolver: net.DefaultResolver,
+		timeout:  5 * time.Second,
+		cache:    newTTLCache[string, MailHosting](10000, 6*time.Hour),
+	}
+}
+
+// WithResolver returns a copy of the locator using resolver for lookups
+func (l *MailHostingLocator) WithResolver(resolver Resolver) *MailHostingLocator {
+	c := *l
+	c.resolver = resolver
+	return &c
+}
+
+// Locate resolves the MX hosts of domain, or the domain itself when it has
+// none, and looks up their addresses
+func (l *MailHostingLocator) Locate(ctx context.Context, domain string) (MailHosting, error) {
+	domain = strings.ToLower(domain)
+	if hosting, ok := l.cache.get(domain); ok {
+		return hosting, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	hosts := []string{domain}
+	mx, err := l.resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return MailHosting{}, wrapLookupError(err)
+	}
+	if len(mx) > 0 {
+		hosts = hosts[:0]
+		for _, record := range mx {
+			hosts = append(hosts, strings.TrimSuffix(record.Host, "."))
+		}
+	}
+
+	var hosting MailHosting
+	seen := make(map[string]bool)
+	for _, host := range hosts {
+		addrs, err := l.resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return MailHosting{}, wrapLookupError(err)
+		}
+		for _, addr := range addrs {
+			ip, ok := netip.AddrFromSlice(addr.IP)
+			if !ok {
+				continue
+			}
+			h := MailHost{Host: host, IP: ip.Unmap().String()}
+			if info, ok := l.db.LookupIP(ip); ok {
+				h.IPInfo = info
+				if h.Country != "" && !seen[h.Country] {
+					seen[h.Country] = true
+					hosting.Countries = append(hosting.Countries, h.Country)
+				}
+			}
+			hosting.Hosts = append(hosting.Hosts, h)
+		}
+	}
+	sort.Strings(hosting.Countries)
+	l.cache.add(domain, hosting)
+	return hosting, nil
+}
+
+// CacheStats implements CacheMetrics for the cache of mail hostings
+func (l *MailHostingLocator) CacheStats() CacheStats {
+	return l.cache.stats()
+}
+
+// checkMailHosting records where the mail of the domain is hosted, leaving
+// MailHosting nil when the lookups fail
+func (v *EmailValidator) checkMailHosting(ctx context.Context, domain string, result *ValidationResult) {
+	hosting, err := v.hostingLocator.Locate(ctx, domain)
+	if err == nil {
+		result.MailHosting = &hosting
+	}
+}
@@ -0,0 +1,55 @@
+package emailvalidator
+
+import (
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const ip2asn = "1.0.0.0\t1.0.0.255\t13335\tUS\tCLOUDFLARENET\n" +
+	"192.0.2.0\t192.0.2.255\t64500\tDE\tEXAMPLE-AS\n" +
+	"198.51.100.0\t198.51.100.255\t0\tNone\tNot routed\n" +
+	"2001:db8::\t2001:db8::ffff\t64501\tFR\tEXAMPLE-V6\n"
+
+func TestIPRanges(t *testing.T) {
+	db, err := ReadIPRanges(strings.NewReader(ip2asn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ip   string
+		want IPInfo
+		ok   bool
+	}{
+		{"192.0.2.77", IPInfo{ASN: 64500, ASOrg: "EXAMPLE-AS", Country: "DE"}, true},
+		{"::ffff:1.0.0.1", IPInfo{ASN: 13335, ASOrg: "CLOUDFLARENET", Country: "US"}, true},
+		{"2001:db8::1", IPInfo{ASN: 64501, ASOrg: "EXAMPLE-V6", Country: "FR"}, true},
+		{"198.51.100.1", IPInfo{}, false},
+		{"1.0.1.0", IPInfo{}, false},
+		{"0.0.0.1", IPInfo{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := db.LookupIP(netip.MustParseAddr(tt.ip))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: got %+v %v", tt.ip, got, ok)
+		}
+	}
+
+	if _, err := ReadIPRanges(strings.NewReader("1.0.0.0\t1.0.0.255\n")); err == nil {
+		t.Error("expected an error for a short line")
+	}
+}
+
+func TestWithMailHosting(t *testing.T) {
+	db, _ := ReadIPRanges(strings.NewReader(ip2asn))
+	v := New(WithMailHosting(NewMailHostingLocator(db).WithResolver(staticResolver{})))
+	r := v.Validate("jane@example.com")
+	want := &MailHosting{
+		Hosts:     []MailHost{{Host: "mx.example.com", IP: "192.0.2.1", IPInfo: IPInfo{ASN: 64500, ASOrg: "EXAMPLE-AS", Country: "DE"}}},
+		Countries: []string{"DE"},
+	}
+	if !reflect.DeepEqual(r.MailHosting, want) {
+		t.Fatalf("got %+v", r.MailHosting)
+	}
+}
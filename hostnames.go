@@ -0,0 +1,32 @@
+package emailvalidator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// looseFormatPattern is formatPattern also accepting underscores in
+// domain labels
+var looseFormatPattern = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@` + looseDomainPattern + `$`)
+
+// looseUTF8FormatPattern is utf8FormatPattern also accepting underscores
+// in domain labels
+var looseUTF8FormatPattern = regexp.MustCompile(`^[\p{L}\p{M}\p{N}a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@` + looseDomainPattern + `$`)
+
+const looseDomainPattern = `[a-zA-Z0-9_](?:[a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?(?:\.[a-zA-Z0-9_](?:[a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?)*`
+
+// looseHostnames reports whether underscores are accepted in domain labels
+func (v *EmailValidator) looseHostnames() bool {
+	return v.allowUnderscores && !v.strictMode
+}
+
+// checkHostname warns about the underscores of a domain accepted with
+// loose hostnames. Underscores are valid in DNS names but not in host
+// names (RFC 952), so mail servers outside the domain's own network may
+// refuse the address.
+func (v *EmailValidator) checkHostname(s *stage, p ParsedEmail) {
+	if !v.looseHostnames() || strings.IndexByte(p.Domain, '_') < 0 {
+		return
+	}
+	s.addWarning(newWarning(WarnDomainUnderscore, SeverityWarning, "Domain contains an underscore, which not all mail servers accept").withParam("domain", p.Domain))
+}
@@ -0,0 +1,45 @@
+package emailvalidator
+
+import "testing"
+
+func TestLooseHostnames(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     *EmailValidator
+		email string
+		valid bool
+	}{
+		{"default", New(), "jane@mail_relay.corp.example", false},
+		{"loose", New(WithLooseHostnames(true)), "jane@mail_relay.corp.example", true},
+		{"loose label edge", New(WithLooseHostnames(true)), "jane@_dmarc.example.com", true},
+		{"loose utf8", New(WithLooseHostnames(true), WithSMTPUTF8(true)), "jösé@mail_relay.example.com", true},
+		{"loose hyphen", New(WithLooseHostnames(true)), "jane@-relay_1.example.com", false},
+		{"strict", NewStrict(WithLooseHostnames(true)), "jane@mail_relay.corp.example", false},
+	}
+	for _, tt := range tests {
+		r := tt.v.Validate(tt.email)
+		if r.IsValid != tt.valid {
+			t.Errorf("%s: valid %v, errors %v", tt.name, r.IsValid, r.Errors)
+		}
+		if got := tt.v.IsValidSyntax(tt.email); got != tt.valid {
+			t.Errorf("%s: IsValidSyntax = %v", tt.name, got)
+		}
+		warned := false
+		for _, w := range r.Warnings {
+			warned = warned || w.Code == WarnDomainUnderscore
+		}
+		if warned != tt.valid {
+			t.Errorf("%s: domain_underscore warning %v", tt.name, warned)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.LooseHostnames = true
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.IsValidSyntax("jane@mail_relay.example.com") {
+		t.Error("configured loose hostnames not applied")
+	}
+}
@@ -0,0 +1,184 @@
+// Package httpvalidate provides net/http middleware that validates the email
+// fields of incoming requests.
+package httpvalidate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// MaxBodyBytes bounds the size of JSON bodies read by the middleware
+const MaxBodyBytes = 1 << 20
+
+// FieldError describes why one field was rejected
+type FieldError struct {
+	Value      string                           `json:"value"`
+	Errors     []emailvalidator.ValidationError `json:"errors"`
+	Suggestion string                           `json:"suggestion,omitempty"`
+}
+
+// ErrorResponse is the body of 422 responses
+type ErrorResponse struct {
+	Error  string                `json:"error"`
+	Fields map[string]FieldError `json:"fields"`
+}
+
+type contextKey struct{}
+
+// Results returns the validation results of the fields checked for r, keyed
+// by field name. Fields that were absent or empty are not included.
+func Results(r *http.Request) map[string]emailvalidator.ValidationResult {
+	results, _ := r.Context().Value(contextKey{}).(map[string]emailvalidator.ValidationResult)
+	return results
+}
+
+// Middleware validates the named fields of each request with a default
+// emailvalidator.New validator. See MiddlewareFor.
+func Middleware(fields ...string) func(http.Handler) http.Handler {
+	return MiddlewareFor(emailvalidator.New(), fields...)
+}
+
+// MiddlewareFor returns middleware that validates the named fields with v.
+// Fields are read from JSON object bodies, where a dotted name such as
+// "user.email" selects a nested field, or else from form and query values.
+// Absent or empty fields are skipped; requiring them is up to the handler.
+// Requests with an invalid address get a 422 response with an
+// ErrorResponse body; malformed JSON gets a 400. Otherwise the request is
+// passed on with its body intact and the results available from Results.
+func MiddlewareFor(v emailvalidator.Validator, fields ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			checked, err := Check(v, w, r, fields...)
+			if err != nil {
+				writeJSON(w, err.Status, err.Body)
+				return
+			}
+			next.ServeHTTP(w, checked)
+		})
+	}
+}
+
+// Error is a request rejected by Check
+type Error struct {
+	// Status is 422 for invalid addresses and 400 for unreadable bodies
+	Status int
+	// Body is an ErrorResponse for invalid addresses
+	Body interface{}
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if resp, ok := e.Body.(ErrorResponse); ok {
+		names := make([]string, 0, len(resp.Fields))
+		for field := range resp.Fields {
+			names = append(names, field)
+		}
+		sort.Strings(names)
+		return "invalid email in " + strings.Join(names, ", ")
+	}
+	return e.Body.(map[string]string)["error"]
+}
+
+// Check validates the named fields of r with v as MiddlewareFor does, for
+// adapting it to other frameworks. It returns r with the results attached
+// for Results, or an *Error describing the response to send. w may be nil.
+func Check(v emailvalidator.Validator, w http.ResponseWriter, r *http.Request, fields ...string) (*http.Request, *Error) {
+	values, err := fieldValues(w, r, fields)
+	if err != nil {
+		return nil, &Error{Status: http.StatusBadRequest, Body: map[string]string{"error": err.Error()}}
+	}
+
+	results := make(map[string]emailvalidator.ValidationResult, len(values))
+	failed := make(map[string]FieldError)
+	for field, value := range values {
+		result := validate(r.Context(), v, value)
+		results[field] = result
+		if !result.IsValid {
+			failed[field] = FieldError{Value: value, Errors: result.Errors, Suggestion: result.Suggestion}
+		}
+	}
+
+	if len(failed) > 0 {
+		return nil, &Error{Status: http.StatusUnprocessableEntity, Body: ErrorResponse{Error: "invalid_email", Fields: failed}}
+	}
+	return r.WithContext(context.WithValue(r.Context(), contextKey{}, results)), nil
+}
+
+// validate validates email with v, cancelling lookups with ctx when v
+// supports it
+func validate(ctx context.Context, v emailvalidator.Validator, email string) emailvalidator.ValidationResult {
+	if cv, ok := v.(emailvalidator.ContextValidator); ok {
+		return cv.ValidateContext(ctx, email)
+	}
+	return v.Validate(email)
+}
+
+// fieldValues returns the non-empty values of fields in r
+func fieldValues(w http.ResponseWriter, r *http.Request, fields []string) (map[string]string, error) {
+	values := make(map[string]string, len(fields))
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body != nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+		if err != nil {
+			return nil, err
+		}
+		// Let the handler read the body again
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var object map[string]interface{}
+		if err := json.Unmarshal(body, &object); err != nil {
+			return nil, errors.New("request body must be a JSON object")
+		}
+		for _, field := range fields {
+			if value, ok := lookup(object, field).(string); ok && value != "" {
+				values[field] = value
+			}
+		}
+		return values, nil
+	}
+
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+	} else if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if value := r.FormValue(field); value != "" {
+			values[field] = value
+		}
+	}
+	return values, nil
+}
+
+// lookup follows a dotted path through nested JSON objects
+func lookup(object map[string]interface{}, path string) interface{} {
+	for {
+		key, rest, nested := strings.Cut(path, ".")
+		value, ok := object[key]
+		if !ok || !nested {
+			return value
+		}
+		if object, ok = value.(map[string]interface{}); !ok {
+			return nil
+		}
+		path = rest
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,92 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// echo responds with the request body and the validated normalized address
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("X-Normalized", Results(r)["email"].Normalized)
+	w.Write(body)
+})
+
+func TestMiddlewareJSON(t *testing.T) {
+	handler := Middleware("email", "contact.email")(echo)
+
+	body := `{"email":"Jane@Example.com","contact":{"email":"jane@example.org"}}`
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Fatalf("expected the request to pass with its body intact, got %d %q", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("X-Normalized"); got != "jane@example.com" {
+		t.Fatalf("expected results in the request context, got %q", got)
+	}
+}
+
+func TestMiddlewareRejectsInvalidField(t *testing.T) {
+	handler := Middleware("email", "contact.email")(echo)
+
+	body := `{"email":"jane@example.com","contact":{"email":"bad..dots@example.com"}}`
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	field, ok := resp.Fields["contact.email"]
+	if len(resp.Fields) != 1 || !ok || field.Errors[0].Code != emailvalidator.CodeLocalPartConsecutiveDots {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestMiddlewareForm(t *testing.T) {
+	handler := Middleware("email")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.PostFormValue("name"))
+	}))
+
+	form := url.Values{"email": {"jane@gmial.com"}, "name": {"Jane"}}
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "Jane" {
+		t.Fatalf("expected the form to reach the handler, got %d %q", rec.Code, rec.Body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/check?email=not-an-email", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for an invalid query value, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareMalformedJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader("{"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	Middleware("email")(echo).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
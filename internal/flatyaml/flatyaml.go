@@ -0,0 +1,102 @@
+// Package flatyaml parses the flat YAML subset used by configuration files,
+// without depending on a YAML library
+package flatyaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse decodes the flat YAML subset used by config files: top-level
+// "key: value" pairs whose values are scalars, inline [a, b] lists or
+// block lists of "- item" lines
+func Parse(data []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	var listKey string
+
+	for n, line := range strings.Split(string(data), "\n") {
+		line = stripComment(line)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if listKey == "" {
+				return nil, fmt.Errorf("line %d: list item outside of a list", n+1)
+			}
+			item := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			values[listKey] = append(values[listKey].([]interface{}), parseScalar(item))
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			return nil, fmt.Errorf("line %d: nested mappings are not supported", n+1)
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key: value", n+1)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		listKey = ""
+
+		switch {
+		case value == "":
+			listKey = key
+			values[key] = []interface{}{}
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			items := []interface{}{}
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, parseScalar(item))
+				}
+			}
+			values[key] = items
+		default:
+			values[key] = parseScalar(value)
+		}
+	}
+
+	return values, nil
+}
+
+// stripComment removes a trailing # comment outside of quotes
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseScalar converts a YAML scalar into a bool, number or string
+func parseScalar(value string) interface{} {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
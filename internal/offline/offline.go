@@ -0,0 +1,70 @@
+// Package offline builds validators limited to the checks made without
+// network requests, for the WebAssembly module and the C shared library
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// keys are the emailvalidator.Config keys of the checks made without
+// network requests. Keys not listed, such as dns_check, gravatar_check or
+// disposable_heuristics, are refused rather than ignored, so new checks
+// are only offered once they are known to stay offline.
+var keys = map[string]bool{
+	"strict_mode":           true,
+	"max_length":            true,
+	"max_local_part_length": true,
+	"max_domain_length":     true,
+	"min_tld_length":        true,
+	"allowed_tlds":          true,
+	"blocked_domains":       true,
+	"allow_ip_addresses":    true,
+	"smtputf8":              true,
+	"loose_hostnames":       true,
+	"disposable_check":      true,
+	"privacy_relay":         true,
+	"emoji_domain":          true,
+	"typo_suggestions":      true,
+	"suggestion_domains":    true,
+	"normalization":         true,
+	"dot_policy":            true,
+	"local_part_analysis":   true,
+	"name_extraction":       true,
+	"personal_name_check":   true,
+	"name_locales":          true,
+	"accept_all_domains":    true,
+	"tld_risk_check":        true,
+	"tld_risk":              true,
+	"tld_policy":            true,
+}
+
+// FromConfig builds a validator from the defaults overridden by the JSON
+// encoding of an emailvalidator.Config. It fails when config sets a key of
+// a check making network requests.
+func FromConfig(config string) (*emailvalidator.EmailValidator, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(config), &raw); err != nil {
+		return nil, err
+	}
+	var refused []string
+	for key := range raw {
+		if !keys[key] {
+			refused = append(refused, key)
+		}
+	}
+	if len(refused) > 0 {
+		sort.Strings(refused)
+		return nil, fmt.Errorf("not available offline: %s", strings.Join(refused, ", "))
+	}
+
+	cfg := emailvalidator.DefaultConfig()
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		return nil, err
+	}
+	return emailvalidator.NewFromConfig(cfg)
+}
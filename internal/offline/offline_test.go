@@ -0,0 +1,33 @@
+package offline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromConfig(t *testing.T) {
+	v, err := FromConfig(`{"disposable_check": "error", "typo_suggestions": true}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Validate("jane@gmial.com").Suggestion; got != "jane@gmail.com" {
+		t.Errorf("suggestion %q", got)
+	}
+
+	for _, config := range []string{
+		`{"dns_check": true}`,
+		`{"dns_check": false}`,
+		`{"parking_check": true}`,
+		`{"gravatar_check": true}`,
+		`{"hibp_api_key": "key"}`,
+		`{"domain_reputation": true}`,
+		`{"organization": true}`,
+		`{"disposable_heuristics": true}`,
+		`{"ip_database": "ip2asn.tsv"}`,
+		`{"Strict_Mode": true}`,
+	} {
+		if _, err := FromConfig(config); err == nil || !strings.Contains(err.Error(), "not available offline") {
+			t.Errorf("%s: got %v", config, err)
+		}
+	}
+}
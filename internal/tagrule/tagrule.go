@@ -0,0 +1,94 @@
+// Package tagrule parses the email check parameters shared by the struct
+// validation integrations, such as "dns nodisposable"
+package tagrule
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// Rule is one parsed parameter set
+type Rule struct {
+	validator *emailvalidator.EmailValidator
+	noRole    bool
+	noTypo    bool
+}
+
+// Parse derives the rule for params from base. Parameters are separated by
+// spaces or commas:
+//
+//	dns           verify that the domain has MX or A records
+//	nodisposable  reject disposable domains
+//	norole        reject role accounts such as admin@ or support@
+//	notypo        reject addresses with a likely domain typo
+//	strict        apply strict syntax rules
+func Parse(base *emailvalidator.EmailValidator, params string) (*Rule, error) {
+	r := &Rule{}
+	var opts []emailvalidator.Option
+	for _, name := range strings.FieldsFunc(params, func(c rune) bool { return c == ' ' || c == ',' }) {
+		switch name {
+		case "dns":
+			opts = append(opts, emailvalidator.WithDNSCheck(nil))
+		case "nodisposable":
+			opts = append(opts, emailvalidator.WithDisposableCheck(emailvalidator.SeverityError))
+		case "norole":
+			r.noRole = true
+		case "notypo":
+			r.noTypo = true
+			opts = append(opts, emailvalidator.WithTypoSuggestions(true))
+		case "strict":
+			opts = append(opts, emailvalidator.WithStrict(true))
+		default:
+			return nil, fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+	r.validator = base.With(opts...)
+	return r, nil
+}
+
+// Check validates email and reports whether it passes the rule. reason
+// explains a rejection not covered by the result's errors.
+func (r *Rule) Check(email string) (result emailvalidator.ValidationResult, ok bool, reason emailvalidator.Reason) {
+	result = r.validator.Validate(email)
+	if !result.IsValid {
+		return result, false, ""
+	}
+	if r.noTypo && result.Suggestion != "" {
+		return result, false, emailvalidator.ReasonPossibleTypo
+	}
+	if r.noRole {
+		for _, w := range result.Warnings {
+			if w.Code == emailvalidator.WarnRoleAccount {
+				return result, false, emailvalidator.ReasonRoleAccount
+			}
+		}
+	}
+	return result, true, ""
+}
+
+// Set caches the rules derived from one base validator by parameter string
+type Set struct {
+	base  *emailvalidator.EmailValidator
+	rules sync.Map
+}
+
+// NewSet creates a Set deriving its rules from base
+func NewSet(base *emailvalidator.EmailValidator) *Set {
+	return &Set{base: base}
+}
+
+// Rule returns the rule for params, parsing it on first use
+func (s *Set) Rule(params string) (*Rule, error) {
+	if cached, ok := s.rules.Load(params); ok {
+		return cached.(*Rule), nil
+	}
+	r, err := Parse(s.base, params)
+	if err != nil {
+		return nil, err
+	}
+	cached, _ := s.rules.LoadOrStore(params, r)
+	return cached.(*Rule), nil
+}
@@ -0,0 +1,42 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// invisibleChar returns the byte offset and value of the first control or
+// format character of email: C0 and C1 controls, zero-width spaces and
+// joiners, the byte order mark, the soft hyphen and the bidirectional
+// marks and overrides. They do not show when the address is displayed, so
+// two addresses looking the same may differ, and a bidi override can make
+// the address read differently from how it is delivered.
+func invisibleChar(email string) (int, rune, bool) {
+	for i := 0; i < len(email); {
+		if c := email[i]; c < utf8.RuneSelf {
+			if c < 0x20 || c == 0x7f {
+				return i, rune(c), true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(email[i:])
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return i, r, true
+		}
+		i += size
+	}
+	return 0, 0, false
+}
+
+// checkInvisible rejects email when it contains an invisible character,
+// reporting whether it does
+func checkInvisible(s *stage, email string) bool {
+	i, r, ok := invisibleChar(email)
+	if !ok {
+		return false
+	}
+	s.addError(newError(CodeInvisibleChars, FieldEmail, ErrInvalidFormat, "email contains a control or invisible character").withParam("char", fmt.Sprintf("U+%04X", r)).at(i, runeAt(email, i)))
+	return true
+}
@@ -0,0 +1,41 @@
+package emailvalidator
+
+import "testing"
+
+func TestInvisibleChars(t *testing.T) {
+	tests := []struct {
+		email  string
+		offset int
+		char   string
+	}{
+		{"jane\x00@example.com", 4, "U+0000"},
+		{"jane@example.com\n", 16, "U+000A"},
+		{"ja\u200bne@example.com", 2, "U+200B"},
+		{"jane@exa\u200dmple.com", 8, "U+200D"},
+		{"\ufeffjane@example.com", 0, "U+FEFF"},
+		{"jane\u202e@example.com", 4, "U+202E"},
+		{"jane@example.com\u2066", 16, "U+2066"},
+		{"jo\u00adhn@example.com", 2, "U+00AD"},
+		{"jane\u0085@example.com", 4, "U+0085"},
+	}
+	for _, v := range []*EmailValidator{New(), NewStrict(WithSMTPUTF8(true))} {
+		for _, tt := range tests {
+			r := v.Validate(tt.email)
+			if len(r.Errors) != 1 {
+				t.Errorf("%q: errors %v", tt.email, r.Errors)
+				continue
+			}
+			got := r.Errors[0]
+			if got.Code != CodeInvisibleChars || got.Offset != tt.offset || got.Params["char"] != tt.char {
+				t.Errorf("%q: got %s at %d, char %v", tt.email, got.Code, got.Offset, got.Params["char"])
+			}
+			if v.IsValidSyntax(tt.email) {
+				t.Errorf("%q: IsValidSyntax accepts it", tt.email)
+			}
+		}
+	}
+
+	if r := New(WithSMTPUTF8(true)).Validate("josé@example.com"); !r.IsValid {
+		t.Errorf("visible non-ASCII letters rejected: %v", r.Errors)
+	}
+}
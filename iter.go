@@ -0,0 +1,22 @@
+//go:build go1.23
+
+package emailvalidator
+
+import "iter"
+
+// Results lazily validates each address produced by emails, yielding the
+// address together with its result. Validation runs sequentially on the
+// caller's goroutine and stops as soon as the loop body breaks.
+//
+//	for email, result := range v.Results(slices.Values(emails)) {
+//		...
+//	}
+func (v *EmailValidator) Results(emails iter.Seq[string]) iter.Seq2[string, ValidationResult] {
+	return func(yield func(string, ValidationResult) bool) {
+		for email := range emails {
+			if !yield(email, v.Validate(email)) {
+				return
+			}
+		}
+	}
+}
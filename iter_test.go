@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package emailvalidator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResults(t *testing.T) {
+	emails := []string{"user@example.com", "invalid-email", "jane@example.org"}
+
+	var got []bool
+	for email, result := range New().Results(slices.Values(emails)) {
+		if email == "" {
+			t.Error("expected the address to be yielded")
+		}
+		got = append(got, result.IsValid)
+	}
+	if !slices.Equal(got, []bool{true, false, true}) {
+		t.Errorf("unexpected results: %v", got)
+	}
+}
+
+func TestResultsStopsEarly(t *testing.T) {
+	validated := 0
+	emails := func(yield func(string) bool) {
+		for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+			validated++
+			if !yield(email) {
+				return
+			}
+		}
+	}
+
+	for range New().Results(emails) {
+		break
+	}
+	if validated != 1 {
+		t.Errorf("expected iteration to stop after one address, validated %d", validated)
+	}
+}
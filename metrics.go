@@ -0,0 +1,49 @@
+package emailvalidator
+
+import "time"
+
+// Metrics receives an observation of every validation made by a validator
+// configured WithMetrics. The result carries the verdict and the duration
+// and status of each stage. Implementations must be safe for concurrent
+// use and should return quickly.
+type Metrics interface {
+	ObserveValidation(result ValidationResult, elapsed time.Duration)
+}
+
+// CacheStats is a snapshot of the counters of one cache layer
+type CacheStats struct {
+	// Hits counts lookups answered from the cache
+	Hits uint64 `json:"hits"`
+	// Misses counts lookups that had to do the work
+	Misses uint64 `json:"misses"`
+	// Evictions counts entries removed for space or age
+	Evictions uint64 `json:"evictions"`
+	// Entries is the number of entries currently held
+	Entries int `json:"entries"`
+}
+
+// HitRatio returns the share of lookups answered from the cache
+func (s CacheStats) HitRatio() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// CacheMetrics is implemented by every cache layer: ResultCache,
+// DomainMemo, the caches of the checkers and enrichers, and the bulk
+// package's DiskCache. Metrics exporters poll it.
+type CacheMetrics interface {
+	CacheStats() CacheStats
+}
+
+var (
+	_ CacheMetrics = (*ResultCache)(nil)
+	_ CacheMetrics = (*DomainMemo)(nil)
+	_ CacheMetrics = (*BreachChecker)(nil)
+	_ CacheMetrics = (*DomainReputationChecker)(nil)
+	_ CacheMetrics = (*DNSOrganizationEnricher)(nil)
+	_ CacheMetrics = (*MailHostingLocator)(nil)
+	_ CacheMetrics = (*ParkingDetector)(nil)
+	_ CacheMetrics = (*DisposableHeuristics)(nil)
+)
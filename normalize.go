@@ -0,0 +1,98 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalization is the identity semantics of ValidationResult.Normalized:
+// which spellings of an address are taken to name the same mailbox
+type Normalization string
+
+const (
+	// NormalizeCanonical trims and lower-cases the whole address, like
+	// Canonical. It is the default.
+	NormalizeCanonical Normalization = "canonical"
+	// NormalizeRFC lower-cases the domain only, since RFC 5321 leaves the
+	// case of local parts to the receiving server
+	NormalizeRFC Normalization = "rfc"
+	// NormalizeProviderAware is NormalizeCanonical plus the rules of large
+	// providers: Gmail ignores dots and googlemail.com is gmail.com, and
+	// Gmail, Outlook, iCloud, Fastmail and Proton drop "+tag" subaddresses
+	NormalizeProviderAware Normalization = "provider"
+	// NormalizeAggressive drops dots and "+tag" subaddresses at every
+	// domain, merging addresses that may well be different mailboxes. It
+	// suits abuse detection rather than deduplication.
+	NormalizeAggressive Normalization = "aggressive"
+)
+
+// ParseNormalization parses "canonical", "rfc", "provider" and "aggressive"
+func ParseNormalization(name string) (Normalization, error) {
+	switch n := Normalization(name); n {
+	case NormalizeCanonical, NormalizeRFC, NormalizeProviderAware, NormalizeAggressive:
+		return n, nil
+	default:
+		return "", fmt.Errorf("invalid normalization %q", name)
+	}
+}
+
+// providerRule is how a provider maps addresses to mailboxes
+type providerRule struct {
+	// domain is the provider's main domain when the key is an alias of it
+	domain     string
+	ignoreDots bool
+}
+
+// providerRules lists providers that deliver "+tag" subaddresses to the
+// base mailbox
+var providerRules = map[string]providerRule{
+	"gmail.com":      {ignoreDots: true},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true},
+	"outlook.com":    {},
+	"hotmail.com":    {},
+	"live.com":       {},
+	"icloud.com":     {},
+	"me.com":         {},
+	"mac.com":        {},
+	"fastmail.com":   {},
+	"proton.me":      {},
+	"protonmail.com": {},
+	"pm.me":          {},
+}
+
+// Normalize returns email normalized by n. Unknown normalizations behave
+// like NormalizeCanonical.
+func (n Normalization) Normalize(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndexByte(email, '@')
+	if n == NormalizeRFC {
+		if at < 0 {
+			return email
+		}
+		return email[:at+1] + strings.ToLower(email[at+1:])
+	}
+	email = strings.ToLower(email)
+	if at < 0 || n != NormalizeProviderAware && n != NormalizeAggressive {
+		return email
+	}
+	// Lower-casing may change the length of some characters
+	at = strings.LastIndexByte(email, '@')
+
+	local, domain := email[:at], email[at+1:]
+	rule, known := providerRules[domain]
+	if rule.domain != "" {
+		domain = rule.domain
+	}
+	if !known && n != NormalizeAggressive {
+		return email
+	}
+	if base, _, _ := strings.Cut(local, "+"); base != "" {
+		local = base
+	}
+	if rule.ignoreDots || n == NormalizeAggressive {
+		if undotted := strings.ReplaceAll(local, ".", ""); undotted != "" {
+			local = undotted
+		}
+	}
+	return local + "@" + domain
+}
@@ -0,0 +1,41 @@
+package emailvalidator
+
+import "testing"
+
+func TestNormalization(t *testing.T) {
+	tests := []struct {
+		n     Normalization
+		email string
+		want  string
+	}{
+		{NormalizeCanonical, " Jane.Doe+news@Example.COM ", "jane.doe+news@example.com"},
+		{NormalizeRFC, "Jane.Doe+news@Example.COM", "Jane.Doe+news@example.com"},
+		{NormalizeProviderAware, "Jane.Doe+news@GoogleMail.com", "janedoe@gmail.com"},
+		{NormalizeProviderAware, "jane.doe+news@outlook.com", "jane.doe@outlook.com"},
+		{NormalizeProviderAware, "jane.doe+news@example.com", "jane.doe+news@example.com"},
+		{NormalizeProviderAware, "+news@gmail.com", "+news@gmail.com"},
+		{NormalizeAggressive, "Jane.Doe+news@example.com", "janedoe@example.com"},
+		{NormalizeAggressive, "jane.doe@googlemail.com", "janedoe@gmail.com"},
+		{Normalization("unknown"), "Jane@Example.com", "jane@example.com"},
+		{NormalizeRFC, "Jane", "Jane"},
+		{NormalizeAggressive, "İ.x@example.com", "ix@example.com"},
+	}
+	for _, tt := range tests {
+		if got := tt.n.Normalize(tt.email); got != tt.want {
+			t.Errorf("%s: Normalize(%q) = %q, want %q", tt.n, tt.email, got, tt.want)
+		}
+	}
+
+	if _, err := ParseNormalization("loose"); err == nil {
+		t.Error("unknown normalization parsed")
+	}
+	cfg := DefaultConfig()
+	cfg.Normalization = "provider"
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Validate("J.Doe+x@gmail.com").Normalized; got != "jdoe@gmail.com" {
+		t.Errorf("Normalized = %q", got)
+	}
+}
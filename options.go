@@ -6,17 +6,45 @@ type Option func(*EmailValidator)
 // WithAllowedTLDs sets allowed top-level domains
 func WithAllowedTLDs(tlds []string) Option {
 	return func(ev *EmailValidator) {
-		ev.allowTLDs = tlds
+		ev.allowTLDs = append([]string(nil), tlds...)
+	}
+}
+
+// WithTLDPolicy sets how addresses on top-level domains of category are
+// treated: blocked ones fail with tld_not_allowed, and warned ones get a
+// tld_category warning. Categories without a policy are allowed.
+func WithTLDPolicy(category TLDCategory, policy Policy) Option {
+	return func(ev *EmailValidator) {
+		// Copied so that clones sharing the map are not affected
+		policies := make(map[TLDCategory]Policy, len(ev.tldPolicies)+1)
+		for c, p := range ev.tldPolicies {
+			policies[c] = p
+		}
+		policies[category] = policy
+		ev.tldPolicies = policies
 	}
 }
 
 // WithBlockedDomains sets blocked domains
 func WithBlockedDomains(domains []string) Option {
 	return func(ev *EmailValidator) {
-		ev.blockedDomains = make(map[string]bool)
-		for _, domain := range domains {
-			ev.blockedDomains[strings.ToLower(domain)] = true
-		}
+		ev.blockedDomains = NewDomainSet(domains...)
+	}
+}
+
+// WithBlockedDomainSet sets blocked domains from a prebuilt set, which is
+// cheaper than WithBlockedDomains for very large lists. The set must not be
+// modified afterwards.
+func WithBlockedDomainSet(set *DomainSet) Option {
+	return func(ev *EmailValidator) {
+		ev.blockedDomains = set
+	}
+}
+
+// WithStrict enables or disables strict character validation
+func WithStrict(strict bool) Option {
+	return func(ev *EmailValidator) {
+		ev.strictMode = strict
 	}
 }
 
@@ -25,4 +53,305 @@ func WithIPAddresses(allow bool) Option {
 	return func(ev *EmailValidator) {
 		ev.allowIPAddresses = allow
 	}
-}
\ No newline at end of file
+}
+
+// WithSMTPUTF8 accepts internationalized local parts with non-ASCII
+// letters, marks and digits (RFC 6531), which only servers supporting the
+// SMTPUTF8 extension deliver to. Local parts mixing scripts are reported
+// with a mixed_script warning.
+func WithSMTPUTF8(allow bool) Option {
+	return func(ev *EmailValidator) {
+		ev.smtputf8 = allow
+	}
+}
+
+// WithLooseHostnames accepts underscores in domain labels outside strict
+// mode, as found in some internal mail domains, reporting them with a
+// domain_underscore warning
+func WithLooseHostnames(allow bool) Option {
+	return func(ev *EmailValidator) {
+		ev.allowUnderscores = allow
+	}
+}
+
+// WithMaxLength sets the maximum total address length. Values below 1
+// restore DefaultMaxLength.
+func WithMaxLength(n int) Option {
+	return func(ev *EmailValidator) {
+		ev.maxLength = positiveOr(n, DefaultMaxLength)
+	}
+}
+
+// WithMaxLocalPartLength sets the maximum length of the local part. Values
+// below 1 restore DefaultMaxLocalPartLength.
+func WithMaxLocalPartLength(n int) Option {
+	return func(ev *EmailValidator) {
+		ev.maxLocalLength = positiveOr(n, DefaultMaxLocalPartLength)
+	}
+}
+
+// WithMaxDomainLength sets the maximum length of the domain part. Values
+// below 1 restore DefaultMaxDomainLength.
+func WithMaxDomainLength(n int) Option {
+	return func(ev *EmailValidator) {
+		ev.maxDomainLength = positiveOr(n, DefaultMaxDomainLength)
+	}
+}
+
+// positiveOr returns n, or fallback when n is not positive
+func positiveOr(n, fallback int) int {
+	if n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+// WithMinTLDLength sets the minimum length of the top-level domain in
+// characters; numeric TLDs of IP address domains are exempt
+func WithMinTLDLength(n int) Option {
+	return func(ev *EmailValidator) {
+		ev.minTLDLength = n
+	}
+}
+
+// WithDisposableCheck enables disposable domain detection in Validate,
+// reported as an error or a warning depending on severity
+func WithDisposableCheck(severity Severity) Option {
+	return func(ev *EmailValidator) {
+		ev.disposableCheck = true
+		ev.disposableSeverity = severity
+	}
+}
+
+// WithDisposableHeuristics estimates with heuristics, or a default
+// DisposableHeuristics when heuristics is nil, how likely the unlisted
+// domains of valid addresses are to be disposable, reported in
+// ValidationResult.LikelyDisposable
+func WithDisposableHeuristics(heuristics *DisposableHeuristics) Option {
+	return func(ev *EmailValidator) {
+		if heuristics == nil {
+			heuristics = NewDisposableHeuristics()
+		}
+		ev.disposableHeuristics = heuristics
+	}
+}
+
+// WithEmojiDomainPolicy sets how addresses whose domain has emoji in a
+// Punycode label, such as xn--i-7iq.ws (i❤.ws), are treated. They are
+// allowed by default.
+func WithEmojiDomainPolicy(policy Policy) Option {
+	return func(ev *EmailValidator) {
+		ev.emojiPolicy = policy
+	}
+}
+
+// WithNormalization sets how ValidationResult.Normalized is derived from
+// addresses; NormalizeCanonical by default
+func WithNormalization(n Normalization) Option {
+	return func(ev *EmailValidator) {
+		ev.normalization = n
+	}
+}
+
+// WithDotPolicy sets how local parts with a leading, trailing or repeated
+// dot are treated outside strict mode; see DotPolicy
+func WithDotPolicy(policy DotPolicy) Option {
+	return func(ev *EmailValidator) {
+		ev.dotPolicy = policy
+	}
+}
+
+// WithTLDRisk lowers the score of addresses by the abuse risk of their
+// top-level domain, reported in ValidationResult.TLDRisk and weighted by
+// ScoreWeights.TLD. Risks are taken from DefaultTLDRisk, with the weights
+// of overrides replacing or adding to them; a weight of 0 clears a TLD.
+func WithTLDRisk(overrides map[string]float64) Option {
+	return func(ev *EmailValidator) {
+		ev.tldRisk = mergeTLDRisk(overrides)
+	}
+}
+
+// WithPrivacyRelayPolicy sets how addresses at privacy relay services, such
+// as Apple's Hide My Email, are treated. They are allowed by default and
+// never count as disposable.
+func WithPrivacyRelayPolicy(policy Policy) Option {
+	return func(ev *EmailValidator) {
+		ev.relayPolicy = policy
+	}
+}
+
+// WithLocalPartAnalysis enables or disables reporting the length, digit
+// ratio, separators and entropy of local parts in
+// ValidationResult.LocalPart
+func WithLocalPartAnalysis(enabled bool) Option {
+	return func(ev *EmailValidator) {
+		ev.localPartAnalysis = enabled
+	}
+}
+
+// WithNameExtraction enables or disables extracting the probable first and
+// last name of the owner from the local part into ValidationResult.Name,
+// as CommonPatterns.ExtractName does
+func WithNameExtraction(enabled bool) Option {
+	return func(ev *EmailValidator) {
+		ev.nameExtraction = enabled
+	}
+}
+
+// WithPersonalNameDetector reports in ValidationResult.PersonalName whether
+// local parts look like a person's name, as told by detector or a
+// PersonalNameDetector for every built-in locale when detector is nil
+func WithPersonalNameDetector(detector *PersonalNameDetector) Option {
+	return func(ev *EmailValidator) {
+		if detector == nil {
+			detector = NewPersonalNameDetector()
+		}
+		ev.personalNameDetector = detector
+	}
+}
+
+// WithTypoSuggestions enables or disables domain typo suggestions
+func WithTypoSuggestions(enabled bool) Option {
+	return func(ev *EmailValidator) {
+		ev.typoSuggestions = enabled
+	}
+}
+
+// WithSuggestionDomains sets the targets of typo suggestions, such as the
+// domains most common among your own users, to DefaultSuggestionDomains
+// with the weights of domains replacing or adding to them; a weight of 0
+// removes a domain. Besides the known typos, domains within one edit of a
+// target are then corrected to it, ranked by DomainSimilarity and, among
+// the equally similar, by weight, heavier first.
+func WithSuggestionDomains(domains map[string]float64) Option {
+	return func(ev *EmailValidator) {
+		ev.suggestionDomains = newDomainTargets(mergeSuggestionDomains(domains))
+	}
+}
+
+// WithScoreWeights overrides the weights used to compute ValidationResult.Score
+func WithScoreWeights(weights ScoreWeights) Option {
+	return func(ev *EmailValidator) {
+		ev.scoreWeights = weights
+	}
+}
+
+// WithDNSCheck enables MX/A record verification in Validate using checker,
+// or a default DNSChecker when checker is nil
+func WithDNSCheck(checker *DNSChecker) Option {
+	return func(ev *EmailValidator) {
+		if checker == nil {
+			checker = NewDNSChecker()
+		}
+		ev.dnsChecker = checker
+	}
+}
+
+// WithGravatarCheck looks up valid addresses on Gravatar using checker, or
+// a default GravatarChecker when checker is nil, and reports the outcome in
+// ValidationResult.HasGravatar. It does not affect validity or the score.
+func WithGravatarCheck(checker *GravatarChecker) Option {
+	return func(ev *EmailValidator) {
+		if checker == nil {
+			checker = NewGravatarChecker()
+		}
+		ev.gravatarChecker = checker
+	}
+}
+
+// WithBreachCheck looks up valid addresses with checker and reports their
+// known data breaches in ValidationResult.Breached and Breaches. It does not
+// affect validity or the score. A nil checker disables the check.
+func WithBreachCheck(checker *BreachChecker) Option {
+	return func(ev *EmailValidator) {
+		ev.breachChecker = checker
+	}
+}
+
+// WithDomainReputation rates the domain of valid addresses using checker,
+// or a default DomainReputationChecker when checker is nil, and reports it
+// in ValidationResult.DomainReputation alongside the address-level Score,
+// which it does not affect
+func WithDomainReputation(checker *DomainReputationChecker) Option {
+	return func(ev *EmailValidator) {
+		if checker == nil {
+			checker = NewDomainReputationChecker()
+		}
+		ev.reputationChecker = checker
+	}
+}
+
+// WithOrganization attaches the organization behind the domain of valid
+// addresses, found by enricher or a default DNSOrganizationEnricher when
+// enricher is nil, as ValidationResult.Organization
+func WithOrganization(enricher OrganizationEnricher) Option {
+	return func(ev *EmailValidator) {
+		if enricher == nil {
+			enricher = NewDNSOrganizationEnricher(nil)
+		}
+		ev.organizationEnricher = enricher
+	}
+}
+
+// WithMailHosting reports the network and country of the mail hosts of
+// valid addresses, found by locator, as ValidationResult.MailHosting
+func WithMailHosting(locator *MailHostingLocator) Option {
+	return func(ev *EmailValidator) {
+		ev.hostingLocator = locator
+	}
+}
+
+// WithParkingCheck rejects addresses at parked domains, recognized by
+// detector or a default ParkingDetector when detector is nil. It runs as
+// part of the DNS check, so it requires WithDNSCheck.
+func WithParkingCheck(detector *ParkingDetector) Option {
+	return func(ev *EmailValidator) {
+		if detector == nil {
+			detector = NewParkingDetector()
+		}
+		ev.parkingDetector = detector
+	}
+}
+
+// WithAcceptAllDomains sets ValidationResult.AcceptAll for valid addresses
+// at domains, or their subdomains, that accept mail for any local part.
+// Without domains it uses DefaultAcceptAllDomains.
+func WithAcceptAllDomains(domains ...string) Option {
+	return func(ev *EmailValidator) {
+		if len(domains) == 0 {
+			domains = DefaultAcceptAllDomains
+		}
+		ev.acceptAllDomains = NewDomainSet(domains...)
+	}
+}
+
+// WithStreamWorkers sets how many addresses ValidateStream validates
+// concurrently. The default is GOMAXPROCS.
+func WithStreamWorkers(n int) Option {
+	return func(ev *EmailValidator) {
+		ev.streamWorkers = n
+	}
+}
+
+// WithMetrics reports every validation to m
+func WithMetrics(m Metrics) Option {
+	return func(ev *EmailValidator) {
+		ev.metrics = m
+	}
+}
+
+// WithAuditor records every validation with a, identified by the hash of
+// the address
+func WithAuditor(a Auditor) Option {
+	return func(ev *EmailValidator) {
+		ev.auditor = a
+	}
+}
+
+// WithTracer records a span for every validation and for its DNS stage,
+// as children of the span in the context passed to ValidateContext
+func WithTracer(t Tracer) Option {
+	return func(ev *EmailValidator) {
+		ev.tracer = t
+	}
+}
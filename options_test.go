@@ -0,0 +1,68 @@
+package emailvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLengthLimits(t *testing.T) {
+	longLocal := strings.Repeat("a", 65) + "@example.com"
+	longTotal := strings.Repeat("a", 60) + "@" + strings.Repeat("b", 60) + "." + strings.Repeat("c", 60) + "." + strings.Repeat("d", 60) + "." + strings.Repeat("e", 10) + ".com"
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		email    string
+		expected bool
+	}{
+		{"default local limit", nil, longLocal, false},
+		{"default total limit", nil, longTotal, false},
+		{"raised local limit", []Option{WithMaxLocalPartLength(80)}, longLocal, true},
+		{"lowered total limit", []Option{WithMaxLength(10)}, "user@example.com", false},
+		{"lowered domain limit", []Option{WithMaxDomainLength(5)}, "user@example.com", false},
+		{"negative total limit", []Option{WithMaxLength(-1)}, "user@example.com", true},
+		{"zero local limit", []Option{WithMaxLocalPartLength(0)}, longLocal, false},
+		{"negative local limit", []Option{WithMaxLocalPartLength(-5)}, "user@example.com", true},
+		{"negative domain limit", []Option{WithMaxDomainLength(-5)}, "user@example.com", true},
+	}
+
+	for _, tc := range testCases {
+		result := New(tc.opts...).Validate(tc.email)
+		if result.IsValid != tc.expected {
+			t.Errorf("%s: expected %t, got %t (errors: %v)", tc.name, tc.expected, result.IsValid, result.Errors)
+		}
+	}
+}
+
+func TestDisposableCheck(t *testing.T) {
+	email := "user@mailinator.com"
+
+	if result := New().Validate(email); len(result.Errors) > 0 || len(result.Warnings) > 0 {
+		t.Errorf("disposable check should be off by default, got %+v", result)
+	}
+
+	result := New(WithDisposableCheck(SeverityWarning)).Validate(email)
+	if !result.IsValid || len(result.Warnings) != 1 {
+		t.Errorf("expected valid result with one warning, got %+v", result)
+	}
+
+	result = New(WithDisposableCheck(SeverityError)).Validate(email)
+	if result.IsValid {
+		t.Errorf("expected disposable address to be rejected, got %+v", result)
+	}
+}
+
+func TestTypoSuggestions(t *testing.T) {
+	result := New().Validate("user@gmial.com")
+	if result.Suggestion != "user@gmail.com" {
+		t.Errorf("expected suggestion user@gmail.com, got %q", result.Suggestion)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", result.Warnings)
+	}
+
+	result = New(WithTypoSuggestions(false)).Validate("user@gmial.com")
+	if result.Suggestion != "" {
+		t.Errorf("expected no suggestion when disabled, got %q", result.Suggestion)
+	}
+}
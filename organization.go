@@ -0,0 +1,281 @@
+package emailvalidator
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// OrganizationType classifies the organization behind a domain
+type OrganizationType string
+
+// Organization types reported in Organization.Type
+const (
+	OrgFreemail   OrganizationType = "freemail"
+	OrgBusiness   OrganizationType = "business"
+	OrgEducation  OrganizationType = "education"
+	OrgGovernment OrganizationType = "government"
+)
+
+// Organization describes who runs the domain of an address. Name is empty
+// for freemail domains, whose users are individuals.
+type Organization struct {
+	Name     string           `json:"name,omitempty"`
+	Domain   string           `json:"domain,omitempty"`
+	Type     OrganizationType `json:"type"`
+	Provider string           `json:"provider,omitempty"`
+}
+
+// OrganizationEnricher finds the organization behind a domain, for example
+// from DNS or a company database. Implementations must be safe for
+// concurrent use.
+type OrganizationEnricher interface {
+	Organization(ctx context.Context, domain string) (Organization, error)
+}
+
+// OrganizationFunc adapts an ordinary function to the OrganizationEnricher
+// interface
+type OrganizationFunc func(ctx context.Context, domain string) (Organization, error)
+
+// Organization calls f(ctx, domain)
+func (f OrganizationFunc) Organization(ctx context.Context, domain string) (Organization, error) {
+	return f(ctx, domain)
+}
+
+// OrganizationResolver performs the DNS lookups of a DNSOrganizationEnricher.
+// *net.Resolver implements it.
+type OrganizationResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// freemailDomains lists the domains of free mailbox providers
+var freemailDomains = NewDomainSet(
+	"gmail.com",
+	"googlemail.com",
+	"yahoo.com",
+	"outlook.com",
+	"hotmail.com",
+	"live.com",
+	"msn.com",
+	"aol.com",
+	"icloud.com",
+	"me.com",
+	"mail.com",
+	"gmx.com",
+	"gmx.de",
+	"web.de",
+	"yandex.com",
+	"yandex.ru",
+	"mail.ru",
+	"proton.me",
+	"protonmail.com",
+	"zoho.com",
+	"qq.com",
+	"163.com",
+)
+
+// mailProviders maps MX host domains to the name of their mail provider
+var mailProviders = map[string]string{
+	"google.com":            "Google",
+	"googlemail.com":        "Google",
+	"outlook.com":           "Microsoft",
+	"hotmail.com":           "Microsoft",
+	"yahoodns.net":          "Yahoo",
+	"icloud.com":            "Apple",
+	"zoho.com":              "Zoho",
+	"zoho.eu":               "Zoho",
+	"messagingengine.com":   "Fastmail",
+	"protonmail.ch":         "Proton",
+	"pphosted.com":          "Proofpoint",
+	"mimecast.com":          "Mimecast",
+	"barracudanetworks.com": "Barracuda",
+	"amazonaws.com":         "Amazon",
+	"yandex.net":            "Yandex",
+}
+
+// dmarcVendors lists DMARC reporting services, whose rua addresses say
+// nothing about the organization
+var dmarcVendors = NewDomainSet(
+	"dmarcian.com",
+	"dmarcanalyzer.com",
+	"valimail.com",
+	"agari.com",
+	"ondmarc.com",
+	"uriports.com",
+	"easydmarc.us",
+	"easydmarc.eu",
+	"postmarkapp.com",
+	"mailgun.org",
+	"returnpath.net",
+	"proofpoint.com",
+)
+
+// DNSOrganizationEnricher finds organizations from DNS: the provider from
+// the MX hosts, and the name from the domains receiving DMARC aggregate
+// reports, which point subsidiaries and brand domains at their parent, or
+// otherwise from the domain itself. Findings are cached per domain.
+type DNSOrganizationEnricher struct {
+	resolver OrganizationResolver
+	timeout  time.Duration
+	cache    *ttlCache[string, Organization]
+}
+
+// NewDNSOrganizationEnricher creates a DNSOrganizationEnricher using
+// resolver, or net.DefaultResolver when resolver is nil
+func NewDNSOrganizationEnricher(resolver OrganizationResolver) *DNSOrganizationEnricher {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &DNSOrganizationEnricher{
+		resolver: resolver,
+		timeout:  5 * time.Second,
+		cache:    newTTLCache[string, Organization](10000, 24*time.Hour),
+	}
+}
+
+// Organization implements OrganizationEnricher. Failed lookups leave the
+// provider unknown and the name derived from domain; they are not cached.
+func (e *DNSOrganizationEnricher) Organization(ctx context.Context, domain string) (Organization, error) {
+	domain = strings.ToLower(domain)
+	if org, ok := e.cache.get(domain); ok {
+		return org, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	org := Organization{Type: organizationType(domain)}
+	mx, err := e.resolver.LookupMX(ctx, domain)
+	conclusive := err == nil || isNotFound(err)
+	for _, record := range mx {
+		if provider := mailProvider(record.Host); provider != "" {
+			org.Provider = provider
+			break
+		}
+	}
+
+	if org.Type != OrgFreemail {
+		org.Domain = registrableDomain(domain)
+		txt, err := e.resolver.LookupTXT(ctx, "_dmarc."+domain)
+		conclusive = conclusive && (err == nil || isNotFound(err))
+		if parent := reportDomain(txt, org.Domain); parent != "" {
+			org.Domain = parent
+		}
+		org.Name = organizationName(org.Domain)
+	}
+
+	if conclusive {
+		e.cache.add(domain, org)
+	}
+	return org, nil
+}
+
+// CacheStats implements CacheMetrics for the cache of organizations
+func (e *DNSOrganizationEnricher) CacheStats() CacheStats {
+	return e.cache.stats()
+}
+
+// organizationType classifies domain by its public suffix and the
+// freemail list
+func organizationType(domain string) OrganizationType {
+	if freemailDomains.Match(domain) {
+		return OrgFreemail
+	}
+	labels := strings.Split(domain, ".")
+	tld := labels[len(labels)-1]
+	second := ""
+	if len(labels) >= 3 {
+		second = labels[len(labels)-2]
+	}
+	switch {
+	case tld == "edu" || second == "edu" || second == "ac":
+		return OrgEducation
+	case tld == "gov" || tld == "mil" || second == "gov" || second == "gouv" || second == "gob":
+		return OrgGovernment
+	}
+	return OrgBusiness
+}
+
+// mailProvider returns the provider of the MX host, or an empty string
+func mailProvider(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for {
+		if provider, ok := mailProviders[host]; ok {
+			return provider
+		}
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			return ""
+		}
+		host = host[dot+1:]
+	}
+}
+
+// reportDomain returns the registrable domain of the first DMARC aggregate
+// report address in records that belongs to neither own nor a reporting
+// vendor, or an empty string
+func reportDomain(records []string, own string) string {
+	for _, record := range records {
+		if !strings.HasPrefix(strings.ToLower(record), "v=dmarc1") {
+			continue
+		}
+		for _, tag := range strings.Split(record, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+			if !strings.EqualFold(name, "rua") {
+				continue
+			}
+			for _, uri := range strings.Split(value, ",") {
+				_, addr, ok := strings.Cut(strings.TrimSpace(uri), "@")
+				if !ok {
+					continue
+				}
+				// Drops a size limit such as "!10m"
+				addr, _, _ = strings.Cut(strings.ToLower(addr), "!")
+				if d := registrableDomain(addr); d != own && !dmarcVendors.Match(d) {
+					return d
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// registrableDomain approximates the domain registered by the owner of
+// domain: its last two labels, or three under second-level suffixes such
+// as co.uk
+func registrableDomain(domain string) string {
+	labels := strings.Split(domain, ".")
+	n := 2
+	if len(labels) >= 3 {
+		switch labels[len(labels)-2] {
+		case "co", "com", "ac", "edu", "gov", "org", "net", "gouv", "gob":
+			n = 3
+		}
+	}
+	if len(labels) < n {
+		return domain
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+// organizationName derives a display name from the first label of the
+// registrable domain, such as "Acme Corp" for acme-corp.com
+func organizationName(domain string) string {
+	label, _, _ := strings.Cut(domain, ".")
+	words := strings.FieldsFunc(label, func(r rune) bool { return r == '-' || r == '_' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// checkOrganization attaches the organization behind the domain of the
+// address, leaving it nil when the enricher fails
+func (v *EmailValidator) checkOrganization(ctx context.Context, domain string, result *ValidationResult) {
+	org, err := v.organizationEnricher.Organization(ctx, domain)
+	if err == nil {
+		result.Organization = &org
+	}
+}
@@ -0,0 +1,56 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDNSOrganizationEnricher(t *testing.T) {
+	resolver := &reputationResolver{
+		txt: map[string][]string{
+			"_dmarc.acme-corp.com":   {"v=DMARC1; p=reject; rua=mailto:dmarc@acme-corp.com"},
+			"_dmarc.acmebrand.co.uk": {"v=DMARC1; p=none; rua=mailto:x@rua.dmarcian.com,mailto:reports@mail.acme-holdings.com!10m"},
+		},
+		mx: map[string]bool{"acme-corp.com": true},
+	}
+	e := NewDNSOrganizationEnricher(resolver)
+
+	tests := []struct {
+		domain string
+		want   Organization
+	}{
+		{"acme-corp.com", Organization{Name: "Acme Corp", Domain: "acme-corp.com", Type: OrgBusiness}},
+		// Reports go to the parent company, past the reporting vendor
+		{"acmebrand.co.uk", Organization{Name: "Acme Holdings", Domain: "acme-holdings.com", Type: OrgBusiness}},
+		{"cs.ox.ac.uk", Organization{Name: "Ox", Domain: "ox.ac.uk", Type: OrgEducation}},
+		{"irs.gov", Organization{Name: "Irs", Domain: "irs.gov", Type: OrgGovernment}},
+		{"gmail.com", Organization{Type: OrgFreemail}},
+	}
+	for _, tt := range tests {
+		got, err := e.Organization(context.Background(), tt.domain)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: got %+v, %v", tt.domain, got, err)
+		}
+	}
+
+	if got := mailProvider("ASPMX.L.Google.com."); got != "Google" {
+		t.Errorf("provider: %q", got)
+	}
+}
+
+func TestWithOrganization(t *testing.T) {
+	failing := OrganizationFunc(func(ctx context.Context, domain string) (Organization, error) {
+		if domain == "down.example" {
+			return Organization{}, errors.New("unavailable")
+		}
+		return Organization{Name: "Example", Type: OrgBusiness}, nil
+	})
+	v := New(WithOrganization(failing))
+	if r := v.Validate("jane@Example.com"); r.Organization == nil || r.Organization.Name != "Example" {
+		t.Fatalf("got %+v", r.Organization)
+	}
+	if r := v.Validate("jane@down.example"); !r.IsValid || r.Organization != nil {
+		t.Fatalf("failed enrichment: %v %+v", r.IsValid, r.Organization)
+	}
+}
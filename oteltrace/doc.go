@@ -0,0 +1,8 @@
+// Package oteltrace adapts an OpenTelemetry tracer to emailvalidator.Tracer:
+//
+//	tracer := oteltrace.New(otel.Tracer("emailvalidator"))
+//	v := emailvalidator.New(emailvalidator.WithTracer(tracer))
+//
+// The adapter depends on go.opentelemetry.io/otel, which this module does
+// not require, so it is built only with the otel build tag.
+package oteltrace
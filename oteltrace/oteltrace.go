@@ -0,0 +1,60 @@
+//go:build otel
+
+package oteltrace
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// New returns a Tracer starting its spans with t
+func New(t trace.Tracer) emailvalidator.Tracer {
+	return tracer{t}
+}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// Start implements emailvalidator.Tracer
+func (t tracer) Start(ctx context.Context, name string) (context.Context, emailvalidator.Span) {
+	ctx, s := t.tracer.Start(ctx, name)
+	return ctx, span{s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+// SetAttribute implements emailvalidator.Span
+func (s span) SetAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case float64:
+		s.span.SetAttributes(attribute.Float64(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+// RecordError implements emailvalidator.Span
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End implements emailvalidator.Span
+func (s span) End() {
+	s.span.End()
+}
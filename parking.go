@@ -0,0 +1,127 @@
+package emailvalidator
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// DefaultParkingPrefixes are the address ranges of well-known domain
+// parking services: Sedo, ParkingCrew, Above.com and Bodis
+var DefaultParkingPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("91.195.240.0/23"),
+	netip.MustParsePrefix("64.190.62.0/23"),
+	netip.MustParsePrefix("185.53.176.0/22"),
+	netip.MustParsePrefix("103.224.182.0/23"),
+	netip.MustParsePrefix("103.224.212.0/24"),
+	netip.MustParsePrefix("199.59.240.0/22"),
+}
+
+// DefaultParkingMXDomains are the domains of the placeholder MX hosts of
+// well-known parking services
+var DefaultParkingMXDomains = []string{
+	"sedoparking.com",
+	"parkingcrew.net",
+	"above.com",
+	"bodis.com",
+	"parklogic.com",
+}
+
+// ParkingDetector recognizes parked domains, whose A records point at a
+// parking service or whose MX hosts are parking placeholders. Outcomes are
+// cached per domain. A detector is safe for concurrent use; its With
+// methods return modified copies.
+type ParkingDetector struct {
+	resolver Resolver
+	prefixes []netip.Prefix
+	mx       *DomainSet
+	timeout  time.Duration
+	cache    *ttlCache[string, bool]
+}
+
+// NewParkingDetector creates a ParkingDetector recognizing the default
+// parking services
+func NewParkingDetector() *ParkingDetector {
+	return &ParkingDetector{
+		resolver: net.DefaultResolver,
+		prefixes: DefaultParkingPrefixes,
+		mx:       NewDomainSet(DefaultParkingMXDomains...),
+		timeout:  5 * time.Second,
+		cache:    newTTLCache[string, bool](10000, 6*time.Hour),
+	}
+}
+
+// WithResolver returns a copy of the detector using resolver for lookups
+func (p *ParkingDetector) WithResolver(resolver Resolver) *ParkingDetector {
+	c := *p
+	c.resolver = resolver
+	return &c
+}
+
+// WithProviders returns a copy of the detector recognizing the parking
+// services with the given address ranges and MX host domains instead of
+// the defaults. It starts with an empty cache.
+func (p *ParkingDetector) WithProviders(prefixes []netip.Prefix, mxDomains []string) *ParkingDetector {
+	c := *p
+	c.prefixes = prefixes
+	c.mx = NewDomainSet(mxDomains...)
+	c.cache = newTTLCache[string, bool](10000, 6*time.Hour)
+	return &c
+}
+
+// IsParked reports whether domain is parked
+func (p *ParkingDetector) IsParked(ctx context.Context, domain string) (bool, error) {
+	domain = strings.ToLower(domain)
+	if parked, ok := p.cache.get(domain); ok {
+		return parked, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	parked, err := p.detect(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+	p.cache.add(domain, parked)
+	return parked, nil
+}
+
+// CacheStats implements CacheMetrics for the cache of parking outcomes
+func (p *ParkingDetector) CacheStats() CacheStats {
+	return p.cache.stats()
+}
+
+// detect looks for parking MX hosts, then for parking addresses
+func (p *ParkingDetector) detect(ctx context.Context, domain string) (bool, error) {
+	mx, err := p.resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return false, wrapLookupError(err)
+	}
+	for _, record := range mx {
+		if p.mx.Match(strings.TrimSuffix(record.Host, ".")) {
+			return true, nil
+		}
+	}
+
+	addrs, err := p.resolver.LookupIPAddr(ctx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, wrapLookupError(err)
+	}
+	for _, addr := range addrs {
+		ip, ok := netip.AddrFromSlice(addr.IP)
+		if !ok {
+			continue
+		}
+		for _, prefix := range p.prefixes {
+			if prefix.Contains(ip.Unmap()) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,31 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/LBFmuraiybatu/email_validator/testutil"
+)
+
+func TestParkingCheck(t *testing.T) {
+	resolver := testutil.NewResolver()
+	resolver.AddMX("sedo.example", "localhost.sedoparking.com.")
+	resolver.AddIP("parked.example", "185.53.178.7")
+	resolver.AddMX("example.com", "mx.example.com.")
+	v := New(
+		WithDNSCheck(NewDNSChecker().WithResolver(resolver)),
+		WithParkingCheck(NewParkingDetector().WithResolver(resolver)),
+	)
+	for _, email := range []string{"jane@parked.example", "jane@sedo.example"} {
+		r := v.Validate(email)
+		if r.IsValid || r.Reason() != ReasonParkedDomain || r.DNS.Status != StatusFailed {
+			t.Errorf("%s: valid %v, reasons %v, dns %s", email, r.IsValid, r.Reasons(), r.DNS.Status)
+		}
+	}
+	if r := v.Validate("jane@example.com"); !r.IsValid {
+		t.Errorf("live domain: %v", r.Errors)
+	}
+
+	if _, err := (Config{ParkingCheck: true}).Options(); err == nil {
+		t.Error("parking_check without dns_check accepted")
+	}
+}
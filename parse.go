@@ -0,0 +1,39 @@
+package emailvalidator
+
+import "strings"
+
+// ParsedEmail is an address split into its parts. Validate parses each
+// address once and passes the result to every check.
+type ParsedEmail struct {
+	Address string
+	Local   string
+	Domain  string
+	// LowerLocal and LowerDomain are the lower-cased parts, used for
+	// case-insensitive list lookups
+	LowerLocal  string
+	LowerDomain string
+	// TLD is the last label of Domain
+	TLD string
+}
+
+// ParseAddress splits email at its only "@". It reports false when email
+// does not contain exactly one "@"; no other syntax is checked.
+func ParseAddress(email string) (ParsedEmail, bool) {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || strings.IndexByte(domain, '@') >= 0 {
+		return ParsedEmail{Address: email}, false
+	}
+
+	p := ParsedEmail{
+		Address:     email,
+		Local:       local,
+		Domain:      domain,
+		LowerLocal:  strings.ToLower(local),
+		LowerDomain: strings.ToLower(domain),
+		TLD:         domain,
+	}
+	if dot := strings.LastIndexByte(domain, '.'); dot >= 0 {
+		p.TLD = domain[dot+1:]
+	}
+	return p, true
+}
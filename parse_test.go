@@ -0,0 +1,27 @@
+package emailvalidator
+
+import "testing"
+
+func TestParseAddress(t *testing.T) {
+	p, ok := ParseAddress("John.Doe@Mail.Example.COM")
+	if !ok {
+		t.Fatal("expected address to parse")
+	}
+	want := ParsedEmail{
+		Address:     "John.Doe@Mail.Example.COM",
+		Local:       "John.Doe",
+		Domain:      "Mail.Example.COM",
+		LowerLocal:  "john.doe",
+		LowerDomain: "mail.example.com",
+		TLD:         "COM",
+	}
+	if p != want {
+		t.Fatalf("got %+v, want %+v", p, want)
+	}
+
+	for _, email := range []string{"no-at-sign", "a@b@c"} {
+		if _, ok := ParseAddress(email); ok {
+			t.Errorf("ParseAddress(%q) should fail", email)
+		}
+	}
+}
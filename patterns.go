@@ -1,8 +1,12 @@
 package emailvalidator
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
-// Common email patterns for additional validation
+// Common email patterns for additional validation. Matching is safe for
+// concurrent use as long as CommonProviders is not modified meanwhile.
 type EmailPatterns struct {
 	CommonProviders map[string]*regexp.Regexp
 }
@@ -0,0 +1,130 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NameDictionary holds the common given names and surnames of a locale
+type NameDictionary struct {
+	given    map[string]bool
+	surnames map[string]bool
+}
+
+// NewNameDictionary creates a NameDictionary from lists of given names
+// and surnames, matched ignoring case
+func NewNameDictionary(given, surnames []string) *NameDictionary {
+	d := &NameDictionary{given: make(map[string]bool), surnames: make(map[string]bool)}
+	for _, name := range given {
+		d.given[strings.ToLower(name)] = true
+	}
+	for _, name := range surnames {
+		d.surnames[strings.ToLower(name)] = true
+	}
+	return d
+}
+
+// NameLocales returns the locales of the built-in name dictionaries
+func NameLocales() []string {
+	entries, _ := nameData.ReadDir("data/names")
+	locales := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		locales = append(locales, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// LoadNameDictionary returns the built-in name dictionary of locale, one
+// of NameLocales
+func LoadNameDictionary(locale string) (*NameDictionary, error) {
+	data, err := nameData.ReadFile(path.Join("data/names", locale+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("no name dictionary for locale %q", locale)
+	}
+	var given, surnames []string
+	for _, section := range parseSections(string(data)) {
+		switch section.name {
+		case "given":
+			given = append(given, section.words...)
+		case "surname":
+			surnames = append(surnames, section.words...)
+		}
+	}
+	return NewNameDictionary(given, surnames), nil
+}
+
+// PersonalNameDetector tells local parts that look like a person's name,
+// such as jane.doe, jsmith or maria1987, from handles and generated
+// strings. It is safe for concurrent use.
+type PersonalNameDetector struct {
+	names *NameDictionary
+}
+
+// NewPersonalNameDetector creates a PersonalNameDetector knowing the names
+// of dictionaries, or of every built-in locale when there are none
+func NewPersonalNameDetector(dictionaries ...*NameDictionary) *PersonalNameDetector {
+	if len(dictionaries) == 0 {
+		for _, locale := range NameLocales() {
+			d, _ := LoadNameDictionary(locale)
+			dictionaries = append(dictionaries, d)
+		}
+	}
+	merged := &NameDictionary{given: make(map[string]bool), surnames: make(map[string]bool)}
+	for _, d := range dictionaries {
+		for name := range d.given {
+			merged.given[name] = true
+		}
+		for name := range d.surnames {
+			merged.surnames[name] = true
+		}
+	}
+	return &PersonalNameDetector{names: merged}
+}
+
+// IsPersonalName reports whether local looks like a person's name: it has
+// a given name, an initial next to a surname (j.smith, smith.j, jsmith),
+// or a given name run into a surname (janedoe). A "+" tag is ignored.
+func (d *PersonalNameDetector) IsPersonalName(local string) bool {
+	local, _, _ = strings.Cut(strings.ToLower(local), "+")
+	tokens := strings.FieldsFunc(local, func(r rune) bool { return !unicode.IsLetter(r) })
+	for i, token := range tokens {
+		if d.names.given[token] || d.isJoinedName(token) {
+			return true
+		}
+		if d.names.surnames[token] && (isInitial(tokens, i-1) || isInitial(tokens, i+1)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJoinedName reports whether token is an initial or a given name run
+// into a surname
+func (d *PersonalNameDetector) isJoinedName(token string) bool {
+	_, size := utf8.DecodeRuneInString(token)
+	if d.names.surnames[token[size:]] && utf8.RuneCountInString(token[size:]) >= 3 {
+		return true
+	}
+	for i := 2; i < len(token)-1; i++ {
+		if d.names.given[token[:i]] && d.names.surnames[token[i:]] {
+			return true
+		}
+	}
+	return false
+}
+
+// isInitial reports whether tokens[i] exists and is a single letter
+func isInitial(tokens []string, i int) bool {
+	return i >= 0 && i < len(tokens) && utf8.RuneCountInString(tokens[i]) == 1
+}
+
+// checkPersonalName flags whether the local part looks like a name
+func (v *EmailValidator) checkPersonalName(p ParsedEmail, result *ValidationResult) {
+	personal := v.personalNameDetector.IsPersonalName(p.LowerLocal)
+	result.PersonalName = &personal
+}
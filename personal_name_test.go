@@ -0,0 +1,55 @@
+package emailvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPersonalNameDetector(t *testing.T) {
+	d := NewPersonalNameDetector()
+	tests := map[string]bool{
+		"jane.doe":       true,
+		"maria1987":      true,
+		"j.smith":        true,
+		"smith.j":        true,
+		"jsmith":         true,
+		"janedoe":        true,
+		"k.mueller+shop": true,
+		"darkwolf99":     false,
+		"x7k2p9q4zt":     false,
+		"smith":          false,
+		"info":           false,
+		"gamer_4_life":   false,
+	}
+	for local, want := range tests {
+		if got := d.IsPersonalName(local); got != want {
+			t.Errorf("IsPersonalName(%q) = %v, want %v", local, got, want)
+		}
+	}
+
+	custom := NewPersonalNameDetector(NewNameDictionary([]string{"Ngozi"}, []string{"Okafor"}))
+	if !custom.IsPersonalName("n.okafor") || custom.IsPersonalName("jane") {
+		t.Error("custom dictionary not used exclusively")
+	}
+}
+
+func TestNameLocales(t *testing.T) {
+	if got := NameLocales(); !reflect.DeepEqual(got, []string{"de", "en", "es", "fr"}) {
+		t.Errorf("NameLocales() = %v", got)
+	}
+	if _, err := LoadNameDictionary("xx"); err == nil {
+		t.Error("unknown locale loaded")
+	}
+
+	cfg := DefaultConfig()
+	cfg.PersonalNameCheck = true
+	cfg.NameLocales = []string{"de"}
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := v.Validate("klaus.weber@example.com")
+	if r.PersonalName == nil || !*r.PersonalName {
+		t.Errorf("PersonalName = %v", r.PersonalName)
+	}
+}
@@ -0,0 +1,61 @@
+// Package playgroundvalidator registers this module's checks as a custom
+// tag for github.com/go-playground/validator:
+//
+//	validate := validator.New()
+//	validate.RegisterValidation(playgroundvalidator.Tag, playgroundvalidator.Func[validator.FieldLevel]())
+//
+//	type Signup struct {
+//		Email string `validate:"required,realemail=dns nodisposable"`
+//	}
+//
+// The struct validator splits tags on commas, so parameters are separated
+// by spaces, as for its oneof tag. An escaped comma (0x2C) works too.
+//
+// Parameters:
+//
+//	dns           verify that the domain has MX or A records
+//	nodisposable  reject disposable domains
+//	norole        reject role accounts such as admin@ or support@
+//	notypo        reject addresses with a likely domain typo
+//	strict        apply strict syntax rules
+package playgroundvalidator
+
+import (
+	"fmt"
+	"reflect"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/internal/tagrule"
+)
+
+// Tag is the conventional tag name for Func
+const Tag = "realemail"
+
+// FieldLevel is the part of validator.FieldLevel used by Func, declared
+// here so that this package does not depend on the struct validator
+type FieldLevel interface {
+	Field() reflect.Value
+	Param() string
+}
+
+// Func returns a validation function for the struct validator. Validators
+// are derived from a default one configured with opts, once per distinct
+// parameter string. A tag with an unknown parameter panics on first use,
+// like the struct validator's own tags.
+func Func[FL FieldLevel](opts ...emailvalidator.Option) func(FL) bool {
+	rules := tagrule.NewSet(emailvalidator.New(opts...))
+
+	return func(fl FL) bool {
+		field := fl.Field()
+		if field.Kind() != reflect.String {
+			panic(fmt.Sprintf("playgroundvalidator: %s tag on unsupported type %s", Tag, field.Type()))
+		}
+
+		rule, err := rules.Rule(fl.Param())
+		if err != nil {
+			panic(fmt.Sprintf("playgroundvalidator: %s tag: %v", Tag, err))
+		}
+		_, ok, _ := rule.Check(field.String())
+		return ok
+	}
+}
@@ -0,0 +1,48 @@
+package playgroundvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fieldLevel stands in for validator.FieldLevel
+type fieldLevel struct {
+	value any
+	param string
+}
+
+func (f fieldLevel) Field() reflect.Value { return reflect.ValueOf(f.value) }
+func (f fieldLevel) Param() string        { return f.param }
+
+func TestFunc(t *testing.T) {
+	fn := Func[fieldLevel]()
+
+	tests := []struct {
+		email, param string
+		want         bool
+	}{
+		{"jane@example.com", "", true},
+		{"not-an-email", "", false},
+		{"jane@mailinator.com", "", true},
+		{"jane@mailinator.com", "nodisposable", false},
+		{"jane@mailinator.com", "strict,nodisposable", false},
+		{"admin@example.com", "norole", false},
+		{"jane@gmial.com", "", true},
+		{"jane@gmial.com", "norole notypo", false},
+		{"jane@gmail.com", "nodisposable,notypo", true},
+	}
+	for _, tt := range tests {
+		if got := fn(fieldLevel{tt.email, tt.param}); got != tt.want {
+			t.Errorf("%q with %q: got %v, want %v", tt.email, tt.param, got, tt.want)
+		}
+	}
+}
+
+func TestFuncUnknownParam(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown parameter")
+		}
+	}()
+	Func[fieldLevel]()(fieldLevel{"jane@example.com", "nosuch"})
+}
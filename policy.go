@@ -0,0 +1,67 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Policy is how the validator treats addresses of a given kind that are
+// neither right nor wrong in themselves
+type Policy int
+
+const (
+	// PolicyAllow accepts the addresses without a finding
+	PolicyAllow Policy = iota
+	// PolicyWarn accepts the addresses with a warning
+	PolicyWarn
+	// PolicyBlock rejects the addresses
+	PolicyBlock
+)
+
+// String returns the policy name used in configuration files
+func (p Policy) String() string {
+	switch p {
+	case PolicyAllow:
+		return "allow"
+	case PolicyWarn:
+		return "warn"
+	case PolicyBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("policy(%d)", int(p))
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (p Policy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	parsed, err := ParsePolicy(name)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// ParsePolicy parses "allow", "warn" (or "warning") and "block" (or
+// "reject")
+func ParsePolicy(name string) (Policy, error) {
+	switch name {
+	case "allow":
+		return PolicyAllow, nil
+	case "warn", "warning":
+		return PolicyWarn, nil
+	case "block", "reject":
+		return PolicyBlock, nil
+	default:
+		return 0, fmt.Errorf("invalid policy %q", name)
+	}
+}
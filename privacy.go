@@ -0,0 +1,218 @@
+package emailvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// privacyMode hides addresses in what the library emits
+var privacyMode atomic.Bool
+
+// SetPrivacyMode turns privacy mode on or off for the whole process. In
+// privacy mode no address or local part appears in the errors, warnings,
+// trace attributes or metrics emitted by the package and its integrations;
+// they carry masked forms instead. Domains are kept, as they name mail
+// providers rather than people and are needed to operate DNS checks.
+// Validation results still hold the address in fields such as Username,
+// Normalized and Suggestion, which the caller must keep out of logs
+// itself, for example with Redact.
+func SetPrivacyMode(on bool) {
+	privacyMode.Store(on)
+}
+
+// PrivacyMode reports whether privacy mode is on
+func PrivacyMode() bool {
+	return privacyMode.Load()
+}
+
+// Redact returns email as is, or masked as by Mask in privacy mode. Use it
+// wherever an address goes into an error or a log line.
+func Redact(email string) string {
+	if !privacyMode.Load() {
+		return email
+	}
+	return Mask(email)
+}
+
+// redactPart returns part of an address as is, or masked in privacy mode
+func redactPart(part string) string {
+	if !privacyMode.Load() {
+		return part
+	}
+	return maskSubstring(part)
+}
+
+// maskSubstring masks part of an address unless it is only punctuation,
+// such as ".." or "@", which reveals nothing
+func maskSubstring(part string) string {
+	if strings.IndexFunc(part, isLetterOrDigit) < 0 {
+		return part
+	}
+	return maskPart(part)
+}
+
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// hashSalt is the key of Hash, random per process until SetHashSalt
+var hashSalt atomic.Pointer[[]byte]
+
+func init() {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		panic("emailvalidator: no randomness for the hash salt: " + err.Error())
+	}
+	hashSalt.Store(&salt)
+}
+
+// SetHashSalt sets the salt of Hash. Without it, the salt is random per
+// process; set the same secret salt in every process whose hashes are
+// compared, such as the services writing to one log store.
+func SetHashSalt(salt []byte) {
+	salt = append([]byte(nil), salt...)
+	hashSalt.Store(&salt)
+}
+
+// Hash returns the salted SHA-256 (HMAC-SHA256) of the canonical form of
+// email as hex, so that logs can correlate an address without holding it.
+// Spellings with the same Canonical form hash alike.
+func Hash(email string) string {
+	mac := hmac.New(sha256.New, *hashSalt.Load())
+	mac.Write([]byte(Canonical(email)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Mask returns the canonical form of email with the local part and the
+// domain below the top-level domain reduced to their first and last
+// characters, as in j***e@e***e.com, for display to the address owner.
+// The masked parts always have three stars, hiding their length.
+func Mask(email string) string {
+	email = Canonical(email)
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return maskPart(email)
+	}
+	local, domain := email[:at], email[at+1:]
+	if dot := strings.LastIndexByte(domain, '.'); dot > 0 {
+		return maskPart(local) + "@" + maskPart(domain[:dot]) + domain[dot:]
+	}
+	return maskPart(local) + "@" + maskPart(domain)
+}
+
+// maskPart keeps the first and last characters of s, or only the first
+// when s is too short to hide anything between them
+func maskPart(s string) string {
+	runes := []rune(s)
+	switch {
+	case len(runes) == 0:
+		return ""
+	case len(runes) <= 2:
+		return string(runes[0]) + "***"
+	default:
+		return string(runes[0]) + "***" + string(runes[len(runes)-1])
+	}
+}
+
+// Redacted returns a copy of r fit for logs: the address in Normalized,
+// Username and Suggestion is masked as by Mask, error substrings and local
+// part parameters are masked, and the extracted Name is dropped. Domains
+// and everything else are kept. It redacts whether or not privacy mode is
+// on.
+func (r ValidationResult) Redacted() ValidationResult {
+	r.Normalized = maskIfSet(r.Normalized)
+	r.Suggestion = maskIfSet(r.Suggestion)
+	if r.Username != "" {
+		r.Username = maskPart(r.Username)
+	}
+	r.Name = nil
+	r.Errors, r.Warnings = redactFindings(r.Errors, r.Warnings)
+	for _, check := range []*CheckResult{&r.Syntax, &r.DNS, &r.Reputation, &r.Suggestions} {
+		check.Errors, check.Warnings = redactFindings(check.Errors, check.Warnings)
+	}
+	return r
+}
+
+func maskIfSet(email string) string {
+	if email == "" {
+		return ""
+	}
+	return Mask(email)
+}
+
+// redactFindings returns copies of errs and warnings with the parts of the
+// address masked
+func redactFindings(errs []ValidationError, warnings []Warning) ([]ValidationError, []Warning) {
+	if errs != nil {
+		errs = append([]ValidationError(nil), errs...)
+		for i := range errs {
+			errs[i].Substring = maskSubstring(errs[i].Substring)
+			errs[i].Params = redactParams(errs[i].Params)
+		}
+	}
+	if warnings != nil {
+		warnings = append([]Warning(nil), warnings...)
+		for i := range warnings {
+			warnings[i].Params = redactParams(warnings[i].Params)
+		}
+	}
+	return errs, warnings
+}
+
+// redactParams returns params with the local part masked
+func redactParams(params map[string]interface{}) map[string]interface{} {
+	local, ok := params["local_part"].(string)
+	if !ok {
+		return params
+	}
+	redacted := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		redacted[k] = v
+	}
+	redacted["local_part"] = maskSubstring(local)
+	return redacted
+}
+
+// String summarizes the redacted result on one line for logs, as in
+// "invalid j***e@e***e.com score=40 errors=[domain_not_found]"
+func (r ValidationResult) String() string {
+	redacted := r.Redacted()
+	var b strings.Builder
+	b.WriteString(string(r.Verdict))
+	if redacted.Normalized != "" {
+		b.WriteString(" " + redacted.Normalized)
+	}
+	fmt.Fprintf(&b, " score=%g", r.Score)
+	if len(r.Errors) > 0 {
+		codes := make([]string, len(r.Errors))
+		for i, err := range r.Errors {
+			codes[i] = string(err.Code)
+		}
+		fmt.Fprintf(&b, " errors=[%s]", strings.Join(codes, " "))
+	}
+	if len(r.Warnings) > 0 {
+		codes := make([]string, len(r.Warnings))
+		for i, w := range r.Warnings {
+			codes[i] = string(w.Code)
+		}
+		fmt.Fprintf(&b, " warnings=[%s]", strings.Join(codes, " "))
+	}
+	if redacted.Suggestion != "" {
+		b.WriteString(" suggestion=" + redacted.Suggestion)
+	}
+	return b.String()
+}
+
+// plainResult has the fields of ValidationResult without its methods
+type plainResult ValidationResult
+
+// GoString redacts the %#v form too, which would otherwise bypass String
+func (r ValidationResult) GoString() string {
+	return "emailvalidator.ValidationResult" + strings.TrimPrefix(fmt.Sprintf("%#v", plainResult(r.Redacted())), "emailvalidator.plainResult")
+}
@@ -0,0 +1,122 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	h := Hash("Jane.Doe@Example.com ")
+	if len(h) != 64 || h != Hash("jane.doe@example.com") || h == Hash("john@example.com") {
+		t.Errorf("Hash = %q", h)
+	}
+
+	SetHashSalt([]byte("pepper"))
+	defer SetHashSalt([]byte("test"))
+	salted := Hash("jane.doe@example.com")
+	if salted == h || salted != Hash("jane.doe@example.com") {
+		t.Error("salt not applied")
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := map[string]string{
+		"jane@example.com":         "j***e@e***e.com",
+		"Jo@Mail.Example.co.uk":    "j***@m***o.uk",
+		"a@b.io":                   "a***@b***.io",
+		"josé@exämple.de":          "j***é@e***e.de",
+		"jane@localhost":           "j***e@l***t",
+		"not an address":           "n***s",
+		"@example.com":             "@e***e.com",
+		"jane.doe+news@gmail.com ": "j***s@g***l.com",
+	}
+	for email, want := range tests {
+		if got := Mask(email); got != want {
+			t.Errorf("Mask(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestPrivacyMode(t *testing.T) {
+	SetPrivacyMode(true)
+	defer SetPrivacyMode(false)
+
+	tracer := &recordingTracer{}
+	v := New(
+		WithBlockedDomains([]string{"blocked.example"}),
+		WithDNSCheck(NewDNSChecker().WithResolver(staticResolver{})),
+		WithTracer(tracer),
+	)
+	addresses := []string{
+		strings.Repeat("a", 64) + "zyxwvut@example.com",
+		"zyxwvut@" + longDomain(248) + ".com",
+		"zyxwvut@blocked.example",
+		"webmaster@example.com",
+		"zyxwvut..doe@example.com",
+		"zyxwvut@@example.com",
+	}
+	var emitted []string
+	for _, email := range addresses {
+		r := v.Validate(email)
+		for _, err := range r.Errors {
+			emitted = append(emitted, err.Error())
+		}
+		for _, findings := range []interface{}{r.Errors, r.Warnings} {
+			data, err := json.Marshal(findings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			emitted = append(emitted, string(data))
+		}
+	}
+	for _, span := range tracer.spans {
+		emitted = append(emitted, fmt.Sprint(span.attrs))
+	}
+
+	for _, out := range emitted {
+		for _, secret := range []string{"zyxwvut", "webmaster"} {
+			if strings.Contains(out, secret) {
+				t.Errorf("%q leaked in %s", secret, out)
+			}
+		}
+	}
+	if got := Redact("jane@example.com"); got != "j***e@e***e.com" {
+		t.Errorf("Redact = %q", got)
+	}
+	SetPrivacyMode(false)
+	if got := Redact("jane@example.com"); got != "jane@example.com" {
+		t.Errorf("Redact without privacy mode = %q", got)
+	}
+}
+
+func TestResultRedacted(t *testing.T) {
+	v := New(WithNameExtraction(true))
+	r := v.Validate("jane.doe@gmial.com")
+	redacted := r.Redacted()
+	if redacted.Normalized != "j***e@g***l.com" || redacted.Username != "j***e" || redacted.Suggestion != "j***e@g***l.com" || redacted.Name != nil {
+		t.Errorf("Redacted() = %+v", redacted)
+	}
+	if r.Normalized != "jane.doe@gmial.com" || r.Name == nil {
+		t.Error("Redacted modified the result")
+	}
+
+	role := New().Validate("webmaster@example.com")
+	invalid := New().Validate(strings.Repeat("a", 60) + "janedoe@example.com")
+	for _, r := range []ValidationResult{r, role, invalid} {
+		for _, out := range []string{r.String(), fmt.Sprintf("%v", r), fmt.Sprintf("%+v", r), fmt.Sprintf("%#v", r)} {
+			for _, secret := range []string{"jane", "webmaster"} {
+				if strings.Contains(out, secret) {
+					t.Errorf("%q leaked in %s", secret, out)
+				}
+			}
+		}
+	}
+	if got := r.String(); got != "valid j***e@g***l.com score=100 suggestion=j***e@g***l.com" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := invalid.String(); got != "invalid a***e@e***e.com score=0 errors=[local_part_too_long]" {
+		t.Errorf("String() = %q", got)
+	}
+}
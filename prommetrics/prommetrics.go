@@ -0,0 +1,227 @@
+// Package prommetrics exports validation metrics in the Prometheus text
+// format:
+//
+//	metrics := prommetrics.New()
+//	metrics.RegisterCache("results", cache)
+//	v := emailvalidator.New(emailvalidator.WithMetrics(metrics))
+//	http.Handle("/metrics", metrics)
+//
+// It writes the exposition format itself rather than depending on the
+// Prometheus client library, so it has no dependencies beyond this module.
+package prommetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// DefaultBuckets are the latency histogram bounds in seconds
+var DefaultBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics implements emailvalidator.Metrics and serves the collected
+// metrics over HTTP
+type Metrics struct {
+	mu       sync.Mutex
+	verdicts map[emailvalidator.Verdict]uint64
+	duration *histogram
+	stages   map[string]*histogram
+	statuses map[stageStatus]uint64
+	caches   map[string]emailvalidator.CacheMetrics
+}
+
+// stageStatus labels the outcome counter
+type stageStatus struct {
+	stage  string
+	status emailvalidator.CheckStatus
+}
+
+// New creates an empty Metrics
+func New() *Metrics {
+	return &Metrics{
+		verdicts: make(map[emailvalidator.Verdict]uint64),
+		duration: newHistogram(DefaultBuckets),
+		stages:   make(map[string]*histogram),
+		statuses: make(map[stageStatus]uint64),
+		caches:   make(map[string]emailvalidator.CacheMetrics),
+	}
+}
+
+// RegisterCache adds a cache layer to the exported cache metrics, labelled
+// with name
+func (m *Metrics) RegisterCache(name string, cache emailvalidator.CacheMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.caches[name] = cache
+}
+
+// ObserveValidation implements emailvalidator.Metrics
+func (m *Metrics) ObserveValidation(result emailvalidator.ValidationResult, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.verdicts[result.Verdict]++
+	m.duration.observe(elapsed.Seconds())
+	for _, stage := range stagesOf(result) {
+		m.statuses[stageStatus{stage.name, stage.check.Status}]++
+		if stage.check.Status == emailvalidator.StatusSkipped {
+			continue
+		}
+		h, ok := m.stages[stage.name]
+		if !ok {
+			h = newHistogram(DefaultBuckets)
+			m.stages[stage.name] = h
+		}
+		h.observe(stage.check.Duration.Seconds())
+	}
+}
+
+// namedCheck pairs a stage with its name
+type namedCheck struct {
+	name  string
+	check emailvalidator.CheckResult
+}
+
+// stagesOf lists the stages of result
+func stagesOf(result emailvalidator.ValidationResult) []namedCheck {
+	return []namedCheck{
+		{"syntax", result.Syntax},
+		{"dns", result.DNS},
+		{"reputation", result.Reputation},
+		{"suggestions", result.Suggestions},
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text format to out
+func (m *Metrics) Write(out io.Writer) error {
+	w := bufio.NewWriter(out)
+	m.write(w)
+	return w.Flush()
+}
+
+// write writes the metrics to w
+func (m *Metrics) write(w *bufio.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP emailvalidator_validations_total Validations by verdict.")
+	fmt.Fprintln(w, "# TYPE emailvalidator_validations_total counter")
+	for _, verdict := range sortedKeys(m.verdicts) {
+		fmt.Fprintf(w, "emailvalidator_validations_total{verdict=%q} %d\n", verdict, m.verdicts[verdict])
+	}
+
+	fmt.Fprintln(w, "# HELP emailvalidator_validation_duration_seconds Time spent validating one address.")
+	fmt.Fprintln(w, "# TYPE emailvalidator_validation_duration_seconds histogram")
+	m.duration.write(w, "emailvalidator_validation_duration_seconds", "")
+
+	fmt.Fprintln(w, "# HELP emailvalidator_stage_duration_seconds Time spent in each validation stage that ran.")
+	fmt.Fprintln(w, "# TYPE emailvalidator_stage_duration_seconds histogram")
+	for _, stage := range sortedKeys(m.stages) {
+		m.stages[stage].write(w, "emailvalidator_stage_duration_seconds", fmt.Sprintf("stage=%q", stage))
+	}
+
+	fmt.Fprintln(w, "# HELP emailvalidator_stage_results_total Validation stage outcomes by status.")
+	fmt.Fprintln(w, "# TYPE emailvalidator_stage_results_total counter")
+	keys := make([]stageStatus, 0, len(m.statuses))
+	for key := range m.statuses {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].stage != keys[j].stage {
+			return keys[i].stage < keys[j].stage
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, key := range keys {
+		fmt.Fprintf(w, "emailvalidator_stage_results_total{stage=%q,status=%q} %d\n", key.stage, key.status, m.statuses[key])
+	}
+
+	if len(m.caches) == 0 {
+		return
+	}
+	stats := make(map[string]emailvalidator.CacheStats, len(m.caches))
+	for name, cache := range m.caches {
+		stats[name] = cache.CacheStats()
+	}
+	names := sortedKeys(stats)
+	for _, metric := range []struct {
+		name, kind, help string
+		value            func(emailvalidator.CacheStats) string
+	}{
+		{"cache_hits_total", "counter", "Cache lookups answered from the cache.", func(s emailvalidator.CacheStats) string { return strconv.FormatUint(s.Hits, 10) }},
+		{"cache_misses_total", "counter", "Cache lookups that had to do the work.", func(s emailvalidator.CacheStats) string { return strconv.FormatUint(s.Misses, 10) }},
+		{"cache_evictions_total", "counter", "Cache entries removed for space or age.", func(s emailvalidator.CacheStats) string { return strconv.FormatUint(s.Evictions, 10) }},
+		{"cache_entries", "gauge", "Entries currently held by the cache.", func(s emailvalidator.CacheStats) string { return strconv.Itoa(s.Entries) }},
+	} {
+		fmt.Fprintf(w, "# HELP emailvalidator_%s %s\n", metric.name, metric.help)
+		fmt.Fprintf(w, "# TYPE emailvalidator_%s %s\n", metric.name, metric.kind)
+		for _, name := range names {
+			fmt.Fprintf(w, "emailvalidator_%s{cache=%q} %s\n", metric.name, name, metric.value(stats[name]))
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// histogram is a cumulative Prometheus histogram
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	h.count++
+	h.sum += v
+	i := sort.SearchFloat64s(h.bounds, v)
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+}
+
+// write writes the histogram series with the given extra labels
+func (h *histogram) write(w *bufio.Writer, name, labels string) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{%s%sle=%q} %d\n", name, labels, sep, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, h.count)
+}
+
+var _ emailvalidator.Metrics = (*Metrics)(nil)
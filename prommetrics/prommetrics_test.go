@@ -0,0 +1,47 @@
+package prommetrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := New()
+	cache := emailvalidator.NewResultCache(10, time.Minute)
+	metrics.RegisterCache("results", cache)
+
+	v := emailvalidator.Cached(emailvalidator.New(emailvalidator.WithMetrics(metrics)), cache)
+	v.Validate("jane@example.com")
+	v.Validate("jane@example.com")
+	v.Validate("not-an-email")
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`emailvalidator_validations_total{verdict="invalid"} 1`,
+		`emailvalidator_validations_total{verdict="valid"} 1`,
+		`emailvalidator_validation_duration_seconds_bucket{le="+Inf"} 2`,
+		`emailvalidator_validation_duration_seconds_count 2`,
+		`emailvalidator_stage_duration_seconds_count{stage="syntax"} 2`,
+		`emailvalidator_stage_results_total{stage="dns",status="skipped"} 2`,
+		`emailvalidator_stage_results_total{stage="syntax",status="failed"} 1`,
+		`emailvalidator_cache_hits_total{cache="results"} 1`,
+		`emailvalidator_cache_entries{cache="results"} 2`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("missing %s in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `stage="dns"}`) {
+		t.Error("skipped stages should not record latencies")
+	}
+	if strings.Contains(body, "jane") || strings.Contains(body, "not-an-email") {
+		t.Error("addresses should not appear in metrics")
+	}
+}
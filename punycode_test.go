@@ -0,0 +1,35 @@
+package emailvalidator
+
+import "testing"
+
+func TestPunycodeRoundTrip(t *testing.T) {
+	tests := map[string]string{
+		"bücher":  "bcher-kva",
+		"München": "Mnchen-3ya",
+		"i❤":      "i-7iq",
+		"☃-⌘":     "--dqo34k",
+		"😀":       "e28h",
+		"例え":      "r8jz45g",
+	}
+	for label, encoded := range tests {
+		if got := punycodeEncode(label); got != encoded {
+			t.Errorf("punycodeEncode(%q) = %q, want %q", label, got, encoded)
+		}
+		if got, err := punycodeDecode(encoded); err != nil || got != label {
+			t.Errorf("punycodeDecode(%q) = %q, %v, want %q", encoded, got, err, label)
+		}
+	}
+
+	for _, invalid := range []string{"99999999999", "a-!", "ü-abc", "abc-9"} {
+		if got, err := punycodeDecode(invalid); err == nil {
+			t.Errorf("punycodeDecode(%q) = %q, want error", invalid, got)
+		}
+	}
+}
+
+func TestDomainToUnicode(t *testing.T) {
+	got, err := domainToUnicode("mail.xn--i-7iq.ws")
+	if err != nil || got != "mail.i❤.ws" {
+		t.Errorf("domainToUnicode = %q, %v", got, err)
+	}
+}
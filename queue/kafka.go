@@ -0,0 +1,49 @@
+//go:build kafka
+
+package queue
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConsumer returns a Consumer fetching from r, committing the offset
+// of each message once its result is published. r must belong to a
+// consumer group.
+func KafkaConsumer(r *kafka.Reader) Consumer {
+	return kafkaConsumer{r}
+}
+
+type kafkaConsumer struct {
+	reader *kafka.Reader
+}
+
+// Receive implements Consumer
+func (c kafkaConsumer) Receive(ctx context.Context) (Delivery, error) {
+	m, err := c.reader.FetchMessage(ctx)
+	if err != nil {
+		return Delivery{}, err
+	}
+	return Delivery{
+		Message: Message{Key: m.Key, Value: m.Value},
+		Ack: func(ctx context.Context) error {
+			return c.reader.CommitMessages(ctx, m)
+		},
+	}, nil
+}
+
+// KafkaPublisher returns a Publisher writing with w, whose Topic names the
+// output topic
+func KafkaPublisher(w *kafka.Writer) Publisher {
+	return kafkaPublisher{w}
+}
+
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// Publish implements Publisher
+func (p kafkaPublisher) Publish(ctx context.Context, msg Message) error {
+	return p.writer.WriteMessages(ctx, kafka.Message{Key: msg.Key, Value: msg.Value})
+}
@@ -0,0 +1,62 @@
+//go:build nats
+
+package queue
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSConsumer returns a Consumer fetching from a JetStream pull
+// subscription, acknowledging each message once its result is published
+func NATSConsumer(sub *nats.Subscription) Consumer {
+	return natsConsumer{sub}
+}
+
+type natsConsumer struct {
+	sub *nats.Subscription
+}
+
+// Receive implements Consumer
+func (c natsConsumer) Receive(ctx context.Context) (Delivery, error) {
+	for {
+		msgs, err := c.sub.Fetch(1, nats.Context(ctx))
+		if err != nil {
+			return Delivery{}, err
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+		m := msgs[0]
+		return Delivery{
+			Message: Message{Key: []byte(m.Header.Get(nats.MsgIdHdr)), Value: m.Data},
+			Ack: func(ctx context.Context) error {
+				return m.AckSync(nats.Context(ctx))
+			},
+		}, nil
+	}
+}
+
+// NATSPublisher returns a Publisher sending to subject through js. Message
+// keys are sent as the Nats-Msg-Id header, so JetStream drops results
+// published twice.
+func NATSPublisher(js nats.JetStreamContext, subject string) Publisher {
+	return natsPublisher{js, subject}
+}
+
+type natsPublisher struct {
+	js      nats.JetStreamContext
+	subject string
+}
+
+// Publish implements Publisher
+func (p natsPublisher) Publish(ctx context.Context, msg Message) error {
+	m := nats.NewMsg(p.subject)
+	m.Data = msg.Value
+	if len(msg.Key) > 0 {
+		m.Header.Set(nats.MsgIdHdr, string(msg.Key))
+	}
+	_, err := p.js.PublishMsg(m, nats.Context(ctx))
+	return err
+}
@@ -0,0 +1,245 @@
+// Package queue runs the bulk engine as a message queue consumer: addresses
+// are read from an input queue or topic and the results are published to an
+// output one, as in CRM hygiene pipelines.
+//
+//	runner := queue.New(bulk.New(v), consumer, publisher)
+//	err := runner.Run(ctx)
+//
+// A message holds one address, either as plain text, as a JSON string, or
+// as a JSON object with the address in its "email" field. Each result is
+// published as a JSON Output with the key of its input message, and the
+// input is acknowledged only once its result is published, so delivery is
+// at least once.
+//
+// Adapters for Kafka (github.com/segmentio/kafka-go), NATS JetStream
+// (github.com/nats-io/nats.go) and SQS (github.com/aws/aws-sdk-go-v2) are
+// built with the kafka, nats and sqs build tags respectively, as this
+// module does not require those clients.
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/bulk"
+)
+
+// DefaultRunSize is the default number of messages per bulk run
+const DefaultRunSize = 10000
+
+// Message is a message read from or published to a queue
+type Message struct {
+	Key   []byte
+	Value []byte
+}
+
+// Delivery is a received message. Ack is called once the result of the
+// message has been published; deliveries are acknowledged in the order
+// they were received.
+type Delivery struct {
+	Message
+	Ack func(ctx context.Context) error
+}
+
+// Consumer reads messages from the input queue or topic
+type Consumer interface {
+	// Receive blocks until a message is available or ctx is done
+	Receive(ctx context.Context) (Delivery, error)
+}
+
+// Publisher writes messages to the output queue or topic
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Output is the JSON encoding of a published result
+type Output struct {
+	Email string `json:"email"`
+	// Input holds the input message when it was a JSON object
+	Input  json.RawMessage                 `json:"input,omitempty"`
+	Result emailvalidator.ValidationResult `json:"result"`
+}
+
+// Option configures a Runner
+type Option func(*Runner)
+
+// WithField sets the field holding the address in JSON object messages.
+// The default is "email".
+func WithField(name string) Option {
+	return func(r *Runner) {
+		r.field = name
+	}
+}
+
+// WithRunSize sets how many messages are validated per bulk run. Domain
+// lookups are shared within a run, so smaller runs see DNS changes sooner
+// and hold less memory. The default is DefaultRunSize.
+func WithRunSize(n int64) Option {
+	return func(r *Runner) {
+		r.runSize = n
+	}
+}
+
+// WithSummary calls fn with the summary of every completed bulk run
+func WithSummary(fn func(bulk.Summary)) Option {
+	return func(r *Runner) {
+		r.onSummary = fn
+	}
+}
+
+// Runner consumes addresses and publishes their results. The bulk job
+// should not use bulk.WithDedupe, which would hold every address of a run
+// in memory and publish duplicates without validating them again.
+type Runner struct {
+	job       *bulk.Job
+	consumer  Consumer
+	publisher Publisher
+
+	field     string
+	runSize   int64
+	onSummary func(bulk.Summary)
+}
+
+// New creates a Runner validating the messages of c with job and
+// publishing the results to p
+func New(job *bulk.Job, c Consumer, p Publisher, opts ...Option) *Runner {
+	r := &Runner{job: job, consumer: c, publisher: p, field: "email", runSize: DefaultRunSize}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.runSize <= 0 {
+		r.runSize = DefaultRunSize
+	}
+	return r
+}
+
+// Run consumes messages until ctx is cancelled or the consumer or
+// publisher fails. Results completed before cancellation are still
+// published and acknowledged; it then returns ctx.Err().
+func (r *Runner) Run(ctx context.Context) error {
+	for {
+		summary, err := r.run(ctx)
+		if r.onSummary != nil && summary.Processed > 0 {
+			r.onSummary(summary)
+		}
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// run validates one run's worth of messages
+func (r *Runner) run(ctx context.Context) (bulk.Summary, error) {
+	// A failing sink cancels the run, so that the source stops waiting
+	// for messages
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	src := &source{runner: r, ctx: runCtx, pending: make(map[int64]Delivery)}
+	return r.job.Run(ctx, src, &sink{ctx: ctx, publisher: r.publisher, source: src, cancel: cancel})
+}
+
+// source reads one run's worth of messages
+type source struct {
+	runner *Runner
+	ctx    context.Context
+
+	mu      sync.Mutex
+	next    int64
+	pending map[int64]Delivery
+}
+
+// Next implements bulk.Source
+func (s *source) Next() (bulk.Record, error) {
+	if s.next >= s.runner.runSize {
+		return bulk.Record{}, io.EOF
+	}
+	d, err := s.runner.consumer.Receive(s.ctx)
+	if err != nil {
+		if s.ctx.Err() != nil {
+			return bulk.Record{}, s.ctx.Err()
+		}
+		return bulk.Record{}, fmt.Errorf("receiving message: %w", err)
+	}
+
+	record := parse(d.Value, s.runner.field)
+	s.mu.Lock()
+	s.pending[s.next] = d
+	s.next++
+	s.mu.Unlock()
+	return record, nil
+}
+
+// take removes the delivery of the record at index
+func (s *source) take(index int64) Delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.pending[index]
+	delete(s.pending, index)
+	return d
+}
+
+// sink publishes results and acknowledges their input
+type sink struct {
+	ctx       context.Context
+	publisher Publisher
+	source    *source
+	cancel    context.CancelFunc
+}
+
+// Write implements bulk.Sink
+func (s *sink) Write(item bulk.Item) error {
+	err := s.write(s.source.take(item.Index), item)
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
+
+// write publishes the result of item and acknowledges d
+func (s *sink) write(d Delivery, item bulk.Item) error {
+	value, err := json.Marshal(Output{Email: item.Email, Input: item.Raw, Result: item.Result})
+	if err != nil {
+		return err
+	}
+	// Results of a cancelled run are still published, so the context used
+	// here outlives the cancellation
+	ctx := context.WithoutCancel(s.ctx)
+	if err := s.publisher.Publish(ctx, Message{Key: d.Key, Value: value}); err != nil {
+		return fmt.Errorf("publishing result: %w", err)
+	}
+	if d.Ack != nil {
+		if err := d.Ack(ctx); err != nil {
+			return fmt.Errorf("acknowledging message: %w", err)
+		}
+	}
+	return nil
+}
+
+// parse extracts the address from a message value
+func parse(value []byte, field string) bulk.Record {
+	value = bytes.TrimSpace(value)
+	switch {
+	case len(value) > 0 && value[0] == '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err == nil {
+			var email string
+			json.Unmarshal(fields[field], &email)
+			return bulk.Record{Email: email, Raw: json.RawMessage(value)}
+		}
+	case len(value) > 0 && value[0] == '"':
+		var email string
+		if err := json.Unmarshal(value, &email); err == nil {
+			return bulk.Record{Email: email}
+		}
+	}
+	return bulk.Record{Email: string(value)}
+}
@@ -0,0 +1,111 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/bulk"
+)
+
+// chanQueue is an in-memory queue recording acknowledgements
+type chanQueue struct {
+	messages chan Message
+
+	mu    sync.Mutex
+	acked []string
+}
+
+func (q *chanQueue) Receive(ctx context.Context) (Delivery, error) {
+	select {
+	case m := <-q.messages:
+		return Delivery{Message: m, Ack: func(context.Context) error {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.acked = append(q.acked, string(m.Key))
+			return nil
+		}}, nil
+	case <-ctx.Done():
+		return Delivery{}, ctx.Err()
+	}
+}
+
+type collector struct {
+	mu       sync.Mutex
+	messages []Message
+	fail     error
+}
+
+func (c *collector) Publish(ctx context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fail != nil {
+		return c.fail
+	}
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+func (c *collector) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.messages)
+}
+
+func TestRunner(t *testing.T) {
+	in := &chanQueue{messages: make(chan Message, 10)}
+	for i, value := range []string{`jane@example.com`, `"bad@@example.com"`, `{"id":7,"address":"joe@example.org"}`} {
+		in.messages <- Message{Key: []byte(fmt.Sprint(i)), Value: []byte(value)}
+	}
+	out := &collector{}
+
+	var runs int
+	runner := New(bulk.New(emailvalidator.New()), in, out,
+		WithField("address"), WithRunSize(2), WithSummary(func(bulk.Summary) { runs++ }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- runner.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for out.len() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v", err)
+	}
+
+	if len(out.messages) != 3 || fmt.Sprint(in.acked) != "[0 1 2]" || runs != 2 {
+		t.Fatalf("published %d, acked %v, runs %d", len(out.messages), in.acked, runs)
+	}
+	want := []struct {
+		key, email string
+		valid      bool
+	}{{"0", "jane@example.com", true}, {"1", "bad@@example.com", false}, {"2", "joe@example.org", true}}
+	for i, w := range want {
+		var output Output
+		if err := json.Unmarshal(out.messages[i].Value, &output); err != nil {
+			t.Fatal(err)
+		}
+		if string(out.messages[i].Key) != w.key || output.Email != w.email || output.Result.IsValid != w.valid {
+			t.Errorf("message %d: key %s, %+v", i, out.messages[i].Key, output)
+		}
+	}
+}
+
+func TestRunnerPublishFailure(t *testing.T) {
+	in := &chanQueue{messages: make(chan Message, 1)}
+	in.messages <- Message{Key: []byte("0"), Value: []byte("jane@example.com")}
+	out := &collector{fail: errors.New("broker down")}
+
+	err := New(bulk.New(emailvalidator.New()), in, out).Run(context.Background())
+	if err == nil || len(in.acked) != 0 {
+		t.Fatalf("Run returned %v, acked %v", err, in.acked)
+	}
+}
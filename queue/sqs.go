@@ -0,0 +1,85 @@
+//go:build sqs
+
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// SQSConsumer returns a Consumer long-polling queueURL, deleting each
+// message once its result is published. Messages are received ten at a
+// time; the queue's visibility timeout should cover their validation.
+func SQSConsumer(client *sqs.Client, queueURL string) Consumer {
+	return &sqsConsumer{client: client, queueURL: queueURL}
+}
+
+type sqsConsumer struct {
+	client   *sqs.Client
+	queueURL string
+
+	mu       sync.Mutex
+	buffered []types.Message
+}
+
+// Receive implements Consumer
+func (c *sqsConsumer) Receive(ctx context.Context) (Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.buffered) == 0 {
+		out, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(c.queueURL),
+			MaxNumberOfMessages: 10,
+			WaitTimeSeconds:     20,
+		})
+		if err != nil {
+			return Delivery{}, err
+		}
+		c.buffered = out.Messages
+	}
+
+	m := c.buffered[0]
+	c.buffered = c.buffered[1:]
+	return Delivery{
+		Message: Message{Key: []byte(aws.ToString(m.MessageId)), Value: []byte(aws.ToString(m.Body))},
+		Ack: func(ctx context.Context) error {
+			_, err := c.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(c.queueURL),
+				ReceiptHandle: m.ReceiptHandle,
+			})
+			return err
+		},
+	}, nil
+}
+
+// SQSPublisher returns a Publisher sending to queueURL. Message keys are
+// ignored, except on FIFO queues where they deduplicate results published
+// twice.
+func SQSPublisher(client *sqs.Client, queueURL string, fifo bool) Publisher {
+	return sqsPublisher{client, queueURL, fifo}
+}
+
+type sqsPublisher struct {
+	client   *sqs.Client
+	queueURL string
+	fifo     bool
+}
+
+// Publish implements Publisher
+func (p sqsPublisher) Publish(ctx context.Context, msg Message) error {
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(p.queueURL),
+		MessageBody: aws.String(string(msg.Value)),
+	}
+	if p.fifo && len(msg.Key) > 0 {
+		input.MessageGroupId = aws.String("emailvalidate")
+		input.MessageDeduplicationId = aws.String(string(msg.Key))
+	}
+	_, err := p.client.SendMessage(ctx, input)
+	return err
+}
@@ -0,0 +1,153 @@
+package emailvalidator
+
+// Reason is a coarse, stable category explaining a finding. Every check
+// maps its error and warning codes onto the same reasons, so that results
+// can be grouped and acted on the same way whichever stage produced them.
+type Reason string
+
+// Reasons reported by ValidationResult.Reasons. NullMX, MailboxNotFound
+// and Greylisted are not produced by the built-in checks; they are reserved
+// for mailbox verification stages. MailboxFull and Rejected are only given
+// by ClassifyBounce.
+const (
+	// ReasonInvalidSyntax: the address is malformed or exceeds a length limit
+	ReasonInvalidSyntax Reason = "invalid_syntax"
+	// ReasonBlockedDomain: the domain is on the blocklist
+	ReasonBlockedDomain Reason = "blocked_domain"
+	// ReasonTLDNotAllowed: the top-level domain is not on the allowlist
+	ReasonTLDNotAllowed Reason = "tld_not_allowed"
+	// ReasonUnusualTLD: the top-level domain is of a category flagged by a
+	// TLD policy
+	ReasonUnusualTLD Reason = "unusual_tld"
+	// ReasonEmojiDomain: the domain contains emoji, valid in Punycode but
+	// unusual
+	ReasonEmojiDomain Reason = "emoji_domain"
+	// ReasonNoMX: the domain does not exist or has neither MX nor A records
+	ReasonNoMX Reason = "no_mx"
+	// ReasonNullMX: the domain publishes a null MX record (RFC 7505)
+	ReasonNullMX Reason = "null_mx"
+	// ReasonParkedDomain: the domain is parked with a parking service
+	ReasonParkedDomain Reason = "parked_domain"
+	// ReasonDNSUnavailable: the DNS check could not be completed
+	ReasonDNSUnavailable Reason = "dns_unavailable"
+	// ReasonMixedScript: the local part mixes scripts, such as Latin and
+	// Cyrillic lookalikes
+	ReasonMixedScript Reason = "mixed_script"
+	// ReasonDisposable: the domain belongs to a disposable provider
+	ReasonDisposable Reason = "disposable"
+	// ReasonPrivacyRelay: the address is an alias at a privacy relay service
+	// such as Apple's Hide My Email
+	ReasonPrivacyRelay Reason = "privacy_relay"
+	// ReasonRoleAccount: the local part names a role, such as admin@
+	ReasonRoleAccount Reason = "role_account"
+	// ReasonPossibleTypo: the domain looks like a typo of a common one
+	ReasonPossibleTypo Reason = "possible_typo"
+	// ReasonCatchAll: the server accepts mail for any local part
+	ReasonCatchAll Reason = "catch_all"
+	// ReasonMailboxNotFound: the server rejected the mailbox
+	ReasonMailboxNotFound Reason = "mailbox_not_found"
+	// ReasonGreylisted: the server deferred the recipient check
+	ReasonGreylisted Reason = "greylisted"
+	// ReasonMailboxFull: the mailbox exists but is over its quota
+	ReasonMailboxFull Reason = "mailbox_full"
+	// ReasonRejected: the server refused the message by policy, such as a
+	// spam filter or a block of the sender, rather than for the address
+	ReasonRejected Reason = "rejected"
+	// ReasonOther: a finding of a custom rule with an unknown code
+	ReasonOther Reason = "other"
+)
+
+// errorReasons maps error codes to their reasons
+var errorReasons = map[ErrorCode]Reason{
+	CodeInvalidFormat:            ReasonInvalidSyntax,
+	CodeInvisibleChars:           ReasonInvalidSyntax,
+	CodeEmailTooLong:             ReasonInvalidSyntax,
+	CodeLocalPartEmpty:           ReasonInvalidSyntax,
+	CodeLocalPartTooLong:         ReasonInvalidSyntax,
+	CodeLocalPartConsecutiveDots: ReasonInvalidSyntax,
+	CodeLocalPartDotBoundary:     ReasonInvalidSyntax,
+	CodeLocalPartInvalidChars:    ReasonInvalidSyntax,
+	CodeDomainEmpty:              ReasonInvalidSyntax,
+	CodeDomainTooLong:            ReasonInvalidSyntax,
+	CodeDomainTooFewLabels:       ReasonInvalidSyntax,
+	CodeDomainLabelEmpty:         ReasonInvalidSyntax,
+	CodeDomainLabelTooLong:       ReasonInvalidSyntax,
+	CodeDomainLabelHyphen:        ReasonInvalidSyntax,
+	CodeDomainInvalidChars:       ReasonInvalidSyntax,
+	CodeDomainBlocked:            ReasonBlockedDomain,
+	CodeTLDNotAllowed:            ReasonTLDNotAllowed,
+	CodeTLDNumeric:               ReasonInvalidSyntax,
+	CodeTLDTooShort:              ReasonInvalidSyntax,
+	CodeDisposable:               ReasonDisposable,
+	CodeDomainNotFound:           ReasonNoMX,
+	CodeDomainParked:             ReasonParkedDomain,
+	CodePrivacyRelay:             ReasonPrivacyRelay,
+	CodeEmojiDomain:              ReasonEmojiDomain,
+}
+
+// warningReasons maps warning codes to their reasons
+var warningReasons = map[WarningCode]Reason{
+	WarnDisposable:       ReasonDisposable,
+	WarnRoleAccount:      ReasonRoleAccount,
+	WarnDNSUnavailable:   ReasonDNSUnavailable,
+	WarnPrivacyRelay:     ReasonPrivacyRelay,
+	WarnTLDCategory:      ReasonUnusualTLD,
+	WarnEmojiDomain:      ReasonEmojiDomain,
+	WarnMixedScript:      ReasonMixedScript,
+	WarnLocalPartDots:    ReasonInvalidSyntax,
+	WarnDomainUnderscore: ReasonInvalidSyntax,
+}
+
+// Reason returns the reason of the error code, ReasonOther for codes of
+// custom rules
+func (c ErrorCode) Reason() Reason {
+	if reason, ok := errorReasons[c]; ok {
+		return reason
+	}
+	return ReasonOther
+}
+
+// Reason returns the reason of the warning code
+func (c WarningCode) Reason() Reason {
+	if reason, ok := warningReasons[c]; ok {
+		return reason
+	}
+	return ReasonOther
+}
+
+// Reasons returns the distinct reasons of the result's errors, then of its
+// warnings, then ReasonPossibleTypo when there is a suggestion and
+// ReasonCatchAll when the domain accepts any local part
+func (r ValidationResult) Reasons() []Reason {
+	var reasons []Reason
+	add := func(reason Reason) {
+		for _, seen := range reasons {
+			if seen == reason {
+				return
+			}
+		}
+		reasons = append(reasons, reason)
+	}
+	for _, err := range r.Errors {
+		add(err.Code.Reason())
+	}
+	for _, w := range r.Warnings {
+		add(w.Code.Reason())
+	}
+	if r.Suggestion != "" {
+		add(ReasonPossibleTypo)
+	}
+	if r.AcceptAll {
+		add(ReasonCatchAll)
+	}
+	return reasons
+}
+
+// Reason returns the most significant reason of the result, the first of
+// Reasons, or an empty Reason when there is none
+func (r ValidationResult) Reason() Reason {
+	if reasons := r.Reasons(); len(reasons) > 0 {
+		return reasons[0]
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package emailvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReasons(t *testing.T) {
+	v := New(WithBlockedDomains([]string{"blocked.example"}), WithDisposableCheck(SeverityWarning))
+	tests := []struct {
+		email string
+		want  []Reason
+	}{
+		{"jane@example.com", nil},
+		{"jane..doe@example.com", []Reason{ReasonInvalidSyntax}},
+		{"not-an-address", []Reason{ReasonInvalidSyntax}},
+		{"jane@blocked.example", []Reason{ReasonBlockedDomain}},
+		{"admin@mailinator.com", []Reason{ReasonDisposable, ReasonRoleAccount}},
+		{"admin@gmial.com", []Reason{ReasonRoleAccount, ReasonPossibleTypo}},
+	}
+	for _, tt := range tests {
+		r := v.Validate(tt.email)
+		if got := r.Reasons(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.email, got, tt.want)
+		}
+		if want := Reason(""); len(tt.want) > 0 {
+			want = tt.want[0]
+			if r.Reason() != want {
+				t.Errorf("%s: primary reason %q", tt.email, r.Reason())
+			}
+		}
+	}
+
+	if got := ErrorCode("custom_rule").Reason(); got != ReasonOther {
+		t.Errorf("custom code: %q", got)
+	}
+}
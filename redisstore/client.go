@@ -0,0 +1,271 @@
+package redisstore
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Nil is returned by Do when the reply is a null bulk string, as for GET
+// on a missing key
+var Nil = errors.New("redis: nil")
+
+// Error is an error reply from the server
+type Error string
+
+// Error implements error
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithPassword authenticates new connections with AUTH
+func WithPassword(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithDB selects database db on new connections
+func WithDB(db int) Option {
+	return func(c *Client) {
+		c.db = db
+	}
+}
+
+// WithTimeout bounds each command, including dialling. The default is one
+// second, so that a slow Redis degrades to cache misses instead of stalling
+// validations.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithPoolSize sets how many idle connections are kept. The default is 8.
+func WithPoolSize(n int) Option {
+	return func(c *Client) {
+		c.idle = make(chan *conn, n)
+	}
+}
+
+// Client is a minimal Redis client speaking RESP2 over a pool of
+// connections. It is safe for concurrent use.
+type Client struct {
+	addr               string
+	username, password string
+	db                 int
+	timeout            time.Duration
+
+	idle chan *conn
+}
+
+// New creates a Client for the server at addr. Connections are dialled
+// on first use.
+func New(addr string, opts ...Option) *Client {
+	c := &Client{addr: addr, timeout: time.Second, idle: make(chan *conn, 8)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Open creates a Client from a URL of the form
+// redis://[[user]:password@]host[:port][/db]
+func Open(rawurl string) (*Client, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("redisstore: unsupported scheme %q", u.Scheme)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	var opts []Option
+	if password, ok := u.User.Password(); ok {
+		opts = append(opts, WithPassword(u.User.Username(), password))
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		db, err := strconv.Atoi(path)
+		if err != nil {
+			return nil, fmt.Errorf("redisstore: invalid database %q", path)
+		}
+		opts = append(opts, WithDB(db))
+	}
+	return New(addr, opts...), nil
+}
+
+// Close closes the idle connections
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// Do runs one command and returns its reply: a string for simple and bulk
+// strings, an int64 for integers and a []interface{} for arrays. Error
+// replies are returned as Error, and null replies as Nil.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cn.do(ctx, args)
+	var replyErr Error
+	if err != nil && !errors.Is(err, Nil) && !errors.As(err, &replyErr) {
+		// The connection is in an unknown state
+		cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+// get returns an idle connection or dials a new one
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	cn := &conn{Conn: nc, reader: bufio.NewReader(nc)}
+	if c.password != "" {
+		args := []string{"AUTH", c.password}
+		if c.username != "" {
+			args = []string{"AUTH", c.username, c.password}
+		}
+		if _, err := cn.do(ctx, args); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if _, err := cn.do(ctx, []string{"SELECT", strconv.Itoa(c.db)}); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+// put returns cn to the pool, closing it when the pool is full
+func (c *Client) put(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+// conn is one connection to the server
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// do writes a command and reads its reply
+func (cn *conn) do(ctx context.Context, args []string) (interface{}, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		cn.SetDeadline(deadline)
+	} else {
+		cn.SetDeadline(time.Time{})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := cn.Write([]byte(b.String())); err != nil {
+		return nil, err
+	}
+	return cn.read()
+}
+
+// read parses one reply
+func (cn *conn) read() (interface{}, error) {
+	line, err := cn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("redisstore: malformed reply %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, Error(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, Nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(cn.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, Nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			item, err := cn.read()
+			var replyErr Error
+			switch {
+			case errors.As(err, &replyErr):
+				// Keep reading so that the connection stays usable
+				item = replyErr
+			case err != nil && !errors.Is(err, Nil):
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redisstore: unknown reply type %q", kind)
+}
@@ -0,0 +1,184 @@
+// Package redisstore keeps validator state in Redis, so that a fleet of
+// validator servers shares one view of it:
+//
+//	client, err := redisstore.Open("redis://cache:6379/0")
+//	checker := emailvalidator.NewDNSChecker().
+//		WithStore(redisstore.NewDomainCache(client, "ev:", time.Hour))
+//	blocked, err := redisstore.NewBlocklist(client, "ev:blocked").Load(ctx)
+//	v := emailvalidator.Cached(
+//		emailvalidator.New(
+//			emailvalidator.WithDNSCheck(checker),
+//			emailvalidator.WithBlockedDomainSet(blocked),
+//		),
+//		redisstore.NewResultCache(client, "ev:", 24*time.Hour),
+//	)
+//
+// Results and domain outcomes expire through Redis TTLs. The caches treat
+// Redis failures as misses, so an unavailable Redis slows validations
+// down instead of failing them.
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// ResultCache is an emailvalidator.ResultStore keeping results in Redis
+// under prefix + "result:" + canonical address
+type ResultCache struct {
+	client *Client
+	prefix string
+	ttl    time.Duration
+
+	hits, misses atomic.Uint64
+}
+
+var (
+	_ emailvalidator.ResultStore  = (*ResultCache)(nil)
+	_ emailvalidator.DomainStore  = (*DomainCache)(nil)
+	_ emailvalidator.CacheMetrics = (*ResultCache)(nil)
+	_ emailvalidator.CacheMetrics = (*DomainCache)(nil)
+)
+
+// NewResultCache creates a ResultCache keeping each result for ttl. A ttl
+// of zero keeps results until Redis evicts them.
+func NewResultCache(client *Client, prefix string, ttl time.Duration) *ResultCache {
+	return &ResultCache{client: client, prefix: prefix, ttl: ttl}
+}
+
+// Get implements emailvalidator.ResultStore
+func (c *ResultCache) Get(email string) (emailvalidator.ValidationResult, bool) {
+	var result emailvalidator.ValidationResult
+	value, ok := get(c.client, c.prefix+"result:"+emailvalidator.Canonical(email))
+	if ok && json.Unmarshal([]byte(value), &result) == nil {
+		c.hits.Add(1)
+		return result, true
+	}
+	c.misses.Add(1)
+	return emailvalidator.ValidationResult{}, false
+}
+
+// Add implements emailvalidator.ResultStore
+func (c *ResultCache) Add(email string, result emailvalidator.ValidationResult) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	set(c.client, c.prefix+"result:"+emailvalidator.Canonical(email), string(data), c.ttl)
+}
+
+// CacheStats implements emailvalidator.CacheMetrics. Entries and evictions
+// are kept by Redis and reported as zero.
+func (c *ResultCache) CacheStats() emailvalidator.CacheStats {
+	return emailvalidator.CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// DomainCache is an emailvalidator.DomainStore keeping domain outcomes in
+// Redis under prefix + "domain:" + domain
+type DomainCache struct {
+	client *Client
+	prefix string
+	ttl    time.Duration
+
+	hits, misses atomic.Uint64
+}
+
+// NewDomainCache creates a DomainCache keeping each outcome for ttl
+func NewDomainCache(client *Client, prefix string, ttl time.Duration) *DomainCache {
+	return &DomainCache{client: client, prefix: prefix, ttl: ttl}
+}
+
+// LookupDomain implements emailvalidator.DomainStore
+func (c *DomainCache) LookupDomain(domain string) (valid, ok bool) {
+	value, ok := get(c.client, c.prefix+"domain:"+domain)
+	if !ok {
+		c.misses.Add(1)
+		return false, false
+	}
+	c.hits.Add(1)
+	return value == "1", true
+}
+
+// StoreDomain implements emailvalidator.DomainStore
+func (c *DomainCache) StoreDomain(domain string, valid bool) {
+	value := "0"
+	if valid {
+		value = "1"
+	}
+	set(c.client, c.prefix+"domain:"+domain, value, c.ttl)
+}
+
+// CacheStats implements emailvalidator.CacheMetrics. Entries and evictions
+// are kept by Redis and reported as zero.
+func (c *DomainCache) CacheStats() emailvalidator.CacheStats {
+	return emailvalidator.CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// Blocklist is a set of blocked domains kept in the Redis set at key, for
+// operators to maintain one list for the whole fleet
+type Blocklist struct {
+	client *Client
+	key    string
+}
+
+// NewBlocklist creates a Blocklist stored at key
+func NewBlocklist(client *Client, key string) *Blocklist {
+	return &Blocklist{client: client, key: key}
+}
+
+// Add adds domains to the list
+func (b *Blocklist) Add(ctx context.Context, domains ...string) error {
+	if len(domains) == 0 {
+		return nil
+	}
+	_, err := b.client.Do(ctx, append([]string{"SADD", b.key}, domains...)...)
+	return err
+}
+
+// Remove removes domains from the list
+func (b *Blocklist) Remove(ctx context.Context, domains ...string) error {
+	if len(domains) == 0 {
+		return nil
+	}
+	_, err := b.client.Do(ctx, append([]string{"SREM", b.key}, domains...)...)
+	return err
+}
+
+// Load returns the current list, for emailvalidator.WithBlockedDomainSet.
+// Validators keep the set they were built with, so servers pick up changes
+// when they rebuild their validator.
+func (b *Blocklist) Load(ctx context.Context) (*emailvalidator.DomainSet, error) {
+	reply, err := b.client.Do(ctx, "SMEMBERS", b.key)
+	if err != nil {
+		return nil, err
+	}
+	members, _ := reply.([]interface{})
+	domains := make([]string, 0, len(members))
+	for _, member := range members {
+		if domain, ok := member.(string); ok {
+			domains = append(domains, domain)
+		}
+	}
+	return emailvalidator.NewDomainSet(domains...), nil
+}
+
+// get returns the string at key, reporting failures as misses
+func get(client *Client, key string) (string, bool) {
+	reply, err := client.Do(context.Background(), "GET", key)
+	value, ok := reply.(string)
+	return value, err == nil && ok
+}
+
+// set stores value at key for ttl, ignoring failures
+func set(client *Client, key, value string, ttl time.Duration) {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	client.Do(context.Background(), args...)
+}
@@ -0,0 +1,202 @@
+package redisstore
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// fakeRedis serves the few commands used by this package from memory
+type fakeRedis struct {
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]bool
+	ttls    map[string]string
+}
+
+func startFake(t *testing.T) (*fakeRedis, string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	f := &fakeRedis{strings: map[string]string{}, sets: map[string]map[string]bool{}, ttls: map[string]string{}}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(c)
+		}
+	}()
+	return f, ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+		for i := range args {
+			header, _ := r.ReadString('\n')
+			size, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+			buf := make([]byte, size+2)
+			io.ReadFull(r, buf)
+			args[i] = string(buf[:size])
+		}
+		io.WriteString(c, f.do(args))
+	}
+}
+
+func (f *fakeRedis) do(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		value, ok := f.strings[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		f.strings[args[1]] = args[2]
+		if len(args) == 5 {
+			f.ttls[args[1]] = args[3] + " " + args[4]
+		}
+		return "+OK\r\n"
+	case "SADD", "SREM":
+		set := f.sets[args[1]]
+		if set == nil {
+			set = map[string]bool{}
+			f.sets[args[1]] = set
+		}
+		for _, member := range args[2:] {
+			if strings.ToUpper(args[0]) == "SADD" {
+				set[member] = true
+			} else {
+				delete(set, member)
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", len(args)-2)
+	case "SMEMBERS":
+		reply := fmt.Sprintf("*%d\r\n", len(f.sets[args[1]]))
+		for member := range f.sets[args[1]] {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(member), member)
+		}
+		return reply
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+func TestResultCache(t *testing.T) {
+	fake, addr := startFake(t)
+	client := New(addr)
+	defer client.Close()
+
+	cache := NewResultCache(client, "ev:", time.Hour)
+	var calls int
+	v := emailvalidator.Cached(emailvalidator.ValidatorFunc(func(email string) emailvalidator.ValidationResult {
+		calls++
+		return emailvalidator.New().Validate(email)
+	}), cache)
+
+	first := v.Validate("Jane@Example.com")
+	// A second instance sharing the Redis sees the first one's result
+	other := emailvalidator.Cached(emailvalidator.New(), NewResultCache(New(addr), "ev:", time.Hour))
+	second := other.Validate("jane@example.com")
+
+	if calls != 1 || !second.IsValid || second.Domain != first.Domain {
+		t.Fatalf("calls %d, second %+v", calls, second)
+	}
+	if ttl := fake.ttls["ev:result:jane@example.com"]; ttl != "PX 3600000" {
+		t.Errorf("ttl %q", ttl)
+	}
+	if stats := cache.CacheStats(); stats.Hits != 0 || stats.Misses != 1 {
+		t.Errorf("stats %+v", stats)
+	}
+}
+
+func TestDomainCacheAndBlocklist(t *testing.T) {
+	_, addr := startFake(t)
+	client := New(addr)
+	defer client.Close()
+	ctx := context.Background()
+
+	domains := NewDomainCache(client, "ev:", time.Hour)
+	if _, ok := domains.LookupDomain("example.com"); ok {
+		t.Fatal("unexpected hit")
+	}
+	domains.StoreDomain("example.com", true)
+	domains.StoreDomain("nowhere.invalid", false)
+	if valid, ok := domains.LookupDomain("example.com"); !ok || !valid {
+		t.Errorf("example.com: %v %v", valid, ok)
+	}
+	if valid, ok := domains.LookupDomain("nowhere.invalid"); !ok || valid {
+		t.Errorf("nowhere.invalid: %v %v", valid, ok)
+	}
+
+	blocklist := NewBlocklist(client, "ev:blocked")
+	if err := blocklist.Add(ctx, "spam.example", "junk.example"); err != nil {
+		t.Fatal(err)
+	}
+	if err := blocklist.Remove(ctx, "junk.example"); err != nil {
+		t.Fatal(err)
+	}
+	set, err := blocklist.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Len() != 1 {
+		t.Errorf("loaded %d domains", set.Len())
+	}
+
+	var replyErr Error
+	if _, err := client.Do(ctx, "FLUSHALL"); !errors.As(err, &replyErr) {
+		t.Errorf("FLUSHALL: %v", err)
+	}
+	// The connection survives an error reply
+	if _, ok := domains.LookupDomain("example.com"); !ok {
+		t.Error("miss after error reply")
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cache := NewResultCache(New(addr, WithTimeout(100*time.Millisecond)), "ev:", 0)
+	if _, ok := cache.Get("jane@example.com"); ok {
+		t.Fatal("hit without Redis")
+	}
+	cache.Add("jane@example.com", emailvalidator.ValidationResult{})
+}
+
+func TestOpen(t *testing.T) {
+	c, err := Open("redis://:secret@cache/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.addr != "cache:6379" || c.password != "secret" || c.db != 2 {
+		t.Errorf("got %+v", c)
+	}
+	if _, err := Open("http://cache"); err == nil {
+		t.Error("accepted http URL")
+	}
+}
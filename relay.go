@@ -0,0 +1,44 @@
+package emailvalidator
+
+import "strings"
+
+// relayDomains maps the domains of privacy relay services, which forward
+// to a real mailbox behind a per-site alias, to the service name
+var relayDomains = map[string]string{
+	"privaterelay.appleid.com": "apple",
+	"mozmail.com":              "firefox",
+	"duck.com":                 "duckduckgo",
+	"simplelogin.com":          "simplelogin",
+	"simplelogin.co":           "simplelogin",
+	"aleeas.com":               "simplelogin",
+	"slmail.me":                "simplelogin",
+	"anonaddy.com":             "addy",
+	"anonaddy.me":              "addy",
+	"addy.io":                  "addy",
+}
+
+// relayService returns the privacy relay service of the lower-cased domain
+// or one of its parents, or an empty string
+func relayService(lowerDomain string) string {
+	for {
+		if service, ok := relayDomains[lowerDomain]; ok {
+			return service
+		}
+		dot := strings.IndexByte(lowerDomain, '.')
+		if dot < 0 {
+			return ""
+		}
+		lowerDomain = lowerDomain[dot+1:]
+	}
+}
+
+// checkPrivacyRelay applies the privacy relay policy to an address at a
+// relay service
+func (v *EmailValidator) checkPrivacyRelay(s *stage, p ParsedEmail) {
+	switch v.relayPolicy {
+	case PolicyWarn:
+		s.addWarning(newWarning(WarnPrivacyRelay, SeverityWarning, "Privacy relay address detected").withParam("domain", p.Domain))
+	case PolicyBlock:
+		s.addError(newError(CodePrivacyRelay, FieldDomain, ErrPrivacyRelay, "privacy relay addresses are not allowed").withParam("domain", p.Domain).at(len(p.Local)+1, p.Domain))
+	}
+}
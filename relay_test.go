@@ -0,0 +1,46 @@
+package emailvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPrivacyRelay(t *testing.T) {
+	tests := []struct {
+		policy  Policy
+		valid   bool
+		reasons []Reason
+	}{
+		{PolicyAllow, true, nil},
+		{PolicyWarn, true, []Reason{ReasonPrivacyRelay}},
+		{PolicyBlock, false, []Reason{ReasonPrivacyRelay}},
+	}
+	for _, tt := range tests {
+		r := New(WithPrivacyRelayPolicy(tt.policy)).Validate("x7k2p9q4zt@privaterelay.appleid.com")
+		if r.PrivacyRelay != "apple" || r.IsValid != tt.valid || len(r.Reasons()) != len(tt.reasons) ||
+			len(tt.reasons) > 0 && r.Reason() != tt.reasons[0] {
+			t.Errorf("%s: relay %q, valid %v, reasons %v", tt.policy, r.PrivacyRelay, r.IsValid, r.Reasons())
+		}
+		if tt.policy == PolicyBlock && !errors.Is(r.Err(), ErrPrivacyRelay) {
+			t.Errorf("block: error %v", r.Err())
+		}
+	}
+
+	if r := New().Validate("jane@alias.anonaddy.com"); r.PrivacyRelay != "addy" {
+		t.Errorf("addy subdomain: relay %q", r.PrivacyRelay)
+	}
+	if r := New().Validate("jane@example.com"); r.PrivacyRelay != "" {
+		t.Errorf("example.com: relay %q", r.PrivacyRelay)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, p := range []Policy{PolicyAllow, PolicyWarn, PolicyBlock} {
+		if parsed, err := ParsePolicy(p.String()); err != nil || parsed != p {
+			t.Errorf("ParsePolicy(%q) = %v, %v", p, parsed, err)
+		}
+	}
+	if _, err := ParsePolicy("maybe"); err == nil {
+		t.Error("ParsePolicy accepted maybe")
+	}
+}
@@ -0,0 +1,145 @@
+package emailvalidator
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReloadInterval is the polling interval of Watch when it is given
+// none
+const DefaultReloadInterval = 5 * time.Second
+
+// Reloader keeps a validator in sync with a configuration file. The active
+// validator is swapped atomically, so each validation sees one consistent
+// snapshot of the configuration and lists.
+type Reloader struct {
+	path    string
+	current atomic.Pointer[EmailValidator]
+
+	mu      sync.Mutex
+	modTime time.Time
+	onError func(error)
+	// loaded is the modification time of the active configuration
+	loaded time.Time
+}
+
+// NewReloader loads the configuration at path and returns a Reloader
+// serving a validator built from it
+func NewReloader(path string) (*Reloader, error) {
+	r := &Reloader{path: path}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// OnError registers a callback for reload failures during Watch. Failed
+// reloads keep the previous validator active.
+func (r *Reloader) OnError(fn func(error)) *Reloader {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onError = fn
+	return r
+}
+
+// Validator returns the currently active validator
+func (r *Reloader) Validator() *EmailValidator {
+	return r.current.Load()
+}
+
+// Validate validates email with the currently active validator
+func (r *Reloader) Validate(email string) ValidationResult {
+	return r.Validator().Validate(email)
+}
+
+// ValidateContext validates email with the currently active validator,
+// stopping DNS lookups when ctx is done
+func (r *Reloader) ValidateContext(ctx context.Context, email string) ValidationResult {
+	return r.Validator().ValidateContext(ctx, email)
+}
+
+// Reload re-reads the configuration and swaps in a new validator
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reloadLocked()
+}
+
+// ModTime returns the modification time of the configuration file the
+// active validator was built from, which tells how fresh its lists are
+func (r *Reloader) ModTime() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loaded
+}
+
+func (r *Reloader) reloadLocked() error {
+	modTime := r.modTime
+	if info, err := os.Stat(r.path); err == nil {
+		modTime = info.ModTime()
+		r.modTime = modTime
+	}
+
+	cfg, err := LoadConfig(r.path)
+	if err != nil {
+		return err
+	}
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	r.current.Store(v)
+	r.loaded = modTime
+	return nil
+}
+
+// Watch reloads the configuration whenever the process receives SIGHUP,
+// where there are signals, or the file's modification time changes,
+// polling every interval. An interval of zero or less polls every
+// DefaultReloadInterval. It blocks until ctx is cancelled.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	hup := make(chan os.Signal, 1)
+	if reloadSignal != nil {
+		signal.Notify(hup, reloadSignal)
+		defer signal.Stop(hup)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reloadAndReport(true)
+		case <-ticker.C:
+			r.reloadAndReport(false)
+		}
+	}
+}
+
+// reloadAndReport reloads when forced or when the file changed on disk
+func (r *Reloader) reloadAndReport(force bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !force {
+		info, err := os.Stat(r.path)
+		if err != nil || info.ModTime().Equal(r.modTime) {
+			return
+		}
+	}
+
+	if err := r.reloadLocked(); err != nil && r.onError != nil {
+		r.onError(err)
+	}
+}
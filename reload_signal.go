@@ -0,0 +1,11 @@
+//go:build !js && !wasip1
+
+package emailvalidator
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignal makes Watch reload the configuration
+var reloadSignal os.Signal = syscall.SIGHUP
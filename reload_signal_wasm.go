@@ -0,0 +1,8 @@
+//go:build js || wasip1
+
+package emailvalidator
+
+import "os"
+
+// reloadSignal is nil where there are no signals
+var reloadSignal os.Signal
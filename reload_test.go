@@ -0,0 +1,72 @@
+package emailvalidator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReloaderSwapsValidator(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "blocked_domains: [spam.com]\n")
+
+	r, err := NewReloader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Validate("user@spam.com").IsValid {
+		t.Fatal("expected spam.com to be blocked")
+	}
+
+	if err := os.WriteFile(path, []byte("blocked_domains: [other.com]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Validate("user@spam.com").IsValid || r.Validate("user@other.com").IsValid {
+		t.Error("reload did not apply the new block list")
+	}
+}
+
+func TestReloaderKeepsPreviousOnError(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "blocked_domains: [spam.com]\n")
+	r, err := NewReloader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := r.ModTime()
+
+	if err := os.WriteFile(path, []byte("disposable_check: sometimes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	future := loaded.Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected reload error")
+	}
+	if r.Validate("user@spam.com").IsValid {
+		t.Error("failed reload replaced the active validator")
+	}
+	if !r.ModTime().Equal(loaded) {
+		t.Error("failed reload changed the active configuration's ModTime")
+	}
+}
+
+func TestReloaderWatchNonPositiveInterval(t *testing.T) {
+	r, err := NewReloader(writeConfig(t, "config.yaml", "strict_mode: true\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Watch(ctx, 0)
+	}()
+	cancel()
+	<-done
+}
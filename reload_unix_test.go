@@ -0,0 +1,49 @@
+//go:build unix
+
+package emailvalidator
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloaderWatchOnSIGHUP(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "blocked_domains: [spam.com]\n")
+	r, err := NewReloader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.Watch(ctx, time.Hour)
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	if err := os.WriteFile(path, []byte("blocked_domains: [other.com]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Give Watch time to register its signal handler
+	time.Sleep(50 * time.Millisecond)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !r.Validate("user@spam.com").IsValid {
+		if time.Now().After(deadline) {
+			t.Fatal("SIGHUP did not trigger a reload")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
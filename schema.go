@@ -0,0 +1,86 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ResultSchemaVersion is the version of the ValidationResult JSON encoding.
+//
+// The encoding follows these compatibility rules:
+//   - the minor version is bumped when fields or enum values are added;
+//     consumers must ignore unknown fields
+//   - the major version is bumped when a field is renamed, removed or changes
+//     type, or when the meaning of an existing value changes
+//
+// Version 1.0 contains:
+//
+//	schema_version  string   always present
+//	is_valid        bool     always present
+//	errors          array    optional, entries: code, field, message, params, offset, substring, rule
+//	warnings        array    optional, entries: code, severity (info|warn|error), message, params
+//	normalized      string   optional
+//	domain          string   optional
+//	username        string   optional
+//	suggestion      string   optional
+//	score           number   always present, 0–100
+//	verdict         string   always present: valid, risky, invalid or unknown
+//	has_gravatar    bool     optional
+//	breached        bool     optional
+//	breaches        array    optional, breach names
+//	reasons         array    optional, Reason values
+//	domain_reputation
+//	                object   optional: score, listings, registered, mx, spf,
+//	                         dmarc, wildcard, incomplete
+//	organization    object   optional: name, domain, type, provider
+//	mail_hosting    object   optional: hosts (host, ip, asn, as_org, country),
+//	                         countries
+//	accept_all      bool     optional
+//	privacy_relay   string   optional, relay service name
+//	alumni_forwarder
+//	                bool     optional
+//	likely_disposable
+//	                number   optional, 0–1
+//	tld_risk        number   optional, 0–1
+//	local_part      object   optional: length, digit_ratio, separators, entropy
+//	name            object   optional: first, last, confidence
+//	personal_name   bool     optional
+//	syntax, dns, reputation, suggestions
+//	                object   always present: status, duration_ns, errors, warnings
+const ResultSchemaVersion = "1.0"
+
+// MarshalJSON encodes the result together with its schema version and
+// reasons
+func (r ValidationResult) MarshalJSON() ([]byte, error) {
+	type result ValidationResult
+	return json.Marshal(struct {
+		SchemaVersion string `json:"schema_version"`
+		result
+		Reasons []Reason `json:"reasons,omitempty"`
+	}{ResultSchemaVersion, result(r), r.Reasons()})
+}
+
+// UnmarshalJSON decodes a result, rejecting incompatible schema versions.
+// Documents without a schema_version are treated as the current version.
+func (r *ValidationResult) UnmarshalJSON(data []byte) error {
+	type result ValidationResult
+	decoded := struct {
+		SchemaVersion string `json:"schema_version"`
+		*result
+	}{result: (*result)(r)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.SchemaVersion != "" && schemaMajor(decoded.SchemaVersion) != schemaMajor(ResultSchemaVersion) {
+		return fmt.Errorf("unsupported result schema version %q (supported: %s)", decoded.SchemaVersion, ResultSchemaVersion)
+	}
+	return nil
+}
+
+// schemaMajor returns the major component of a schema version
+func schemaMajor(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
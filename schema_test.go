@@ -0,0 +1,79 @@
+package emailvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// stableResult clears timings so that results can be compared across runs
+func stableResult(r ValidationResult) ValidationResult {
+	for _, check := range []*CheckResult{&r.Syntax, &r.DNS, &r.Reputation, &r.Suggestions} {
+		check.Duration = 0
+	}
+	return r
+}
+
+func TestResultJSONGolden(t *testing.T) {
+	v := New(WithDisposableCheck(SeverityWarning))
+	results := []ValidationResult{
+		stableResult(v.Validate("admin@gmial.com")),
+		stableResult(v.Validate("user..name@mailinator.com")),
+		stableResult(v.Validate("invalid-email")),
+	}
+
+	got, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", "result_v1.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("result JSON changed; bump ResultSchemaVersion if intended and run go test -update\ngot:\n%s", got)
+	}
+}
+
+func TestResultJSONRoundTrip(t *testing.T) {
+	original := stableResult(New().Validate("admin@gmial.com"))
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ValidationResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// Params decode as float64, so compare the re-encoded form
+	again, _ := json.Marshal(decoded)
+	if !reflect.DeepEqual(data, again) {
+		t.Errorf("round trip mismatch:\n%s\n%s", data, again)
+	}
+}
+
+func TestResultJSONRejectsNewerMajor(t *testing.T) {
+	var r ValidationResult
+	if err := json.Unmarshal([]byte(`{"schema_version":"2.0","is_valid":true}`), &r); err == nil {
+		t.Error("expected an error for an unsupported major version")
+	}
+	if err := json.Unmarshal([]byte(`{"schema_version":"1.7","is_valid":true,"new_field":1}`), &r); err != nil || !r.IsValid {
+		t.Errorf("expected newer minor versions to decode, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package emailvalidator
+
+import "strings"
+
+// Verdict summarizes a validation result for decision making
+type Verdict string
+
+// Verdicts reported in ValidationResult.Verdict
+const (
+	// VerdictValid means the address passed every check and scored well
+	VerdictValid Verdict = "valid"
+	// VerdictRisky means the address is well-formed but carries risk signals
+	VerdictRisky Verdict = "risky"
+	// VerdictInvalid means the address is malformed or cannot receive mail
+	VerdictInvalid Verdict = "invalid"
+	// VerdictUnknown means a verification step could not reach a conclusion
+	VerdictUnknown Verdict = "unknown"
+)
+
+// RiskyScoreThreshold is the score below which a valid address is risky
+const RiskyScoreThreshold = 70
+
+// ScoreWeights are the points deducted from a perfect score of 100 when a
+// signal is negative
+type ScoreWeights struct {
+	Syntax     float64 `json:"syntax"`
+	DNS        float64 `json:"dns"`
+	Disposable float64 `json:"disposable"`
+	Role       float64 `json:"role"`
+	Pattern    float64 `json:"pattern"`
+	// TLD is deducted in proportion to the risk of the top-level domain
+	// when WithTLDRisk is set
+	TLD float64 `json:"tld"`
+}
+
+// DefaultScoreWeights returns the weights used unless WithScoreWeights is set
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		Syntax:     100,
+		DNS:        100,
+		Disposable: 50,
+		Role:       20,
+		Pattern:    15,
+		TLD:        30,
+	}
+}
+
+// signalState is the outcome of a single scoring signal
+type signalState int
+
+const (
+	signalUnknown signalState = iota
+	signalPass
+	signalFail
+)
+
+// signals collects the outcomes that feed the score
+type signals struct {
+	syntax     signalState
+	dns        signalState
+	disposable signalState
+	role       signalState
+	pattern    signalState
+
+	// likelyDisposable is the probability that an unlisted domain is
+	// disposable, deducting that share of the disposable weight
+	likelyDisposable float64
+	// tldRisk is the 0–1 risk of the top-level domain, deducting that share
+	// of the TLD weight
+	tldRisk float64
+
+	// incomplete is set when a verification stage could not run to completion
+	incomplete bool
+}
+
+// score computes the 0–100 score and verdict from signals. Unknown signals
+// neither add nor deduct points.
+func (w ScoreWeights) score(s signals) (float64, Verdict) {
+	score := 100.0
+	deduct := func(state signalState, weight float64) {
+		if state == signalFail {
+			score -= weight
+		}
+	}
+	deduct(s.syntax, w.Syntax)
+	deduct(s.dns, w.DNS)
+	deduct(s.disposable, w.Disposable)
+	if s.disposable != signalFail {
+		score -= s.likelyDisposable * w.Disposable
+	}
+	score -= s.tldRisk * w.TLD
+	deduct(s.role, w.Role)
+	deduct(s.pattern, w.Pattern)
+	if score < 0 {
+		score = 0
+	}
+
+	switch {
+	case s.syntax == signalFail || s.dns == signalFail:
+		return score, VerdictInvalid
+	case score < RiskyScoreThreshold:
+		return score, VerdictRisky
+	case s.incomplete:
+		return score, VerdictUnknown
+	default:
+		return score, VerdictValid
+	}
+}
+
+// stateOf converts a failing condition into a signal state
+func stateOf(failed bool) signalState {
+	if failed {
+		return signalFail
+	}
+	return signalPass
+}
+
+// isSuspiciousPattern reports whether localPart looks like a test, demo or
+// purely numeric throwaway account
+func isSuspiciousPattern(localPart string) bool {
+	lower := strings.ToLower(localPart)
+	if strings.HasPrefix(lower, "test") || strings.HasPrefix(lower, "demo") {
+		return true
+	}
+	for _, char := range lower {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return lower != ""
+}
@@ -0,0 +1,37 @@
+package emailvalidator
+
+import "testing"
+
+func TestScoreAndVerdict(t *testing.T) {
+	v := New()
+
+	testCases := []struct {
+		email   string
+		score   float64
+		verdict Verdict
+	}{
+		{"jane.doe@example.com", 100, VerdictValid},
+		{"admin@example.com", 80, VerdictValid},
+		{"jane@mailinator.com", 50, VerdictRisky},
+		{"test@mailinator.com", 35, VerdictRisky},
+		{"invalid-email", 0, VerdictInvalid},
+		{"user..name@example.com", 0, VerdictInvalid},
+	}
+
+	for _, tc := range testCases {
+		result := v.Validate(tc.email)
+		if result.Score != tc.score || result.Verdict != tc.verdict {
+			t.Errorf("%s: got score %v verdict %s, want %v %s", tc.email, result.Score, result.Verdict, tc.score, tc.verdict)
+		}
+	}
+}
+
+func TestScoreWeightsOverride(t *testing.T) {
+	weights := DefaultScoreWeights()
+	weights.Role = 40
+
+	result := New(WithScoreWeights(weights)).Validate("admin@example.com")
+	if result.Score != 60 || result.Verdict != VerdictRisky {
+		t.Errorf("got score %v verdict %s, want 60 risky", result.Score, result.Verdict)
+	}
+}
@@ -0,0 +1,81 @@
+package emailvalidator
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// scriptNames are the names of unicode.Scripts in a fixed order
+var scriptNames = func() []string {
+	names := make([]string, 0, len(unicode.Scripts))
+	for name := range unicode.Scripts {
+		if name != "Common" && name != "Inherited" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}()
+
+// LocalPartScripts returns the Unicode scripts of the letters of a local
+// part, such as Latin and Cyrillic, in order of appearance. Digits,
+// punctuation and combining marks belong to no script.
+func LocalPartScripts(local string) []string {
+	var scripts []string
+	add := func(script string) {
+		for _, seen := range scripts {
+			if seen == script {
+				return
+			}
+		}
+		scripts = append(scripts, script)
+	}
+	for _, r := range local {
+		if script := runeScript(r); script != "" {
+			add(script)
+		}
+	}
+	return scripts
+}
+
+// runeScript returns the script of the letter r, or "" for other runes
+func runeScript(r rune) string {
+	switch {
+	case r < utf8.RuneSelf:
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return "Latin"
+		}
+	case unicode.IsLetter(r):
+		for _, name := range scriptNames {
+			if unicode.Is(unicode.Scripts[name], r) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// mixesScripts reports whether local has letters of more than one
+// script, without building the list of LocalPartScripts
+func mixesScripts(local string) bool {
+	first := ""
+	for _, r := range local {
+		switch script := runeScript(r); {
+		case script == "" || script == first:
+		case first == "":
+			first = script
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// checkMixedScript warns about local parts mixing scripts, as lookalike
+// letters of another script evade deduplication and blocklists
+func (v *EmailValidator) checkMixedScript(s *stage, p ParsedEmail) {
+	if mixesScripts(p.Local) {
+		s.addWarning(newWarning(WarnMixedScript, SeverityWarning, "Local part mixes scripts").withParam("scripts", LocalPartScripts(p.Local)))
+	}
+}
@@ -0,0 +1,43 @@
+package emailvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocalPartScripts(t *testing.T) {
+	tests := map[string][]string{
+		"jane.doe42": {"Latin"},
+		"jаne":       {"Latin", "Cyrillic"}, // Cyrillic а
+		"δοκιμή":     {"Greek"},
+		"用户":         {"Han"},
+		"user.用户":    {"Latin", "Han"},
+		"1234":       nil,
+	}
+	for local, want := range tests {
+		if got := LocalPartScripts(local); !reflect.DeepEqual(got, want) {
+			t.Errorf("LocalPartScripts(%q) = %v, want %v", local, got, want)
+		}
+		if got := mixesScripts(local); got != (len(want) > 1) {
+			t.Errorf("mixesScripts(%q) = %v", local, got)
+		}
+	}
+}
+
+func TestMixedScriptWarning(t *testing.T) {
+	if New().Validate("jаne@example.com").IsValid {
+		t.Error("non-ASCII local part accepted without SMTPUTF8")
+	}
+
+	v := New(WithSMTPUTF8(true))
+	r := v.Validate("jаne@example.com")
+	if !r.IsValid || r.Reason() != ReasonMixedScript {
+		t.Fatalf("valid %v, reasons %v", r.IsValid, r.Reasons())
+	}
+	if scripts := r.Warnings[0].Params["scripts"]; !reflect.DeepEqual(scripts, []string{"Latin", "Cyrillic"}) {
+		t.Errorf("scripts = %v", scripts)
+	}
+	if r := v.Validate("δοκιμή@example.com"); !r.IsValid || len(r.Warnings) != 0 {
+		t.Errorf("single script: valid %v, warnings %v", r.IsValid, r.Warnings)
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKey grants one client access to the API. Zero limits are unlimited.
+type APIKey struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// RateLimit is the sustained number of requests per second, with
+	// bursts of up to Burst requests
+	RateLimit float64 `json:"rate_limit,omitempty"`
+	Burst     int     `json:"burst,omitempty"`
+	// Quota is the number of addresses the key may validate per
+	// QuotaPeriod, which defaults to a day. Each address of a batch counts.
+	Quota       int64    `json:"quota,omitempty"`
+	QuotaPeriod Duration `json:"quota_period,omitempty"`
+}
+
+// Duration is a time.Duration encoded in JSON as a string such as "24h"
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// LoadAPIKeys reads a JSON array of APIKey from path
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// WithAPIKeys requires requests to present one of keys, either as a bearer
+// token or in the X-API-Key header, and enforces its limits. Requests over
+// a limit get a 429 with a Retry-After header.
+func WithAPIKeys(keys ...APIKey) Option {
+	return func(s *Server) {
+		s.keys = make(map[string]*client, len(keys))
+		for _, key := range keys {
+			s.keys[key.Key] = newClient(key)
+		}
+	}
+}
+
+// client tracks the usage of one API key
+type client struct {
+	key APIKey
+
+	mu          sync.Mutex
+	tokens      float64
+	refilled    time.Time
+	used        int64
+	periodStart time.Time
+}
+
+func newClient(key APIKey) *client {
+	if key.QuotaPeriod <= 0 {
+		key.QuotaPeriod = Duration(24 * time.Hour)
+	}
+	if key.Burst <= 0 {
+		key.Burst = int(math.Max(1, math.Ceil(key.RateLimit)))
+	}
+	return &client{key: key, tokens: float64(key.Burst)}
+}
+
+// allow spends one request and n addresses at now, or reports how long to
+// wait before retrying
+func (c *client) allow(now time.Time, n int64) (retryAfter time.Duration, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key.Quota > 0 {
+		period := time.Duration(c.key.QuotaPeriod)
+		if now.Sub(c.periodStart) >= period {
+			c.periodStart, c.used = now.Truncate(period), 0
+		}
+		if c.used+n > c.key.Quota {
+			return c.periodStart.Add(period).Sub(now), false
+		}
+	}
+
+	if c.key.RateLimit > 0 {
+		if !c.refilled.IsZero() {
+			c.tokens = math.Min(float64(c.key.Burst), c.tokens+now.Sub(c.refilled).Seconds()*c.key.RateLimit)
+		}
+		c.refilled = now
+		if c.tokens < 1 {
+			return time.Duration((1 - c.tokens) / c.key.RateLimit * float64(time.Second)), false
+		}
+		c.tokens--
+	}
+
+	c.used += n
+	return 0, true
+}
+
+type clientKey struct{}
+
+// KeyName returns the name of the API key that authenticated r, or "" when
+// the server does not require keys
+func KeyName(r *http.Request) string {
+	if c, ok := r.Context().Value(clientKey{}).(*client); ok {
+		return c.key.Name
+	}
+	return ""
+}
+
+// authenticate resolves the API key of r. It responds and returns nil when
+// the request is rejected.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) *http.Request {
+	if s.keys == nil {
+		return r
+	}
+	key := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); key == "" && strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	}
+	c, ok := s.keys[key]
+	if key == "" || !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="emailvalidate"`)
+		writeError(w, http.StatusUnauthorized, "a valid API key is required")
+		return nil
+	}
+	return r.WithContext(context.WithValue(r.Context(), clientKey{}, c))
+}
+
+// charge spends n addresses from the quota of the request's key. It
+// responds and returns false when a limit is exceeded.
+func (s *Server) charge(w http.ResponseWriter, r *http.Request, n int64) bool {
+	c, ok := r.Context().Value(clientKey{}).(*client)
+	if !ok {
+		return true
+	}
+	retryAfter, ok := c.allow(s.now(), n)
+	if !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "rate limit or quota exceeded")
+	}
+	return ok
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestAPIKeys(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	handler := New(emailvalidator.New(), WithAPIKeys(
+		APIKey{Key: "team-a", Name: "a", RateLimit: 1, Burst: 2},
+		APIKey{Key: "team-b", Name: "b", Quota: 3, QuotaPeriod: Duration(time.Hour)},
+	))
+	handler.now = func() time.Time { return now }
+
+	get := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/validate?email=jane@example.com", nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a key, got %d", rec.Code)
+	}
+	if rec := get("nope"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for an unknown key, got %d", rec.Code)
+	}
+
+	// Team a bursts two requests, then waits for the next token
+	for i := 0; i < 2; i++ {
+		if rec := get("team-a"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: got %d", i, rec.Code)
+		}
+	}
+	if rec := get("team-a"); rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("expected 429 with Retry-After, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	now = now.Add(time.Second)
+	if rec := get("team-a"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the bucket to refill, got %d", rec.Code)
+	}
+
+	// Team b's batch counts every address toward its quota
+	req := httptest.NewRequest(http.MethodPost, "/v1/validate/batch", strings.NewReader(`{"emails":["a@example.com","b@example.com"]}`))
+	req.Header.Set("X-API-Key", "team-b")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("batch: got %d", rec.Code)
+	}
+	if rec := get("team-b"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the last address of the quota to pass, got %d", rec.Code)
+	}
+	if rec := get("team-b"); rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "3599" {
+		t.Fatalf("expected the quota to be exhausted, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	now = now.Add(time.Hour)
+	if rec := get("team-b"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the quota to reset, got %d", rec.Code)
+	}
+
+	// Health checks stay open
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("healthz: got %d", rec.Code)
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// ReadinessTimeout bounds the time /readyz waits for its checks
+const ReadinessTimeout = 5 * time.Second
+
+// Check reports whether one dependency of the server is usable
+type Check func(ctx context.Context) error
+
+// WithReadinessCheck adds check, reported under name, to /readyz
+func WithReadinessCheck(name string, check Check) Option {
+	return func(s *Server) {
+		s.readiness[name] = check
+	}
+}
+
+// ResolverCheck verifies that checker can resolve domain, which should be
+// one known to exist. A domain reported as not found still proves that the
+// resolver answered.
+func ResolverCheck(checker *emailvalidator.DNSChecker, domain string) Check {
+	return func(ctx context.Context) error {
+		_, err := checker.IsDomainValidContext(ctx, domain)
+		if err != nil && !errors.Is(err, emailvalidator.ErrDomainNotFound) {
+			return err
+		}
+		return nil
+	}
+}
+
+// FreshnessCheck fails when the lists last changed more than maxAge ago,
+// according to modTime, such as Reloader.ModTime
+func FreshnessCheck(modTime func() time.Time, maxAge time.Duration) Check {
+	return func(ctx context.Context) error {
+		if age := time.Since(modTime()); age > maxAge {
+			return fmt.Errorf("lists are %s old, more than %s", age.Round(time.Second), maxAge)
+		}
+		return nil
+	}
+}
+
+// Pinger is implemented by cache backends that can report their health
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingCheck checks a cache backend
+func PingCheck(p Pinger) Check {
+	return p.Ping
+}
+
+// ReadinessResponse is the body of /readyz responses
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// handleHealth serves /healthz, which only shows that the process is up
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleReady serves /readyz, running the readiness checks concurrently.
+// It responds 503 when any fails.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), ReadinessTimeout)
+	defer cancel()
+
+	names := make([]string, 0, len(s.readiness))
+	for name := range s.readiness {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			errs[i] = check(ctx)
+		}(i, s.readiness[name])
+	}
+	wg.Wait()
+
+	resp := ReadinessResponse{Status: "ok", Checks: make(map[string]string, len(names))}
+	status := http.StatusOK
+	for i, name := range names {
+		resp.Checks[name] = "ok"
+		if errs[i] != nil {
+			resp.Checks[name] = errs[i].Error()
+			resp.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, status, resp)
+}
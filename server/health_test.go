@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+// pinger is a cache backend with a settable health
+type pinger struct{ err error }
+
+func (p *pinger) Ping(ctx context.Context) error { return p.err }
+
+func TestReadiness(t *testing.T) {
+	cache := &pinger{}
+	modTime := time.Now().Add(-time.Hour)
+	handler := New(emailvalidator.New(),
+		WithReadinessCheck("cache", PingCheck(cache)),
+		WithReadinessCheck("lists", FreshnessCheck(func() time.Time { return modTime }, 24*time.Hour)),
+	)
+
+	ready := func() (int, ReadinessResponse) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var resp ReadinessResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec.Code, resp
+	}
+
+	if code, resp := ready(); code != http.StatusOK || resp.Checks["cache"] != "ok" || resp.Checks["lists"] != "ok" {
+		t.Fatalf("expected ready, got %d %+v", code, resp)
+	}
+
+	cache.err = errors.New("connection refused")
+	modTime = modTime.Add(-48 * time.Hour)
+	code, resp := ready()
+	if code != http.StatusServiceUnavailable || resp.Status != "unavailable" || resp.Checks["cache"] != "connection refused" || resp.Checks["lists"] == "ok" {
+		t.Fatalf("expected unavailable, got %d %+v", code, resp)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected liveness to ignore readiness, got %d", rec.Code)
+	}
+}
+
+// failingResolver cannot reach any server
+type failingResolver struct{}
+
+func (failingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+}
+
+func (failingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true}
+}
+
+func TestResolverCheck(t *testing.T) {
+	checker := emailvalidator.NewDNSChecker().WithResolver(failingResolver{})
+	if err := ResolverCheck(checker, "gmail.com")(context.Background()); err == nil {
+		t.Error("expected an unreachable resolver to fail the check")
+	}
+}
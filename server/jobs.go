@@ -0,0 +1,415 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LBFmuraiybatu/email_validator/bulk"
+)
+
+// Job statuses reported by the jobs API
+const (
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// Webhook request headers. The signature is the hex HMAC-SHA256 of the
+// timestamp, a period and the body, keyed with the webhook secret.
+const (
+	WebhookTimestampHeader = "X-Emailvalidate-Timestamp"
+	WebhookSignatureHeader = "X-Emailvalidate-Signature"
+)
+
+// webhookAttempts is the number of deliveries tried per finished job
+const webhookAttempts = 3
+
+// DefaultMaxJobBytes is the default size limit of job bodies
+const DefaultMaxJobBytes = 64 << 20
+
+// DefaultJobTTL is how long finished jobs and their results are kept by
+// default
+const DefaultJobTTL = 24 * time.Hour
+
+// WithJobs enables asynchronous bulk jobs, spooling their input and results
+// under dir:
+//
+//	POST /v1/jobs               submit an NDJSON or CSV body, get 202 and a JobStatus
+//	GET  /v1/jobs/{id}          poll the JobStatus
+//	GET  /v1/jobs/{id}/results  download the results as NDJSON once done
+//
+// CSV bodies name the address column with the column query parameter and
+// NDJSON objects name their field with field; both default to "email".
+func WithJobs(dir string) Option {
+	return func(s *Server) {
+		s.jobDir = dir
+	}
+}
+
+// WithWebhook POSTs a WebhookEvent to url whenever a job finishes, signed
+// with secret so the receiver can check it came from this server
+func WithWebhook(url string, secret []byte) Option {
+	return func(s *Server) {
+		s.webhookURL = url
+		s.webhookSecret = secret
+	}
+}
+
+// WithPublicURL sets the base URL used in the links returned to clients.
+// By default it is derived from the request that submitted the job.
+func WithPublicURL(base string) Option {
+	return func(s *Server) {
+		s.publicURL = strings.TrimSuffix(base, "/")
+	}
+}
+
+// WithMaxJobBytes sets the size limit of job bodies. Larger bodies are
+// refused with 413. The default is DefaultMaxJobBytes.
+func WithMaxJobBytes(n int64) Option {
+	return func(s *Server) {
+		s.maxJobBytes = n
+	}
+}
+
+// WithJobTTL sets how long a finished job is kept. Once it expires, its
+// status is forgotten and its results file deleted. The default is
+// DefaultJobTTL.
+func WithJobTTL(d time.Duration) Option {
+	return func(s *Server) {
+		s.jobTTL = d
+	}
+}
+
+// JobStatus describes an asynchronous job
+type JobStatus struct {
+	ID         string        `json:"id"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	Summary    *bulk.Summary `json:"summary,omitempty"`
+	StatusURL  string        `json:"status_url"`
+	ResultsURL string        `json:"results_url"`
+}
+
+// WebhookEvent is the body POSTed to the webhook when a job finishes
+type WebhookEvent struct {
+	Event string `json:"event"`
+	JobStatus
+}
+
+// SignWebhook returns the signature of a webhook body sent at timestamp
+func SignWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook reports whether signature matches a webhook body sent at
+// timestamp, for use by receivers
+func VerifyWebhook(secret []byte, timestamp, signature string, body []byte) bool {
+	return hmac.Equal([]byte(signature), []byte(SignWebhook(secret, timestamp, body)))
+}
+
+// asyncJob is the server's record of one job
+type asyncJob struct {
+	mu       sync.Mutex
+	status   JobStatus
+	finished time.Time
+}
+
+func (j *asyncJob) snapshot() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// Close cancels the running jobs and waits for them to stop
+func (s *Server) Close() error {
+	s.cancel()
+	s.running.Wait()
+	return nil
+}
+
+// handleJobs serves /v1/jobs and the paths below it
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	id, sub, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/jobs"), "/"), "/")
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		s.submitJob(w, r)
+	case id != "" && sub == "" && r.Method == http.MethodGet:
+		s.jobStatus(w, id)
+	case id != "" && sub == "results" && r.Method == http.MethodGet:
+		s.jobResults(w, r, id)
+	case id == "" || sub == "" || sub == "results":
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// submitJob spools the request body and starts a job over it
+func (s *Server) submitJob(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	csvInput := mediaType == "text/csv"
+	if !csvInput && mediaType != "application/x-ndjson" && mediaType != "application/jsonl" {
+		writeError(w, http.StatusUnsupportedMediaType, "body must be text/csv or application/x-ndjson")
+		return
+	}
+	field := r.URL.Query().Get("column")
+	if !csvInput {
+		field = r.URL.Query().Get("field")
+	}
+	if field == "" {
+		field = "email"
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.expireJobs()
+	input := filepath.Join(s.jobDir, id+".input")
+	lines, err := spool(input, http.MaxBytesReader(w, r.Body, s.maxJobBytes))
+	if err != nil {
+		os.Remove(input)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("body exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, "reading body: "+err.Error())
+		return
+	}
+	if csvInput && lines > 0 {
+		lines-- // header
+	}
+	if !s.charge(w, r, lines) {
+		os.Remove(input)
+		return
+	}
+
+	base := s.publicURL
+	if base == "" {
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		base = scheme + "://" + r.Host
+	}
+	job := &asyncJob{status: JobStatus{
+		ID:         id,
+		Status:     JobRunning,
+		StatusURL:  base + "/v1/jobs/" + id,
+		ResultsURL: base + "/v1/jobs/" + id + "/results",
+	}}
+	s.jobsMu.Lock()
+	s.jobs[id] = job
+	s.jobsMu.Unlock()
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.runJob(job, input, csvInput, field)
+	}()
+
+	writeJSON(w, http.StatusAccepted, job.snapshot())
+}
+
+// runJob validates the spooled input of job and reports the outcome
+func (s *Server) runJob(job *asyncJob, input string, csvInput bool, field string) {
+	defer os.Remove(input)
+	summary, err := s.runJobFiles(input, s.resultsPath(job.status.ID), csvInput, field)
+
+	job.mu.Lock()
+	job.finished = s.now()
+	job.status.Status = JobDone
+	job.status.Summary = &summary
+	if err != nil {
+		job.status.Status = JobFailed
+		job.status.Error = err.Error()
+	}
+	status := job.status
+	job.mu.Unlock()
+
+	if s.webhookURL != "" {
+		s.notify(WebhookEvent{Event: "job." + status.Status, JobStatus: status})
+	}
+}
+
+// runJobFiles runs the bulk job from input to output
+func (s *Server) runJobFiles(input, output string, csvInput bool, field string) (bulk.Summary, error) {
+	in, err := os.Open(input)
+	if err != nil {
+		return bulk.Summary{}, err
+	}
+	defer in.Close()
+
+	out, err := os.Create(output)
+	if err != nil {
+		return bulk.Summary{}, err
+	}
+	defer out.Close()
+
+	var src bulk.Source = bulk.FromNDJSON(in, field)
+	if csvInput {
+		src = bulk.FromCSV(in, field)
+	}
+	summary, err := s.batch.Run(s.ctx, src, bulk.ToNDJSON(out))
+	if err != nil {
+		return summary, err
+	}
+	return summary, out.Close()
+}
+
+// notify delivers event to the webhook, retrying failed attempts
+func (s *Server) notify(event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	backoff := time.Second
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if s.deliver(body) == nil || attempt == webhookAttempts {
+			return
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver makes one webhook request
+func (s *Server) deliver(body []byte) error {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(s.webhookSecret, timestamp, body))
+
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// jobStatus serves GET /v1/jobs/{id}
+func (s *Server) jobStatus(w http.ResponseWriter, id string) {
+	job, ok := s.job(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no such job")
+		return
+	}
+	writeJSON(w, http.StatusOK, job.snapshot())
+}
+
+// jobResults serves GET /v1/jobs/{id}/results
+func (s *Server) jobResults(w http.ResponseWriter, r *http.Request, id string) {
+	job, ok := s.job(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no such job")
+		return
+	}
+	if status := job.snapshot(); status.Status != JobDone {
+		writeError(w, http.StatusConflict, "job is "+status.Status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	http.ServeFile(w, r, s.resultsPath(id))
+}
+
+func (s *Server) job(id string) (*asyncJob, bool) {
+	s.expireJobs()
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+// expireJobs forgets the jobs finished more than the job TTL ago and
+// deletes their results. It runs whenever the jobs are accessed, so no
+// goroutine is needed to keep them bounded.
+func (s *Server) expireJobs() {
+	deadline := s.now().Add(-s.jobTTL)
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	for id, job := range s.jobs {
+		job.mu.Lock()
+		expired := !job.finished.IsZero() && job.finished.Before(deadline)
+		job.mu.Unlock()
+		if expired {
+			delete(s.jobs, id)
+			os.Remove(s.resultsPath(id))
+		}
+	}
+}
+
+func (s *Server) resultsPath(id string) string {
+	return filepath.Join(s.jobDir, id+".ndjson")
+}
+
+// newJobID returns a random job identifier
+func newJobID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// spool copies body to path, returning the number of lines
+func spool(path string, body io.Reader) (int64, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var lines int64
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadSlice('\n')
+		if len(bytes.TrimSpace(line)) > 0 && (err == nil || errors.Is(err, io.EOF)) {
+			lines++
+		}
+		if _, werr := f.Write(line); werr != nil {
+			return 0, werr
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			return 0, err
+		}
+	}
+	return lines, f.Close()
+}
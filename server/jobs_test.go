@@ -0,0 +1,145 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestJobWebhook(t *testing.T) {
+	secret := []byte("s3cret")
+	events := make(chan WebhookEvent, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature := strings.TrimPrefix(r.Header.Get(WebhookSignatureHeader), "sha256=")
+		if !VerifyWebhook(secret, r.Header.Get(WebhookTimestampHeader), signature, body) {
+			t.Errorf("bad signature %q", signature)
+		}
+		var event WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Error(err)
+		}
+		events <- event
+	}))
+	defer hook.Close()
+
+	handler := New(emailvalidator.New(), WithJobs(t.TempDir()), WithWebhook(hook.URL, secret))
+	defer handler.Close()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	csv := "name,email\njane,jane@example.com\nbad,not-an-email\n"
+	resp, err := http.Post(srv.URL+"/v1/jobs", "text/csv", strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var submitted JobStatus
+	json.NewDecoder(resp.Body).Decode(&submitted)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || submitted.ID == "" || submitted.ResultsURL != srv.URL+"/v1/jobs/"+submitted.ID+"/results" {
+		t.Fatalf("unexpected response %d %+v", resp.StatusCode, submitted)
+	}
+
+	var event WebhookEvent
+	select {
+	case event = <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not called")
+	}
+	if event.Event != "job.done" || event.ID != submitted.ID || event.Summary == nil || event.Summary.Processed != 2 || event.Summary.Verdicts[emailvalidator.VerdictInvalid] != 1 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	resp, err = http.Get(event.ResultsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var lines int
+	for scanner := bufio.NewScanner(resp.Body); scanner.Scan(); lines++ {
+	}
+	if resp.StatusCode != http.StatusOK || lines != 2 {
+		t.Fatalf("results: got %d with %d lines", resp.StatusCode, lines)
+	}
+}
+
+func TestJobRequests(t *testing.T) {
+	handler := New(emailvalidator.New(), WithJobs(t.TempDir()))
+	defer handler.Close()
+
+	tests := []struct {
+		method, target, contentType string
+		status                      int
+	}{
+		{http.MethodPost, "/v1/jobs", "application/json", http.StatusUnsupportedMediaType},
+		{http.MethodGet, "/v1/jobs", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/v1/jobs/missing", "", http.StatusNotFound},
+		{http.MethodGet, "/v1/jobs/missing/results", "", http.StatusNotFound},
+		{http.MethodGet, "/v1/jobs/missing/other", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(""))
+		req.Header.Set("Content-Type", tt.contentType)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: got %d, want %d", tt.method, tt.target, rec.Code, tt.status)
+		}
+	}
+}
+
+func TestJobBodyLimit(t *testing.T) {
+	handler := New(emailvalidator.New(), WithJobs(t.TempDir()), WithMaxJobBytes(32))
+	defer handler.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(strings.Repeat("jane@example.com\n", 4)))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("got %d, want 413", rec.Code)
+	}
+}
+
+func TestJobExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	handler := New(emailvalidator.New(), WithJobs(t.TempDir()), WithJobTTL(time.Hour))
+	defer handler.Close()
+	handler.now = func() time.Time { return now }
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(`{"email":"jane@example.com"}`+"\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	var submitted JobStatus
+	json.NewDecoder(rec.Body).Decode(&submitted)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("submit: got %d", rec.Code)
+	}
+	handler.running.Wait()
+
+	status := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/jobs/"+submitted.ID, nil))
+		return rec.Code
+	}
+	now = now.Add(time.Hour)
+	if code := status(); code != http.StatusOK {
+		t.Fatalf("job expired early: got %d", code)
+	}
+	now = now.Add(time.Second)
+	if code := status(); code != http.StatusNotFound {
+		t.Fatalf("expired job: got %d, want 404", code)
+	}
+	if _, err := os.Stat(handler.resultsPath(submitted.ID)); !os.IsNotExist(err) {
+		t.Errorf("results file of the expired job kept: %v", err)
+	}
+}
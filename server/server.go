@@ -0,0 +1,262 @@
+// Package server exposes a validator over HTTP:
+//
+//	GET  /v1/validate?email=jane@example.com
+//	POST /v1/validate  {"email": "jane@example.com"}
+//	POST /v1/validate/batch  {"emails": ["jane@example.com", ...]}
+//	POST /v1/jobs  asynchronous bulk jobs, enabled WithJobs
+//	GET  /healthz  liveness, always 204 while the process serves
+//	GET  /readyz   readiness, running the checks added WithReadinessCheck
+//
+// Results use the ValidationResult JSON encoding described by
+// emailvalidator.ResultSchemaVersion. Malformed requests get a 400 with an
+// ErrorResponse body.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+	"github.com/LBFmuraiybatu/email_validator/bulk"
+)
+
+// MaxBodyBytes bounds the size of request bodies
+const MaxBodyBytes = 1 << 20
+
+// DefaultMaxBatch is the default number of addresses accepted per batch
+const DefaultMaxBatch = 1000
+
+// ErrorResponse is the body of error responses
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ValidateRequest is the body of POST /v1/validate
+type ValidateRequest struct {
+	Email string `json:"email"`
+}
+
+// BatchRequest is the body of POST /v1/validate/batch
+type BatchRequest struct {
+	Emails []string `json:"emails"`
+}
+
+// BatchResponse is the response of POST /v1/validate/batch
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+	Summary bulk.Summary  `json:"summary"`
+}
+
+// BatchResult is the result for one address of a batch, in request order
+type BatchResult struct {
+	Index  int64                           `json:"index"`
+	Email  string                          `json:"email"`
+	Result emailvalidator.ValidationResult `json:"result"`
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithMaxBatch sets the number of addresses accepted per batch request.
+// The default is DefaultMaxBatch.
+func WithMaxBatch(n int) Option {
+	return func(s *Server) {
+		s.maxBatch = n
+	}
+}
+
+// WithBatchOptions configures the bulk job running batch requests
+func WithBatchOptions(opts ...bulk.Option) Option {
+	return func(s *Server) {
+		s.batchOptions = append(s.batchOptions, opts...)
+	}
+}
+
+// WithMetrics serves h at /metrics, such as a prommetrics.Metrics. Like
+// /healthz and /readyz, it does not require an API key.
+func WithMetrics(h http.Handler) Option {
+	return func(s *Server) {
+		s.metrics = h
+	}
+}
+
+// Server is an http.Handler serving the validation API. It is safe for
+// concurrent use as long as its validator is.
+type Server struct {
+	validator emailvalidator.Validator
+	mux       *http.ServeMux
+
+	maxBatch     int
+	batchOptions []bulk.Option
+	batch        *bulk.Job
+
+	keys map[string]*client
+	now  func() time.Time
+
+	metrics   http.Handler
+	readiness map[string]Check
+
+	jobDir        string
+	maxJobBytes   int64
+	jobTTL        time.Duration
+	jobsMu        sync.Mutex
+	jobs          map[string]*asyncJob
+	publicURL     string
+	webhookURL    string
+	webhookSecret []byte
+	webhookClient *http.Client
+
+	// ctx is cancelled by Close to stop the running jobs
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// New creates a Server validating with v, shared by all requests
+func New(v emailvalidator.Validator, opts ...Option) *Server {
+	s := &Server{validator: v, mux: http.NewServeMux(), maxBatch: DefaultMaxBatch, now: time.Now, readiness: make(map[string]Check), maxJobBytes: DefaultMaxJobBytes, jobTTL: DefaultJobTTL}
+	for _, opt := range opts {
+		opt(s)
+	}
+	// Duplicates within a batch are validated once
+	s.batch = bulk.New(v, append([]bulk.Option{bulk.WithDedupe()}, s.batchOptions...)...)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.mux.HandleFunc("/v1/validate", s.authenticated(s.handleValidate))
+	s.mux.HandleFunc("/v1/validate/batch", s.authenticated(s.handleBatch))
+	if s.jobDir != "" {
+		s.jobs = make(map[string]*asyncJob)
+		s.webhookClient = &http.Client{Timeout: 10 * time.Second}
+		s.mux.HandleFunc("/v1/jobs", s.authenticated(s.handleJobs))
+		s.mux.HandleFunc("/v1/jobs/", s.authenticated(s.handleJobs))
+	}
+	if s.metrics != nil {
+		s.mux.Handle("/metrics", s.metrics)
+	}
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/readyz", s.handleReady)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// authenticated wraps next with API key authentication
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r = s.authenticate(w, r); r != nil {
+			next(w, r)
+		}
+	}
+}
+
+// handleValidate serves /v1/validate
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var email string
+	switch r.Method {
+	case http.MethodGet:
+		email = r.URL.Query().Get("email")
+	case http.MethodPost:
+		var req ValidateRequest
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		email = req.Email
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if email == "" {
+		writeError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+	if !s.charge(w, r, 1) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.validate(r.Context(), email))
+}
+
+// handleBatch serves /v1/validate/batch
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req BatchRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(req.Emails) == 0 {
+		writeError(w, http.StatusBadRequest, "emails is required")
+		return
+	}
+	if len(req.Emails) > s.maxBatch {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("batch of %d addresses exceeds the limit of %d", len(req.Emails), s.maxBatch))
+		return
+	}
+	if !s.charge(w, r, int64(len(req.Emails))) {
+		return
+	}
+
+	var out bulk.Collector
+	summary, err := s.batch.Run(r.Context(), bulk.FromSlice(req.Emails), &out)
+	if err != nil {
+		// Only cancellation can fail a run over an in-memory source, in
+		// which case the client is gone
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	resp := BatchResponse{Results: make([]BatchResult, len(out.Items)), Summary: summary}
+	for i, item := range out.Items {
+		resp.Results[i] = BatchResult{Index: item.Index, Email: item.Email, Result: item.Result}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// validate validates email, cancelling lookups when the client goes away
+func (s *Server) validate(ctx context.Context, email string) emailvalidator.ValidationResult {
+	if cv, ok := s.validator.(emailvalidator.ContextValidator); ok {
+		return cv.ValidateContext(ctx, email)
+	}
+	return s.validator.Validate(email)
+}
+
+// decodeJSON decodes the JSON body of r into v
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if mediaType := r.Header.Get("Content-Type"); mediaType != "" && !strings.HasPrefix(mediaType, "application/json") {
+		return errors.New("content type must be application/json")
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("request body is empty")
+		}
+		return errors.New("request body must be a JSON object: " + err.Error())
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
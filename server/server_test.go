@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/LBFmuraiybatu/email_validator"
+)
+
+func TestValidate(t *testing.T) {
+	srv := httptest.NewServer(New(emailvalidator.New()))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/validate?email=jane@gmial.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result emailvalidator.ValidationResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !result.IsValid || result.Suggestion != "jane@gmail.com" {
+		t.Fatalf("unexpected response %d %+v", resp.StatusCode, result)
+	}
+
+	resp, err = http.Post(srv.URL+"/v1/validate", "application/json", strings.NewReader(`{"email":"jane@@example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&raw)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || raw["is_valid"] != false || raw["schema_version"] != emailvalidator.ResultSchemaVersion {
+		t.Fatalf("unexpected response %d %v", resp.StatusCode, raw)
+	}
+}
+
+func TestValidateBadRequests(t *testing.T) {
+	handler := New(emailvalidator.New())
+
+	tests := []struct {
+		method, target, body string
+		status               int
+	}{
+		{http.MethodGet, "/v1/validate", "", http.StatusBadRequest},
+		{http.MethodPost, "/v1/validate", "{", http.StatusBadRequest},
+		{http.MethodPost, "/v1/validate", "", http.StatusBadRequest},
+		{http.MethodDelete, "/v1/validate", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/v2/validate", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+		if rec.Code != tt.status {
+			t.Errorf("%s %s %q: got %d, want %d", tt.method, tt.target, tt.body, rec.Code, tt.status)
+		}
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	handler := New(emailvalidator.New(), WithMaxBatch(3))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/validate/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(`{"emails":["jane@example.com","bad","Jane@Example.com"]}`)
+	var resp BatchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || len(resp.Results) != 3 {
+		t.Fatalf("unexpected response %d %+v", rec.Code, resp)
+	}
+	for i, want := range []bool{true, false, true} {
+		if r := resp.Results[i]; r.Index != int64(i) || r.Result.IsValid != want {
+			t.Errorf("result %d: %+v", i, r)
+		}
+	}
+	if resp.Summary.Processed != 3 || resp.Summary.Duplicates != 1 || resp.Summary.Verdicts[emailvalidator.VerdictInvalid] != 1 {
+		t.Errorf("unexpected summary %+v", resp.Summary)
+	}
+
+	if rec := post(`{"emails":["a@example.com","b@example.com","c@example.com","d@example.com"]}`); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for an oversized batch, got %d", rec.Code)
+	}
+	if rec := post(`{"emails":[]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty batch, got %d", rec.Code)
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, "up 1\n") })
+	handler := New(emailvalidator.New(), WithMetrics(metrics), WithAPIKeys(APIKey{Key: "k"}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "up 1\n" {
+		t.Fatalf("unexpected metrics response %d %q", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,203 @@
+package emailvalidator
+
+import (
+	"sort"
+	"strings"
+)
+
+// DomainSimilarity returns how alike two domains are, from 0 for nothing
+// in common to 1 for the same domain ignoring case and a trailing dot. It
+// is one minus the number of single-character insertions, deletions,
+// substitutions and transpositions turning one into the other, relative to
+// the longer one, so "gmial.com" is 0.89 alike "gmail.com". Typo
+// suggestions rank candidate domains by it; it also serves to detect
+// lookalike domains.
+func DomainSimilarity(a, b string) float64 {
+	// Domains fit in the buffers, which keeps the common case off the heap
+	var bufA, bufB [64]rune
+	ra := appendRunes(bufA[:0], normalizeDomain(strings.TrimSpace(a)))
+	rb := appendRunes(bufB[:0], normalizeDomain(strings.TrimSpace(b)))
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb, longest))/float64(longest)
+}
+
+func appendRunes(buf []rune, s string) []rune {
+	for _, r := range s {
+		buf = append(buf, r)
+	}
+	return buf
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// the Levenshtein distance also counting a transposition of adjacent
+// characters as one edit. Past limit it gives up and returns limit+1.
+func editDistance(a, b []rune, limit int) int {
+	if diff := len(a) - len(b); diff > limit || -diff > limit {
+		return limit + 1
+	}
+	over := limit + 1
+
+	// rows holds the last three rows of the distance matrix, of which only
+	// the band within limit of the diagonal is computed
+	var buf [3][65]int
+	var rows [3][]int
+	for i := range rows {
+		if len(b) < len(buf[i]) {
+			rows[i] = buf[i][:len(b)+1]
+		} else {
+			rows[i] = make([]int, len(b)+1)
+		}
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	prevLeast := 0
+	for i := 1; i <= len(a); i++ {
+		prev2, prev, cur := rows[(i+1)%3], rows[(i+2)%3], rows[i%3]
+		lo, hi := max(1, i-limit), min(len(b), i+limit)
+		least := over
+		if lo == 1 {
+			cur[0] = i
+			least = i
+		} else {
+			cur[lo-1] = over
+		}
+		for j := lo; j <= hi; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			least = min(least, cur[j])
+		}
+		if hi < len(b) {
+			cur[hi+1] = over
+		}
+		// A row's least distance exceeds that of the row before or, by a
+		// transposition, of the row before that by one
+		if least > limit && prevLeast >= limit {
+			return over
+		}
+		prevLeast = least
+	}
+	return min(rows[len(a)%3][len(b)], over)
+}
+
+// suggestionThreshold is the DomainSimilarity above which a domain is
+// taken for a typo of a popular one: one edit in a domain of seven
+// characters or more
+const suggestionThreshold = 0.85
+
+// DefaultSuggestionDomains are the targets of typo suggestions, mailbox
+// providers weighted by their rough share of addresses worldwide, Gmail
+// being 1. Real domains within one edit of another are listed too, so
+// that they are not mistaken for typos.
+var DefaultSuggestionDomains = map[string]float64{
+	"gmail.com":      1,
+	"yahoo.com":      0.4,
+	"hotmail.com":    0.3,
+	"outlook.com":    0.3,
+	"icloud.com":     0.2,
+	"aol.com":        0.1,
+	"live.com":       0.1,
+	"msn.com":        0.05,
+	"ymail.com":      0.05,
+	"googlemail.com": 0.05,
+	"protonmail.com": 0.05,
+	"gmx.com":        0.05,
+	"gmx.de":         0.05,
+	"web.de":         0.05,
+	"mail.com":       0.05,
+	"email.com":      0.02,
+	"hotmail.co.uk":  0.05,
+	"yahoo.co.uk":    0.05,
+	"yandex.ru":      0.05,
+	"mail.ru":        0.05,
+	"comcast.net":    0.05,
+	"verizon.net":    0.03,
+	"att.net":        0.03,
+	"sbcglobal.net":  0.03,
+	"btinternet.com": 0.03,
+	"orange.fr":      0.03,
+	"zoho.com":       0.02,
+	"fastmail.com":   0.02,
+}
+
+// defaultSuggestionTargets are DefaultSuggestionDomains as prepared at init
+var defaultSuggestionTargets = newDomainTargets(DefaultSuggestionDomains)
+
+// domainTargets are the candidate domains of suggestions, heaviest first,
+// with their runes decoded once
+type domainTargets struct {
+	domains []string
+	runes   [][]rune
+	set     map[string]bool
+}
+
+// newDomainTargets prepares the domains of weights, lower-cased and
+// stripped of a trailing dot, leaving out those not weighing more than 0
+func newDomainTargets(weights map[string]float64) *domainTargets {
+	normalized := make(map[string]float64, len(weights))
+	for domain, weight := range weights {
+		if weight > 0 {
+			normalized[normalizeDomain(strings.TrimSpace(domain))] = weight
+		}
+	}
+	t := &domainTargets{set: make(map[string]bool, len(normalized))}
+	for domain := range normalized {
+		t.domains = append(t.domains, domain)
+		t.set[domain] = true
+	}
+	sort.Slice(t.domains, func(i, j int) bool {
+		a, b := t.domains[i], t.domains[j]
+		if normalized[a] != normalized[b] {
+			return normalized[a] > normalized[b]
+		}
+		return a < b
+	})
+	t.runes = make([][]rune, len(t.domains))
+	for i, domain := range t.domains {
+		t.runes[i] = []rune(domain)
+	}
+	return t
+}
+
+// mergeSuggestionDomains returns DefaultSuggestionDomains with the weights
+// of overrides
+func mergeSuggestionDomains(overrides map[string]float64) map[string]float64 {
+	weights := make(map[string]float64, len(DefaultSuggestionDomains)+len(overrides))
+	for domain, weight := range DefaultSuggestionDomains {
+		weights[domain] = weight
+	}
+	for domain, weight := range overrides {
+		weights[normalizeDomain(strings.TrimSpace(domain))] = weight
+	}
+	return weights
+}
+
+// closest returns the most similar target to domain when it is above the
+// suggestion threshold, preferring heavier targets on ties, or an empty
+// string when none is or domain is a target itself
+func (t *domainTargets) closest(domain string) string {
+	if t.set[domain] {
+		return ""
+	}
+	var buf [64]rune
+	runes := appendRunes(buf[:0], domain)
+	best, bestScore := "", suggestionThreshold
+	for i, target := range t.runes {
+		longest := max(len(target), len(runes))
+		maxEdits := int((1 - suggestionThreshold) * float64(longest))
+		edits := editDistance(runes, target, maxEdits)
+		if score := 1 - float64(edits)/float64(longest); score >= bestScore && (best == "" || score > bestScore) {
+			best, bestScore = t.domains[i], score
+		}
+	}
+	return best
+}
@@ -0,0 +1,95 @@
+package emailvalidator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDomainSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"gmail.com", "gmail.com", 1},
+		{"Gmail.COM.", "gmail.com", 1},
+		{"gmial.com", "gmail.com", 8.0 / 9},
+		{"gmail.con", "gmail.com", 8.0 / 9},
+		{"gmai.com", "gmail.com", 8.0 / 9},
+		{"hotmail.com", "gmail.com", 8.0 / 11},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+		{"", "a.com", 0},
+		{"bücher.de", "bucher.de", 8.0 / 9},
+	}
+	for _, tt := range tests {
+		if got := DomainSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("DomainSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := DomainSimilarity(tt.b, tt.a); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("DomainSimilarity(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistanceLimit(t *testing.T) {
+	a, b := []rune("mail.example.com"), []rune("protonmail.com")
+	full := editDistance(a, b, 100)
+	for limit := 0; limit < full+2; limit++ {
+		want := min(full, limit+1)
+		if got := editDistance(a, b, limit); got != want {
+			t.Errorf("limit %d: got %d, want %d", limit, got, want)
+		}
+	}
+}
+
+func TestSuggestionsBySimilarity(t *testing.T) {
+	// By default only the known typos are corrected
+	if got := New().Validate("jane@gamil.com").Suggestion; got != "" {
+		t.Errorf("default suggestion %q", got)
+	}
+	if got := New().Validate("jane@gmial.com").Suggestion; got != "jane@gmail.com" {
+		t.Errorf("default typo suggestion %q", got)
+	}
+
+	v := New(WithSuggestionDomains(nil))
+	tests := map[string]string{
+		"jane@gamil.com":   "jane@gmail.com",
+		"jane@outlok.com":  "jane@outlook.com",
+		"jane@icloud.co":   "jane@icloud.com",
+		"jane@ymail.com":   "",
+		"jane@email.com":   "",
+		"jane@example.com": "",
+		"jane@aol.co":      "jane@aol.com",
+	}
+	for email, want := range tests {
+		if got := v.Validate(email).Suggestion; got != want {
+			t.Errorf("%s: suggestion %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestWithSuggestionDomains(t *testing.T) {
+	v := New(WithSuggestionDomains(map[string]float64{"Acme-Corp.com.": 1, "acme.io": 0.5, "acne.io": 0.2, "gmail.com": 0}))
+	tests := map[string]string{
+		"jane@acme-crop.com": "jane@acme-corp.com",
+		"jane@acre.io":       "jane@acme.io",
+		"jane@gmial.com":     "",
+		"jane@gamil.com":     "",
+		"jane@yahooo.com":    "jane@yahoo.com",
+	}
+	for email, want := range tests {
+		if got := v.Validate(email).Suggestion; got != want {
+			t.Errorf("%s: suggestion %q, want %q", email, got, want)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.SuggestionDomains = map[string]float64{"acme.io": 0.2, "acne.io": 0.5}
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Validate("jane@acre.io").Suggestion; got != "jane@acne.io" {
+		t.Errorf("configured weights: suggestion %q", got)
+	}
+}
@@ -0,0 +1,79 @@
+package emailvalidator
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Result pairs an address read from a stream with its validation result
+type Result struct {
+	// Index is the position of the address in the input stream
+	Index  int64            `json:"index"`
+	Email  string           `json:"email"`
+	Result ValidationResult `json:"result"`
+}
+
+// ValidateStream validates addresses received on in and sends the results on
+// the returned channel, which is closed once in is closed and drained or ctx
+// is cancelled. Addresses are validated by the number of workers set with
+// WithStreamWorkers, so results may arrive out of input order; use
+// Result.Index to restore it. An unread output channel blocks the workers,
+// which in turn stop reading from in.
+func (v *EmailValidator) ValidateStream(ctx context.Context, in <-chan string) <-chan Result {
+	workers := v.streamWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		index int64
+		email string
+	}
+	jobs := make(chan job)
+	out := make(chan Result)
+
+	go func() {
+		defer close(jobs)
+		var index int64
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case email, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- job{index, email}:
+					index++
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result := Result{Index: j.index, Email: j.email, Result: v.ValidateContext(ctx, j.email)}
+				select {
+				case out <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
@@ -0,0 +1,50 @@
+package emailvalidator
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+func TestValidateStream(t *testing.T) {
+	emails := []string{"user@example.com", "invalid-email", "jane@example.org", "user@", "a@b.co"}
+
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, email := range emails {
+			in <- email
+		}
+	}()
+
+	var results []Result
+	for r := range New(WithStreamWorkers(3)).ValidateStream(context.Background(), in) {
+		results = append(results, r)
+	}
+
+	if len(results) != len(emails) {
+		t.Fatalf("expected %d results, got %d", len(emails), len(results))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+	for i, r := range results {
+		if r.Email != emails[i] {
+			t.Errorf("result %d: got %q, want %q", i, r.Email, emails[i])
+		}
+	}
+	if !results[0].Result.IsValid || results[1].Result.IsValid {
+		t.Error("unexpected validation results")
+	}
+}
+
+func TestValidateStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan string)
+	out := New(WithStreamWorkers(2)).ValidateStream(ctx, in)
+
+	in <- "user@example.com"
+	cancel()
+
+	// The output channel must be closed even though in never is
+	for range out {
+	}
+}
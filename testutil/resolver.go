@@ -0,0 +1,156 @@
+// Package testutil provides an in-memory DNS resolver and a scriptable SMTP
+// server, so that tests of emailvalidator and of code using it exercise DNS
+// and SMTP paths without touching the network
+package testutil
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Resolver is an in-memory resolver answering from records added to it.
+// It satisfies every resolver interface of emailvalidator. Names without
+// records do not exist. A record for "*.example.com" answers for every
+// subdomain of example.com that has no record of its own.
+//
+// Resolver is safe for concurrent use, including adding records while
+// lookups run.
+type Resolver struct {
+	mu      sync.RWMutex
+	mx      map[string][]*net.MX
+	ips     map[string][]net.IPAddr
+	txt     map[string][]string
+	errs    map[string]error
+	lookups atomic.Int64
+}
+
+// NewResolver creates a resolver without records
+func NewResolver() *Resolver {
+	return &Resolver{
+		mx:   make(map[string][]*net.MX),
+		ips:  make(map[string][]net.IPAddr),
+		txt:  make(map[string][]string),
+		errs: make(map[string]error),
+	}
+}
+
+// AddMX adds MX records for domain, preferring hosts in order
+func (r *Resolver) AddMX(domain string, hosts ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := normalize(domain)
+	for _, host := range hosts {
+		pref := uint16(10 * (len(r.mx[name]) + 1))
+		r.mx[name] = append(r.mx[name], &net.MX{Host: host, Pref: pref})
+	}
+}
+
+// AddIP adds address records for host. It panics on an invalid address.
+func (r *Resolver) AddIP(host string, addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := normalize(host)
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			panic("testutil: invalid IP address " + addr)
+		}
+		r.ips[name] = append(r.ips[name], net.IPAddr{IP: ip})
+	}
+}
+
+// AddTXT adds TXT records for name
+func (r *Resolver) AddTXT(name string, records ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.txt[normalize(name)] = append(r.txt[normalize(name)], records...)
+}
+
+// Fail makes every lookup of name return err, e.g. a *net.DNSError with
+// IsTimeout set
+func (r *Resolver) Fail(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs[normalize(name)] = err
+}
+
+// Lookups returns the number of lookups made so far
+func (r *Resolver) Lookups() int {
+	return int(r.lookups.Load())
+}
+
+// LookupMX returns the MX records of name
+func (r *Resolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	mx, err := lookup(ctx, r, r.mx, name)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*net.MX, len(mx))
+	for i, m := range mx {
+		records[i] = &net.MX{Host: m.Host, Pref: m.Pref}
+	}
+	return records, nil
+}
+
+// LookupIPAddr returns the addresses of host
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, err := lookup(ctx, r, r.ips, host)
+	return append([]net.IPAddr(nil), ips...), err
+}
+
+// LookupHost returns the addresses of host as strings
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	ips, err := lookup(ctx, r, r.ips, host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = ip.String()
+	}
+	return addrs, nil
+}
+
+// LookupTXT returns the TXT records of name
+func (r *Resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	txt, err := lookup(ctx, r, r.txt, name)
+	return append([]string(nil), txt...), err
+}
+
+// lookup finds the records of name in records, falling back to wildcards
+// of its parent domains
+func lookup[T any](ctx context.Context, r *Resolver, records map[string][]T, name string) ([]T, error) {
+	r.lookups.Add(1)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	name = normalize(name)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errs[name]; err != nil {
+		return nil, err
+	}
+	if found, ok := records[name]; ok {
+		return found, nil
+	}
+	for parent := name; ; {
+		dot := strings.IndexByte(parent, '.')
+		if dot < 0 {
+			break
+		}
+		parent = parent[dot+1:]
+		if found, ok := records["*."+parent]; ok {
+			return found, nil
+		}
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+// normalize lower-cases name and strips a trailing dot
+func normalize(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
@@ -0,0 +1,184 @@
+package testutil
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Reply is an SMTP reply
+type Reply struct {
+	Code    int
+	Message string
+}
+
+// SMTPServer is an SMTP server on the loopback interface answering with
+// scripted replies. Without a script it accepts every command a mailbox
+// check sends: the greeting, EHLO, HELO, MAIL, RCPT, DATA, RSET, NOOP and
+// QUIT. Other commands, including STARTTLS, are not implemented.
+//
+// Its methods are safe for concurrent use.
+type SMTPServer struct {
+	ln       net.Listener
+	greeting Reply
+	mu       sync.Mutex
+	replies  map[string]Reply
+	commands []string
+	wg       sync.WaitGroup
+}
+
+// NewSMTPServer starts a server on a free loopback port. It panics when it
+// cannot listen, like httptest.NewServer.
+func NewSMTPServer() *SMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("testutil: failed to listen: " + err.Error())
+	}
+	s := &SMTPServer{
+		ln:       ln,
+		greeting: Reply{220, "mx.test ESMTP ready"},
+		replies:  make(map[string]Reply),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Addr returns the host:port the server listens on
+func (s *SMTPServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Greet sets the reply sent when a client connects. A code other than 220
+// ends the session after the greeting.
+func (s *SMTPServer) Greet(code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.greeting = Reply{code, message}
+}
+
+// Respond scripts the reply to command, either a verb such as "RCPT" for
+// every use of it or a full command line such as
+// "RCPT TO:<jane@example.com>", which takes precedence. Commands are
+// compared case-insensitively. A DATA reply other than 354 refuses the
+// message content.
+func (s *SMTPServer) Respond(command string, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies[strings.ToUpper(command)] = Reply{code, message}
+}
+
+// Commands returns the command lines received so far, in order
+func (s *SMTPServer) Commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+// Close stops the server and waits for open sessions to end
+func (s *SMTPServer) Close() error {
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(conn)
+		}()
+	}
+}
+
+// session talks to one client until it quits, disconnects or is refused
+func (s *SMTPServer) session(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(rep Reply) bool {
+		fmt.Fprintf(w, "%d %s\r\n", rep.Code, rep.Message)
+		return w.Flush() == nil
+	}
+
+	s.mu.Lock()
+	greeting := s.greeting
+	s.mu.Unlock()
+	if !reply(greeting) || greeting.Code != 220 {
+		return
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb, _, _ := strings.Cut(strings.ToUpper(line), " ")
+		rep := s.reply(line, verb)
+		if verb == "EHLO" && rep.Code == 250 {
+			// Advertise no extensions, so clients stay in plain SMTP
+			fmt.Fprintf(w, "250-%s\r\n", rep.Message)
+			rep.Message = "8BITMIME"
+		}
+		if !reply(rep) {
+			return
+		}
+
+		switch {
+		case verb == "QUIT":
+			return
+		case verb == "DATA" && rep.Code == 354:
+			if !s.readData(r) || !reply(Reply{250, "OK: queued"}) {
+				return
+			}
+		case rep.Code == 421:
+			return
+		}
+	}
+}
+
+// reply records line and returns the reply to it
+func (s *SMTPServer) reply(line, verb string) Reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = append(s.commands, line)
+	if rep, ok := s.replies[strings.ToUpper(line)]; ok {
+		return rep
+	}
+	if rep, ok := s.replies[verb]; ok {
+		return rep
+	}
+	switch verb {
+	case "EHLO", "HELO":
+		return Reply{250, "mx.test"}
+	case "MAIL", "RCPT", "RSET", "NOOP":
+		return Reply{250, "OK"}
+	case "DATA":
+		return Reply{354, "End data with <CR><LF>.<CR><LF>"}
+	case "QUIT":
+		return Reply{221, "Bye"}
+	}
+	return Reply{502, "Command not implemented"}
+}
+
+// readData discards message content up to the terminating dot
+func (s *SMTPServer) readData(r *bufio.Reader) bool {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return false
+		}
+		if strings.TrimRight(line, "\r\n") == "." {
+			return true
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"reflect"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	r := NewResolver()
+	r.AddMX("example.com", "mx1.example.com.", "mx2.example.com.")
+	r.AddIP("*.wildcard.example", "192.0.2.1")
+	r.AddTXT("example.com", "v=spf1 -all")
+	timeout := &net.DNSError{Err: "i/o timeout", Name: "slow.example", IsTimeout: true}
+	r.Fail("slow.example", timeout)
+	ctx := context.Background()
+
+	mx, err := r.LookupMX(ctx, "Example.COM.")
+	if err != nil || len(mx) != 2 || mx[0].Host != "mx1.example.com." || mx[1].Pref != 20 {
+		t.Errorf("LookupMX = %v, %v", mx, err)
+	}
+	if addrs, err := r.LookupHost(ctx, "a.b.wildcard.example"); err != nil || !reflect.DeepEqual(addrs, []string{"192.0.2.1"}) {
+		t.Errorf("wildcard LookupHost = %v, %v", addrs, err)
+	}
+	if txt, err := r.LookupTXT(ctx, "example.com"); err != nil || len(txt) != 1 {
+		t.Errorf("LookupTXT = %v, %v", txt, err)
+	}
+
+	var dnsErr *net.DNSError
+	if _, err := r.LookupIPAddr(ctx, "example.com"); !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("missing record: %v", err)
+	}
+	if _, err := r.LookupMX(ctx, "slow.example"); err != timeout {
+		t.Errorf("failing name: %v", err)
+	}
+	if got := r.Lookups(); got != 5 {
+		t.Errorf("Lookups() = %d, want 5", got)
+	}
+}
+
+func TestSMTPServer(t *testing.T) {
+	s := NewSMTPServer()
+	defer s.Close()
+	s.Respond("RCPT TO:<nobody@example.com>", 550, "No such user")
+
+	c, err := smtp.Dial(s.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Mail("probe@example.org"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Rcpt("jane@example.com"); err != nil {
+		t.Errorf("accepted recipient: %v", err)
+	}
+	var tpErr *textproto.Error
+	if err := c.Rcpt("nobody@example.com"); !errors.As(err, &tpErr) || tpErr.Code != 550 {
+		t.Errorf("rejected recipient: %v", err)
+	}
+	if err := c.Quit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"EHLO localhost", "MAIL FROM:<probe@example.org> BODY=8BITMIME", "RCPT TO:<jane@example.com>", "RCPT TO:<nobody@example.com>", "QUIT"}
+	if got := s.Commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Commands() = %q, want %q", got, want)
+	}
+}
+
+func TestSMTPServerGreeting(t *testing.T) {
+	s := NewSMTPServer()
+	defer s.Close()
+	s.Greet(554, "No service")
+
+	if _, err := smtp.Dial(s.Addr()); err == nil {
+		t.Error("refused greeting accepted")
+	}
+}
@@ -0,0 +1,63 @@
+package emailvalidator
+
+import "strings"
+
+// TLDCategory classifies top-level domains
+type TLDCategory string
+
+// Categories returned by TLDCategoryOf
+const (
+	// TLDLegacy: the generic TLDs predating the 2012 expansion, such as com
+	TLDLegacy TLDCategory = "legacy"
+	// TLDGeneric: new generic TLDs, such as app or shop
+	TLDGeneric TLDCategory = "generic"
+	// TLDCountry: country-code TLDs, such as de or xn--p1ai (рф)
+	TLDCountry TLDCategory = "country"
+	// TLDBrand: TLDs reserved for the use of one company, such as google
+	TLDBrand TLDCategory = "brand"
+	// TLDHighAbuse: TLDs with high abuse rates, such as tk
+	TLDHighAbuse TLDCategory = "high_abuse"
+)
+
+// tldCategories maps the TLDs listed in data/tlds.txt to their category
+var tldCategories = parseTLDCategories(tldData)
+
+// parseTLDCategories reads the sections of data, keeping the first
+// category of each TLD
+func parseTLDCategories(data string) map[string]TLDCategory {
+	categories := make(map[string]TLDCategory)
+	for _, section := range parseSections(data) {
+		category := TLDCategory(strings.ReplaceAll(section.name, "-", "_"))
+		for _, tld := range section.words {
+			if _, ok := categories[tld]; !ok {
+				categories[tld] = category
+			}
+		}
+	}
+	return categories
+}
+
+// TLDCategoryOf returns the category of the top-level domain tld, with or
+// without a leading dot. Internationalized TLDs are given in their xn--
+// form.
+func TLDCategoryOf(tld string) TLDCategory {
+	tld = strings.ToLower(strings.TrimPrefix(tld, "."))
+	if category, ok := tldCategories[tld]; ok {
+		return category
+	}
+	if len(tld) == 2 {
+		return TLDCountry
+	}
+	return TLDGeneric
+}
+
+// checkTLDPolicy applies the policy of the category of the address's TLD
+func (v *EmailValidator) checkTLDPolicy(s *stage, p ParsedEmail) {
+	category := TLDCategoryOf(p.TLD)
+	switch v.tldPolicies[category] {
+	case PolicyWarn:
+		s.addWarning(newWarning(WarnTLDCategory, SeverityWarning, "Unusual top-level domain").withParam("tld", p.TLD).withParam("category", string(category)))
+	case PolicyBlock:
+		s.addError(newError(CodeTLDNotAllowed, FieldTLD, ErrTLDNotAllowed, "top-level domain is not allowed").withParam("tld", p.TLD).withParam("category", string(category)).at(len(p.Local)+1+len(p.Domain)-len(p.TLD), p.TLD))
+	}
+}
@@ -0,0 +1,70 @@
+package emailvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTLDCategoryOf(t *testing.T) {
+	tests := map[string]TLDCategory{
+		"com":      TLDLegacy,
+		".ORG":     TLDLegacy,
+		"de":       TLDCountry,
+		"xn--p1ai": TLDCountry,
+		"tk":       TLDHighAbuse,
+		"top":      TLDHighAbuse,
+		"google":   TLDBrand,
+		"app":      TLDGeneric,
+		"shop":     TLDGeneric,
+	}
+	for tld, want := range tests {
+		if got := TLDCategoryOf(tld); got != want {
+			t.Errorf("TLDCategoryOf(%q) = %s, want %s", tld, got, want)
+		}
+	}
+}
+
+func TestTLDPolicy(t *testing.T) {
+	v := New(WithTLDPolicy(TLDHighAbuse, PolicyBlock), WithTLDPolicy(TLDBrand, PolicyWarn))
+
+	r := v.Validate("jane@example.tk")
+	if r.IsValid || !errors.Is(r.Err(), ErrTLDNotAllowed) || r.Errors[0].Params["category"] != "high_abuse" {
+		t.Errorf("tk: valid %v, errors %v", r.IsValid, r.Errors)
+	}
+	r = v.Validate("jane@mail.google")
+	if !r.IsValid || r.Reason() != ReasonUnusualTLD {
+		t.Errorf("google: valid %v, reasons %v", r.IsValid, r.Reasons())
+	}
+	if r := v.Validate("jane@example.app"); !r.IsValid || len(r.Reasons()) != 0 {
+		t.Errorf("app: valid %v, reasons %v", r.IsValid, r.Reasons())
+	}
+
+	// With copies the policies rather than changing the original
+	v.With(WithTLDPolicy(TLDGeneric, PolicyBlock))
+	if !v.Validate("jane@example.app").IsValid {
+		t.Error("With changed the policies of the original")
+	}
+}
+
+func TestTLDPolicyConfig(t *testing.T) {
+	t.Setenv("EMAILVALIDATOR_TLD_POLICY", "country=warn, generic=block")
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TLDPolicy["country"] != "warn" || cfg.TLDPolicy["generic"] != "block" {
+		t.Fatalf("TLDPolicy = %v", cfg.TLDPolicy)
+	}
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Validate("jane@example.app").IsValid {
+		t.Error("generic TLD accepted")
+	}
+
+	cfg.TLDPolicy = map[string]string{"exotic": "block"}
+	if _, err := cfg.Options(); err == nil {
+		t.Error("unknown category accepted")
+	}
+}
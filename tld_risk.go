@@ -0,0 +1,72 @@
+package emailvalidator
+
+import "strings"
+
+// DefaultTLDRisk rates top-level domains with high abuse rates from 0 (no
+// more abuse than average) to 1, after the phishing and spam statistics
+// published by Spamhaus, Interisle and the Anti-Phishing Working Group.
+// Unlisted TLDs have no risk.
+var DefaultTLDRisk = map[string]float64{
+	// Free or abandoned ccTLDs of the former Freenom registry
+	"tk": 1,
+	"ml": 1,
+	"ga": 1,
+	"cf": 1,
+	"gq": 1,
+	// Cheap new gTLDs topping abuse rankings
+	"top":        0.8,
+	"xyz":        0.6,
+	"buzz":       0.8,
+	"cyou":       0.8,
+	"icu":        0.8,
+	"sbs":        0.8,
+	"cfd":        0.8,
+	"bond":       0.8,
+	"rest":       0.7,
+	"monster":    0.7,
+	"quest":      0.6,
+	"cam":        0.6,
+	"surf":       0.6,
+	"click":      0.6,
+	"link":       0.5,
+	"work":       0.5,
+	"online":     0.4,
+	"site":       0.4,
+	"store":      0.3,
+	"shop":       0.3,
+	"live":       0.3,
+	"zip":        0.5,
+	"mov":        0.5,
+	"country":    0.7,
+	"gdn":        0.7,
+	"win":        0.6,
+	"bid":        0.6,
+	"loan":       0.7,
+	"men":        0.6,
+	"date":       0.6,
+	"review":     0.6,
+	"stream":     0.6,
+	"download":   0.6,
+	"racing":     0.6,
+	"accountant": 0.6,
+}
+
+// mergeTLDRisk returns DefaultTLDRisk with the weights of overrides, whose
+// TLDs are lower-cased and stripped of a leading dot
+func mergeTLDRisk(overrides map[string]float64) map[string]float64 {
+	table := make(map[string]float64, len(DefaultTLDRisk)+len(overrides))
+	for tld, risk := range DefaultTLDRisk {
+		table[tld] = risk
+	}
+	for tld, risk := range overrides {
+		table[strings.TrimPrefix(strings.ToLower(tld), ".")] = risk
+	}
+	return table
+}
+
+// tldRisk returns the risk of the top-level domain of the lower-cased
+// domain in table
+func tldRisk(table map[string]float64, lowerDomain string) float64 {
+	tld := lowerDomain[strings.LastIndexByte(lowerDomain, '.')+1:]
+	return table[tld]
+}
@@ -0,0 +1,38 @@
+package emailvalidator
+
+import "testing"
+
+func TestTLDRisk(t *testing.T) {
+	v := New(WithTLDRisk(map[string]float64{".XYZ": 0, "example": 0.5}))
+	tests := []struct {
+		email string
+		risk  float64
+		score float64
+	}{
+		{"jane@example.tk", 1, 70},
+		{"jane@example.xyz", 0, 100},
+		{"jane@example.com", 0, 100},
+		{"jane@mail.example", 0.5, 85},
+	}
+	for _, tt := range tests {
+		r := v.Validate(tt.email)
+		if r.TLDRisk != tt.risk || r.Score != tt.score {
+			t.Errorf("%s: risk %v, score %v; want %v, %v", tt.email, r.TLDRisk, r.Score, tt.risk, tt.score)
+		}
+	}
+
+	if r := New().Validate("jane@example.tk"); r.TLDRisk != 0 || r.Score != 100 {
+		t.Errorf("without WithTLDRisk: risk %v, score %v", r.TLDRisk, r.Score)
+	}
+}
+
+func TestTLDRiskFromEnv(t *testing.T) {
+	t.Setenv("EMAILVALIDATOR_TLD_RISK", "xyz=0.2, tk=0.9")
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TLDRisk["xyz"] != 0.2 || cfg.TLDRisk["tk"] != 0.9 {
+		t.Errorf("TLDRisk = %v", cfg.TLDRisk)
+	}
+}
@@ -0,0 +1,47 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"net/netip"
+	"unicode/utf8"
+)
+
+// DefaultMinTLDLength is the shortest top-level domain accepted by default.
+// No delegated TLD has a single character.
+const DefaultMinTLDLength = 2
+
+// validateTLD checks the last label of the domain, which starts at byte
+// offset base of the address. An all-numeric TLD is only accepted for a
+// dotted IPv4 address in IP address mode, so that "user@example.123" is
+// told apart from a malformed IP address.
+func (v *EmailValidator) validateTLD(p ParsedEmail, base int) error {
+	tld := p.TLD
+	offset := base + len(p.Domain) - len(tld)
+	if isNumeric(tld) && !v.isIPDomain(p.Domain) {
+		return newError(CodeTLDNumeric, FieldTLD, ErrInvalidDomain, "top-level domain cannot be all-numeric").withParam("tld", tld).at(offset, tld)
+	}
+	if n := utf8.RuneCountInString(tld); n < v.minTLDLength && !isNumeric(tld) {
+		return newError(CodeTLDTooShort, FieldTLD, ErrInvalidDomain, fmt.Sprintf("top-level domain too short (min %d characters)", v.minTLDLength)).withParam("min", v.minTLDLength).at(offset, tld)
+	}
+	return nil
+}
+
+// isIPDomain reports whether domain is an IPv4 address accepted in IP
+// address mode
+func (v *EmailValidator) isIPDomain(domain string) bool {
+	if !v.allowIPAddresses {
+		return false
+	}
+	addr, err := netip.ParseAddr(domain)
+	return err == nil && addr.Is4()
+}
+
+// isNumeric reports whether s is a non-empty run of ASCII digits
+func isNumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
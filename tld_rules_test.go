@@ -0,0 +1,52 @@
+package emailvalidator
+
+import "testing"
+
+func TestTLDRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      *EmailValidator
+		email  string
+		code   ErrorCode
+		offset int
+	}{
+		{"one letter", New(), "a@b.c", CodeTLDTooShort, 4},
+		{"numeric", New(), "user@example.123", CodeTLDNumeric, 13},
+		{"numeric in strict mode", NewStrict(), "user@example.123", CodeTLDNumeric, 13},
+		{"IPv4 without IP mode", New(), "user@192.168.2.1", CodeTLDNumeric, 15},
+		{"IPv4 in IP mode", New(WithIPAddresses(true)), "user@192.168.2.1", "", 0},
+		{"not an IPv4 in IP mode", New(WithIPAddresses(true)), "user@example.123", CodeTLDNumeric, 13},
+		{"minimum raised", New(WithMinTLDLength(3)), "jane@example.io", CodeTLDTooShort, 13},
+		{"minimum lowered", New(WithMinTLDLength(1)), "a@b.c", "", 0},
+		{"IDN", New(), "jane@example.xn--p1ai", "", 0},
+	}
+	for _, tt := range tests {
+		r := tt.v.Validate(tt.email)
+		if tt.code == "" {
+			if !r.IsValid {
+				t.Errorf("%s: unexpected errors %v", tt.name, r.Errors)
+			}
+			continue
+		}
+		if len(r.Errors) == 0 {
+			t.Errorf("%s: expected %s", tt.name, tt.code)
+			continue
+		}
+		if got := r.Errors[0]; got.Code != tt.code || got.Field != FieldTLD || got.Offset != tt.offset {
+			t.Errorf("%s: got %s at %d in %s, want %s at %d", tt.name, got.Code, got.Offset, got.Field, tt.code, tt.offset)
+		}
+		if tt.v.IsValidSyntax(tt.email) {
+			t.Errorf("%s: IsValidSyntax accepts %s", tt.name, tt.email)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.MinTLDLength = 3
+	v, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.IsValidSyntax("jane@example.io") {
+		t.Error("configured minimum TLD length not applied")
+	}
+}
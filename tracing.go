@@ -0,0 +1,30 @@
+package emailvalidator
+
+import "context"
+
+// Tracer starts the spans recorded around validations by a validator
+// configured WithTracer. It mirrors the subset of the OpenTelemetry API
+// used here; the oteltrace package adapts an OpenTelemetry tracer.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Span names and attribute keys. The address itself is never recorded.
+const (
+	SpanValidate = "emailvalidator.Validate"
+	SpanDNS      = "emailvalidator.DNS"
+
+	AttrDomain   = "email.domain"
+	AttrVerdict  = "emailvalidator.verdict"
+	AttrValid    = "emailvalidator.valid"
+	AttrScore    = "emailvalidator.score"
+	AttrStatus   = "emailvalidator.status"
+	AttrCacheHit = "emailvalidator.cache_hit"
+)
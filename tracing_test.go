@@ -0,0 +1,61 @@
+package emailvalidator
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// recordingTracer keeps the spans it starts
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name   string
+	parent *recordedSpan
+	attrs  map[string]interface{}
+	ended  bool
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	span := &recordedSpan{name: name, parent: parent, attrs: make(map[string]interface{})}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *recordedSpan) RecordError(err error)                      {}
+func (s *recordedSpan) End()                                       { s.ended = true }
+
+func TestTracer(t *testing.T) {
+	tracer := &recordingTracer{}
+	checker := NewDNSChecker().WithResolver(staticResolver{})
+	v := New(WithDNSCheck(checker), WithTracer(tracer)).Batch(nil)
+
+	v.Validate("jane@example.com")
+	v.Validate("john@example.com")
+
+	if len(tracer.spans) != 4 {
+		t.Fatalf("expected 4 spans, got %d", len(tracer.spans))
+	}
+	validate, dns := tracer.spans[0], tracer.spans[1]
+	if validate.name != SpanValidate || dns.name != SpanDNS || dns.parent != validate || !validate.ended || !dns.ended {
+		t.Fatalf("unexpected spans %+v %+v", validate, dns)
+	}
+	if validate.attrs[AttrVerdict] != "valid" || validate.attrs[AttrDomain] != "example.com" {
+		t.Errorf("unexpected validate attributes %v", validate.attrs)
+	}
+	if dns.attrs[AttrStatus] != "passed" || dns.attrs[AttrCacheHit] != false {
+		t.Errorf("unexpected DNS attributes %v", dns.attrs)
+	}
+	if tracer.spans[3].attrs[AttrCacheHit] != true {
+		t.Error("expected the second lookup of a domain to hit the memo")
+	}
+}
@@ -0,0 +1,77 @@
+package emailvalidator
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache is a bounded map whose entries expire. When full, it drops the
+// expired entries, or all of them if none has expired. It is safe for
+// concurrent use; a size of zero disables it. It counts its hits, misses
+// and evictions for the CacheStats of the checkers owning one.
+type ttlCache[K comparable, V any] struct {
+	size int
+	ttl  time.Duration
+
+	mu                      sync.Mutex
+	entries                 map[K]ttlEntry[V]
+	hits, misses, evictions uint64
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+func newTTLCache[K comparable, V any](size int, ttl time.Duration) *ttlCache[K, V] {
+	return &ttlCache[K, V]{size: size, ttl: ttl, entries: make(map[K]ttlEntry[V])}
+}
+
+// get returns the unexpired value of key
+func (c *ttlCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if ok && time.Now().After(e.expires) {
+		delete(c.entries, key)
+		c.evictions++
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	return e.value, true
+}
+
+// add stores value for key
+func (c *ttlCache[K, V]) add(key K, value V) {
+	if c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.entries) >= c.size {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+				c.evictions++
+			}
+		}
+		if len(c.entries) >= c.size {
+			c.evictions += uint64(len(c.entries))
+			c.entries = make(map[K]ttlEntry[V])
+		}
+	}
+	c.entries[key] = ttlEntry[V]{value: value, expires: now.Add(c.ttl)}
+}
+
+// stats returns a snapshot of the counters
+func (c *ttlCache[K, V]) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Entries: len(c.entries)}
+}
@@ -0,0 +1,40 @@
+package emailvalidator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTTLCacheStats(t *testing.T) {
+	c := newTTLCache[string, int](2, time.Hour)
+	c.get("a")
+	c.add("a", 1)
+	c.get("a")
+	c.add("b", 2)
+	// Full with nothing expired: everything is dropped
+	c.add("c", 3)
+	if got, want := c.stats(), (CacheStats{Hits: 1, Misses: 1, Evictions: 2, Entries: 1}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	expiring := newTTLCache[string, int](2, -time.Second)
+	expiring.add("a", 1)
+	if _, ok := expiring.get("a"); ok {
+		t.Fatal("expired entry returned")
+	}
+	if got, want := expiring.stats(), (CacheStats{Misses: 1, Evictions: 1}); got != want {
+		t.Fatalf("expired: got %+v, want %+v", got, want)
+	}
+}
+
+func TestCheckerCacheStats(t *testing.T) {
+	checker := NewDomainReputationChecker(WithReputationResolver(&reputationResolver{}), WithDNSBLs(), WithDomainAge(nil))
+	checker.Reputation(context.Background(), "example.com")
+	checker.Reputation(context.Background(), "example.com")
+
+	var metrics CacheMetrics = checker
+	if got := metrics.CacheStats(); got.Hits != 1 || got.Misses != 1 || got.Entries != 1 {
+		t.Fatalf("got %+v", got)
+	}
+}
@@ -7,17 +7,23 @@ import (
 // IsDisposableEmail checks if email is from common disposable email providers
 func (v *EmailValidator) IsDisposableEmail(email string) bool {
 	_, domain := v.splitEmail(email)
-	
-	disposableDomains := map[string]bool{
-		"tempmail.com":     true,
-		"guerrillamail.com": true,
-		"mailinator.com":   true,
-		"10minutemail.com": true,
-		"yopmail.com":      true,
-		"throwawaymail.com": true,
-	}
-	
-	return disposableDomains[strings.ToLower(domain)]
+	return isDisposableDomain(strings.ToLower(domain))
+}
+
+// disposableDomains lists common disposable email providers
+var disposableDomains = NewDomainSet(
+	"tempmail.com",
+	"guerrillamail.com",
+	"mailinator.com",
+	"10minutemail.com",
+	"yopmail.com",
+	"throwawaymail.com",
+)
+
+// isDisposableDomain reports whether the lower-cased domain belongs to a
+// disposable email provider
+func isDisposableDomain(lowerDomain string) bool {
+	return disposableDomains.Contains(lowerDomain)
 }
 
 // ExtractDomain extracts domain from email address
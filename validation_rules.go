@@ -1,6 +1,9 @@
 package emailvalidator
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // ValidationRule defines an interface for email validation rules
 type ValidationRule interface {
@@ -22,7 +25,7 @@ func NewFormatRule() *FormatRule {
 
 func (r *FormatRule) Validate(email string) error {
 	if !r.pattern.MatchString(email) {
-		return ValidationError{Rule: r.Name(), Message: "Invalid email format"}
+		return ValidationError{Code: CodeInvalidFormat, Field: FieldEmail, Rule: r.Name(), Offset: -1, Message: "Invalid email format", Err: ErrInvalidFormat}
 	}
 	return nil
 }
@@ -39,21 +42,21 @@ func NewLengthRule() *LengthRule {
 }
 
 func (r *LengthRule) Validate(email string) error {
-	if len(email) > 254 {
-		return ValidationError{Rule: r.Name(), Message: "Email too long (max 254 characters)"}
+	if len(email) > DefaultMaxLength {
+		return ValidationError{Code: CodeEmailTooLong, Field: FieldEmail, Rule: r.Name(), Offset: -1, Message: "Email too long (max 254 characters)", Err: ErrTooLong}
 	}
 	
 	parts := strings.Split(email, "@")
 	if len(parts) != 2 {
-		return ValidationError{Rule: r.Name(), Message: "Invalid email structure"}
+		return ValidationError{Code: CodeInvalidFormat, Field: FieldEmail, Rule: r.Name(), Offset: -1, Message: "Invalid email structure", Err: ErrInvalidFormat}
 	}
 	
-	if len(parts[0]) > 64 {
-		return ValidationError{Rule: r.Name(), Message: "Local part too long (max 64 characters)"}
+	if len(parts[0]) > DefaultMaxLocalPartLength {
+		return ValidationError{Code: CodeLocalPartTooLong, Field: FieldLocal, Rule: r.Name(), Offset: -1, Message: "Local part too long (max 64 characters)", Err: ErrTooLong}
 	}
 	
-	if len(parts[1]) > 253 {
-		return ValidationError{Rule: r.Name(), Message: "Domain too long (max 253 characters)"}
+	if len(parts[1]) > DefaultMaxDomainLength {
+		return ValidationError{Code: CodeDomainTooLong, Field: FieldDomain, Rule: r.Name(), Offset: -1, Message: "Domain too long (max 253 characters)", Err: ErrTooLong}
 	}
 	
 	return nil
@@ -68,20 +71,26 @@ type DisposableDomainRule struct {
 	disposableDomains map[string]bool
 }
 
+// NewDisposableDomainRule creates a rule for domains. The map is copied, so
+// later changes by the caller do not affect the rule.
 func NewDisposableDomainRule(domains map[string]bool) *DisposableDomainRule {
+	copied := make(map[string]bool, len(domains))
+	for domain, disposable := range domains {
+		copied[domain] = disposable
+	}
 	return &DisposableDomainRule{
-		disposableDomains: domains,
+		disposableDomains: copied,
 	}
 }
 
 func (r *DisposableDomainRule) Validate(email string) error {
 	parts := strings.Split(strings.ToLower(email), "@")
 	if len(parts) != 2 {
-		return ValidationError{Rule: r.Name(), Message: "Invalid email structure"}
+		return ValidationError{Code: CodeInvalidFormat, Field: FieldEmail, Rule: r.Name(), Offset: -1, Message: "Invalid email structure", Err: ErrInvalidFormat}
 	}
 	
 	if r.disposableDomains[parts[1]] {
-		return ValidationError{Rule: r.Name(), Message: "Disposable email addresses are not allowed"}
+		return ValidationError{Code: CodeDisposable, Field: FieldDomain, Rule: r.Name(), Offset: -1, Message: "Disposable email addresses are not allowed", Err: ErrDisposable}
 	}
 	
 	return nil
@@ -90,13 +99,3 @@ func (r *DisposableDomainRule) Validate(email string) error {
 func (r *DisposableDomainRule) Name() string {
 	return "disposable_domain_rule"
 }
-
-// ValidationError represents a validation error
-type ValidationError struct {
-	Rule    string
-	Message string
-}
-
-func (e ValidationError) Error() string {
-	return e.Message
-}
\ No newline at end of file
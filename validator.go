@@ -0,0 +1,59 @@
+package emailvalidator
+
+import "context"
+
+// Validator validates email addresses. It is implemented by EmailValidator
+// and Reloader; applications can depend on it and inject a fake, such as a
+// ValidatorFunc, in their own tests. Implementations must be safe for
+// concurrent use, since streams and bulk jobs share one across workers.
+type Validator interface {
+	Validate(email string) ValidationResult
+}
+
+// ContextValidator is a Validator whose network checks stop when ctx is
+// cancelled. Checks that could not complete are reported as errors of
+// their stage, as for any other lookup failure.
+type ContextValidator interface {
+	Validator
+	ValidateContext(ctx context.Context, email string) ValidationResult
+}
+
+// BatchValidator is a Validator that can derive a variant tuned for one
+// batch run, in which domain-level checks are shared between addresses
+// through memo
+type BatchValidator interface {
+	Validator
+	Batch(memo *DomainMemo) Validator
+}
+
+// Prefetcher is implemented by validators that can resolve the DNS outcome
+// of an address ahead of validation, so that a pipeline can overlap slow
+// lookups with CPU-bound checks. It is only useful on a validator returned
+// by Batch, whose lookups are memoized.
+type Prefetcher interface {
+	Prefetch(ctx context.Context, email string)
+}
+
+// ValidatorFunc adapts an ordinary function to the Validator interface
+type ValidatorFunc func(email string) ValidationResult
+
+// Validate calls f(email)
+func (f ValidatorFunc) Validate(email string) ValidationResult {
+	return f(email)
+}
+
+var (
+	_ Validator = (*EmailValidator)(nil)
+	_ Validator = (*Reloader)(nil)
+	_ Validator = ValidatorFunc(nil)
+
+	_ BatchValidator = (*EmailValidator)(nil)
+	_ BatchValidator = (*CachedValidator)(nil)
+
+	_ ContextValidator = (*EmailValidator)(nil)
+	_ ContextValidator = (*Reloader)(nil)
+	_ ContextValidator = (*CachedValidator)(nil)
+
+	_ Prefetcher = (*EmailValidator)(nil)
+	_ Prefetcher = (*CachedValidator)(nil)
+)
@@ -0,0 +1,104 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Severity ranks findings from informational to blocking. Optional checks
+// such as WithDisposableCheck report an error at SeverityError and a warning
+// of the given severity otherwise.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// String returns the severity name used in JSON output
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warn"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	parsed, err := ParseSeverity(name)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// ParseSeverity parses "info", "warn" (or "warning") and "error"
+func ParseSeverity(name string) (Severity, error) {
+	switch name {
+	case "info":
+		return SeverityInfo, nil
+	case "warn", "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return 0, fmt.Errorf("invalid severity %q", name)
+	}
+}
+
+// WarningCode is a stable, machine-readable identifier for a warning
+type WarningCode string
+
+// Warning codes reported in Warning.Code
+const (
+	WarnDisposable       WarningCode = "disposable"
+	WarnRoleAccount      WarningCode = "role_account"
+	WarnDNSUnavailable   WarningCode = "dns_unavailable"
+	WarnPrivacyRelay     WarningCode = "privacy_relay"
+	WarnTLDCategory      WarningCode = "tld_category"
+	WarnEmojiDomain      WarningCode = "emoji_domain"
+	WarnMixedScript      WarningCode = "mixed_script"
+	WarnLocalPartDots    WarningCode = "local_part_dots"
+	WarnDomainUnderscore WarningCode = "domain_underscore"
+)
+
+// Warning is a non-blocking finding about an address
+type Warning struct {
+	Code     WarningCode            `json:"code"`
+	Severity Severity               `json:"severity"`
+	Message  string                 `json:"message"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+}
+
+// newWarning builds a Warning with code and severity
+func newWarning(code WarningCode, severity Severity, message string) Warning {
+	return Warning{Code: code, Severity: severity, Message: message}
+}
+
+// withParam returns a copy of w with an additional parameter
+func (w Warning) withParam(key string, value interface{}) Warning {
+	params := make(map[string]interface{}, len(w.Params)+1)
+	for k, v := range w.Params {
+		params[k] = v
+	}
+	params[key] = value
+	w.Params = params
+	return w
+}
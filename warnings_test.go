@@ -0,0 +1,50 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWarningCodesAndSeverity(t *testing.T) {
+	v := New(WithDisposableCheck(SeverityWarning))
+
+	result := v.Validate("admin@mailinator.com")
+	if !result.IsValid || len(result.Warnings) != 2 {
+		t.Fatalf("expected a valid result with two warnings, got %+v", result)
+	}
+
+	codes := map[WarningCode]Severity{}
+	for _, w := range result.Warnings {
+		codes[w.Code] = w.Severity
+	}
+	if codes[WarnDisposable] != SeverityWarning || codes[WarnRoleAccount] != SeverityInfo {
+		t.Errorf("unexpected warning severities: %v", codes)
+	}
+
+	filtered := result.WarningsAtLeast(SeverityWarning)
+	if len(filtered) != 1 || filtered[0].Code != WarnDisposable {
+		t.Errorf("expected only the disposable warning, got %+v", filtered)
+	}
+}
+
+func TestSeverityOrder(t *testing.T) {
+	if !(SeverityInfo < SeverityWarning && SeverityWarning < SeverityError) {
+		t.Fatalf("severities out of order: info %d, warning %d, error %d", SeverityInfo, SeverityWarning, SeverityError)
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	data, err := json.Marshal(newWarning(WarnRoleAccount, SeverityInfo, "Role-based account detected"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"severity":"info"`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var w Warning
+	if err := json.Unmarshal(data, &w); err != nil || w.Severity != SeverityInfo {
+		t.Errorf("round trip failed: %+v, %v", w, err)
+	}
+}
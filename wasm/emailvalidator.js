@@ -0,0 +1,34 @@
+// JavaScript bindings for emailvalidator.wasm. Load Go's wasm_exec.js
+// first, which defines the Go class.
+
+let api;
+
+// load instantiates the module once and returns the bindings
+export async function load(url = new URL("emailvalidator.wasm", import.meta.url)) {
+  if (!api) {
+    const go = new Go();
+    const { instance } = await WebAssembly.instantiateStreaming(fetch(url), go.importObject);
+    go.run(instance);
+    api = globalThis.__emailvalidator;
+  }
+  return { validator };
+}
+
+// validator returns a validator configured like emailvalidator.Config,
+// e.g. { strict_mode: true, disposable_check: "error" }
+function validator(config = {}) {
+  const v = api.newValidator(JSON.stringify(config));
+  if (v instanceof Error) {
+    throw v;
+  }
+  return {
+    // validate returns the ValidationResult of email as a plain object
+    validate(email) {
+      const result = v.validate(String(email));
+      if (result instanceof Error) {
+        throw result;
+      }
+      return JSON.parse(result);
+    },
+  };
+}
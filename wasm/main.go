@@ -0,0 +1,65 @@
+//go:build js && wasm
+
+// Command wasm exposes the offline checks of the validator (syntax, typo
+// suggestions, disposable and blocked domains) to JavaScript, so that
+// frontends validate exactly like the backend. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o emailvalidator.wasm ./wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// and load it through emailvalidator.js:
+//
+//	import { load } from "./emailvalidator.js";
+//	const validator = (await load()).validator({ disposable_check: "error" });
+//	validator.validate("jane@gmial.com").suggestion; // "jane@gmail.com"
+//
+// Validators are configured with the JSON encoding of
+// emailvalidator.Config. Checks making network requests, such as DNS,
+// Gravatar or breach lookups, are not available in the browser.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"syscall/js"
+
+	"github.com/LBFmuraiybatu/email_validator/internal/offline"
+)
+
+func main() {
+	js.Global().Set("__emailvalidator", js.ValueOf(map[string]interface{}{
+		"newValidator": js.FuncOf(newValidator),
+	}))
+	// Keep the exported functions alive
+	select {}
+}
+
+// newValidator builds a validator from a JSON configuration and returns an
+// object with its validate function, or an Error
+func newValidator(this js.Value, args []js.Value) interface{} {
+	config := "{}"
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		config = args[0].String()
+	}
+	v, err := offline.FromConfig(config)
+	if err != nil {
+		return jsError(err)
+	}
+
+	return js.ValueOf(map[string]interface{}{
+		"validate": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 || args[0].Type() != js.TypeString {
+				return jsError(errors.New("validate expects a string"))
+			}
+			data, err := json.Marshal(v.Validate(args[0].String()))
+			if err != nil {
+				return jsError(err)
+			}
+			return string(data)
+		}),
+	})
+}
+
+func jsError(err error) js.Value {
+	return js.Global().Get("Error").New(err.Error())
+}